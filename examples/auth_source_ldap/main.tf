@@ -0,0 +1,52 @@
+variable "client_username" {}
+variable "client_password" {}
+variable "ldap_bind_password" {}
+
+provider "foreman" {
+  server_hostname = "192.168.1.118"
+  server_protocol = "https"
+
+  client_tls_insecure = true
+
+  client_username = "${var.client_username}"
+  client_password = "${var.client_password}"
+}
+
+resource "foreman_auth_source_ldap" "corp" {
+    name = "corp-ldap"
+    host = "ldap.example.com"
+    tls = true
+    server_type = "free_ipa"
+
+    base_dn = "cn=users,cn=accounts,dc=example,dc=com"
+    groups_base = "cn=groups,cn=accounts,dc=example,dc=com"
+
+    account = "uid=foreman,cn=sysaccounts,cn=etc,dc=example,dc=com"
+    account_password = var.ldap_bind_password
+
+    attr_login = "uid"
+    attr_firstname = "givenName"
+    attr_lastname = "sn"
+    attr_mail = "mail"
+
+    onthefly_register = true
+
+    # Fail the apply if Foreman cannot bind to the server
+    test_connection = true
+}
+
+# Users and external usergroups reference the authentication source by its ID
+resource "foreman_usergroup" "admins" {
+    name = "ldap-admins"
+    admin = true
+
+    external_usergroup {
+        name = "foreman-admins"
+        auth_source_id = foreman_auth_source_ldap.corp.id
+    }
+}
+
+resource "foreman_user" "jdoe" {
+    login = "jdoe"
+    auth_source_id = foreman_auth_source_ldap.corp.id
+}
@@ -57,8 +57,27 @@ resource "foreman_host" "TerraformTest" {
   owner_id   = data.foreman_usergroup.root.id
   owner_type = "Usergroup"
 
+  power_state = "on"
+
+  # Reinstall the host whenever the kickstart template changes
+  rebuild_triggers = {
+    kickstart = filesha256("templates/kickstart.erb")
+  }
+
+  # Dependent resources are only created once the host is installed
+  wait_for_build = true
+  timeouts {
+    create = "90m"
+  }
+
   parameters = {
-    role = "postgresql"
+    role       = "postgresql"
+    backup     = "true"
+    admin_keys = jsonencode(["ssh-ed25519 AAAA..."])
+  }
+  parameter_types = {
+    backup     = "boolean"
+    admin_keys = "array"
   }
 
 // Example uses vSphere compute attributes
@@ -98,3 +117,54 @@ EOF
     }
   }
 }
+
+// Bare-metal host with an LACP bond and a tagged VLAN on top of it
+resource "foreman_host" "baremetal" {
+  name = "baremetal01"
+
+  hostgroup_id       = data.foreman_hostgroup.app.id
+  operatingsystem_id = data.foreman_operatingsystem.rhel7.id
+  enable_bmc         = true
+
+  interfaces_attributes {
+    type       = "interface"
+    identifier = "eno1"
+    mac        = "c0:ff:ee:00:00:01"
+    provision  = true
+    managed    = true
+  }
+  interfaces_attributes {
+    type       = "interface"
+    identifier = "eno2"
+    mac        = "c0:ff:ee:00:00:02"
+    managed    = true
+  }
+  interfaces_attributes {
+    type             = "bond"
+    identifier       = "bond0"
+    mode             = "802.3ad"
+    bond_options     = "miimon=100 lacp_rate=fast"
+    attached_devices = "eno1,eno2"
+    mtu              = 9000
+    managed          = true
+  }
+  interfaces_attributes {
+    type        = "interface"
+    identifier  = "bond0.170"
+    virtual     = true
+    attached_to = "bond0"
+    tag         = 170
+    subnet_id   = data.foreman_subnet.app1.id
+    primary     = true
+    managed     = true
+  }
+  interfaces_attributes {
+    type         = "bmc"
+    identifier   = "ipmi"
+    mac          = "c0:ff:ee:00:00:03"
+    bmc_provider = "IPMI"
+    username     = "admin"
+    password     = "changeme"
+    managed      = true
+  }
+}
@@ -11,11 +11,25 @@ provider "foreman" {
   client_password = "${var.client_password}"
 }
 
+variable "proxy_password" {}
+
 resource "foreman_httpproxy" "example" {
   name = "proxy.company.com"
   url = "https://proxy.company.com:8443"
 }
 
+resource "foreman_httpproxy" "authenticated" {
+  name = "auth-proxy.company.com"
+  url = "https://auth-proxy.company.com:8443"
+  username = "foreman"
+  password = "${var.proxy_password}"
+
+  test_connection = true
+
+  location_ids = [2]
+  organization_ids = [1]
+}
+
 data "foreman_httpproxy" "httpproxy" {
   name = "proxy.company.com"
 }
\ No newline at end of file
@@ -0,0 +1,34 @@
+variable "client_username" {}
+variable "client_password" {}
+
+provider "foreman" {
+  server_hostname = "192.168.1.118"
+  server_protocol = "https"
+
+  client_tls_insecure = true
+
+  client_username = "${var.client_username}"
+  client_password = "${var.client_password}"
+}
+
+resource "foreman_location" "europe" {
+	name = "Europe"
+}
+
+resource "foreman_location" "dc1" {
+	name = "DC1"
+	description = "First data center in Frankfurt"
+	parent_id = foreman_location.europe.id
+
+	organization_ids = [1, 12]
+	domain_ids = [39]
+	subnet_ids = [5, 6]
+	smart_proxy_ids = [1]
+	compute_resource_ids = [2]
+}
+
+# Nested locations may share their name - use the title to tell them apart
+data "foreman_location" "dc1" {
+	name = "DC1"
+	title = "Europe/DC1"
+}
@@ -0,0 +1,26 @@
+variable "client_username" {}
+variable "client_password" {}
+
+provider "foreman" {
+  server_hostname = "192.168.1.118"
+  server_protocol = "https"
+
+  client_tls_insecure = true
+
+  client_username = "${var.client_username}"
+  client_password = "${var.client_password}"
+}
+
+data "foreman_organization" "default" {
+	name = "Default Organization"
+}
+
+resource "foreman_organization" "tenant_a" {
+	name = "Tenant A"
+	description = "Organization of tenant A"
+	parent_id = data.foreman_organization.default.id
+
+	location_ids = [2]
+	domain_ids = [39]
+	subnet_ids = [5, 6]
+}
@@ -0,0 +1,61 @@
+variable "client_username" {}
+variable "client_password" {}
+
+provider "foreman" {
+  server_hostname = "192.168.1.118"
+  server_protocol = "https"
+
+  client_tls_insecure = true
+
+  client_username = "${var.client_username}"
+  client_password = "${var.client_password}"
+}
+
+# Builtin roles cannot be changed - clone them to extend their permissions
+data "foreman_role" "viewer" {
+    name = "Viewer"
+}
+
+resource "foreman_role" "web_operators" {
+    name = "Web Operators"
+    description = "Viewer which may also power and rebuild web servers"
+    clone_from_id = data.foreman_role.viewer.id
+}
+
+resource "foreman_filter" "web_hosts" {
+    role_id = foreman_role.web_operators.id
+    permissions = ["power_hosts", "build_hosts"]
+    search = "hostgroup = web"
+}
+
+# Filters may be limited to other locations and organizations than their role
+resource "foreman_filter" "dc1_hostgroups" {
+    role_id = foreman_role.web_operators.id
+    resource_type = "Hostgroup"
+    permissions = ["edit_hostgroups"]
+    override = true
+    location_ids = [2]
+}
+
+# Resolve a permission name to its ID and resource type
+data "foreman_permission" "view_hosts" {
+    name = "view_hosts"
+}
+
+output "permission_view_hosts" {
+    value = data.foreman_permission.view_hosts
+}
+
+# Result:
+# permission_view_hosts = {
+#   __meta__      = null
+#   id            = "74"
+#   name          = "view_hosts"
+#   resource_type = "Host"
+# }
+
+# Grant the role to a usergroup
+resource "foreman_usergroup" "web_operators" {
+    name = "web-operators"
+    role_ids = [foreman_role.web_operators.id]
+}
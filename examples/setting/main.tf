@@ -11,7 +11,7 @@ provider "foreman" {
   client_password = "${var.client_password}"
 }
 
-# Read the data resource (it's read-only at the moment)
+# Read the data resource
 data "foreman_setting" "append_domain" {
     name = "append_domain_name_for_hosts"
 }
@@ -33,3 +33,14 @@ output "setting_append_domain" {
 #   settings_type = "boolean"
 #   value         = "true"
 # }
+
+# Manage settings with the resource. Destroying it restores the default value.
+resource "foreman_setting" "safemode_render" {
+    name = "safemode_render"
+    value = "true"
+}
+
+resource "foreman_setting" "trusted_hosts" {
+    name = "trusted_hosts"
+    value = jsonencode(["proxy1.example.com", "proxy2.example.com"])
+}
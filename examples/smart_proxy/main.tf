@@ -19,3 +19,36 @@ resource "foreman_smartproxy" "terraformtest" {
 	name = "terraformtestproxy.dc1.company.com"
 	url  = "https://terraformtestproxy.dc1.company.com"
 }
+
+variable "proxy_version" {
+	default = "3.9.1"
+}
+
+# Refreshes the features of the proxy whenever it is upgraded
+resource "foreman_smartproxy" "dhcp" {
+	name = "dhcp.dc1.company.com"
+	url  = "https://dhcp.dc1.company.com:8443"
+
+	refresh_on_change = {
+		version = var.proxy_version
+	}
+}
+
+data "foreman_smartproxy_status" "dhcp" {
+	name = foreman_smartproxy.dhcp.name
+}
+
+resource "foreman_subnet" "dhcp" {
+	name    = "10.228.161.0 DC1"
+	network = "10.228.161.0"
+	mask    = "255.255.255.0"
+	ipam    = "DHCP"
+	dhcp_id = foreman_smartproxy.dhcp.id
+
+	lifecycle {
+		precondition {
+			condition     = data.foreman_smartproxy_status.dhcp.healthy && contains(keys(data.foreman_smartproxy_status.dhcp.features), "dhcp")
+			error_message = "The DHCP feature of the smart proxy does not work."
+		}
+	}
+}
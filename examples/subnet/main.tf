@@ -14,3 +14,26 @@ provider "foreman" {
 data "foreman_subnet" "DC1_VLAN24" {
   network = "10.228.159.0"
 }
+
+data "foreman_smartproxy" "ipam" {
+  name = "ipam.dev.dc1.company.com"
+}
+
+# Addresses are suggested by the external IPAM of the smart proxy, remote
+# jobs on the hosts of the subnet run through the same proxy
+resource "foreman_subnet" "DC1_VLAN25" {
+  name = "10.228.160.0 DC1"
+  network = "10.228.160.0"
+  mask = "255.255.255.0"
+  gateway = "10.228.160.1"
+
+  ipam = "External IPAM"
+  externalipam_id = data.foreman_smartproxy.ipam.id
+  dns_id = data.foreman_smartproxy.ipam.id
+  discovery_id = data.foreman_smartproxy.ipam.id
+  remote_execution_proxy_ids = [data.foreman_smartproxy.ipam.id]
+
+  parameters = {
+    ntp_server = "ntp.dev.dc1.company.com"
+  }
+}
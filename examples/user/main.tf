@@ -0,0 +1,36 @@
+variable "client_username" {}
+variable "client_password" {}
+
+provider "foreman" {
+  server_hostname = "192.168.1.118"
+  server_protocol = "https"
+
+  client_tls_insecure = true
+
+  client_username = "${var.client_username}"
+  client_password = "${var.client_password}"
+}
+
+data "foreman_role" "viewer" {
+    name = "Viewer"
+}
+
+resource "foreman_user" "ci" {
+    login = "ci"
+    mail = "ci@example.com"
+    mail_enabled = false
+    auth_source_id = 1
+    password = "changeme123"
+    role_ids = [data.foreman_role.viewer.id]
+}
+
+resource "foreman_personal_access_token" "ci" {
+    user_id = foreman_user.ci.id
+    name = "ci-pipeline"
+    expires_at = "2027-01-01T00:00:00Z"
+}
+
+output "ci_token" {
+    value = foreman_personal_access_token.ci.token
+    sensitive = true
+}
@@ -198,52 +198,8 @@ func (c *Client) DeleteArchitecture(ctx context.Context, id int) error {
 func (c *Client) QueryArchitecture(ctx context.Context, a *ForemanArchitecture) (QueryResponse, error) {
 	log.Tracef("foreman/api/architecture.go#Search")
 
-	queryResponse := QueryResponse{}
-
 	reqEndpoint := fmt.Sprintf("/%s", ArchitectureEndpointPrefix)
-	req, reqErr := c.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		reqEndpoint,
-		nil,
-	)
-	if reqErr != nil {
-		return queryResponse, reqErr
-	}
-
-	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
-	name := `"` + a.Name + `"`
-	reqQuery.Set("search", "name="+name)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanArchitecture for
-	// the results
-	results := []ForemanArchitecture{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanArchitecture to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return searchQueryResponse[ForemanArchitecture](ctx, c, reqEndpoint, SearchQuery{
+		Search: SearchEquals("name", a.Name),
+	})
 }
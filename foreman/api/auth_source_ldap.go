@@ -0,0 +1,264 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	AuthSourceLDAPEndpointPrefix = "auth_source_ldaps"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanAuthSourceLDAP API model represents an LDAP server users
+// authenticate against.  Users of the authentication source reference it by
+// its ID (auth_source_id).
+type ForemanAuthSourceLDAP struct {
+	// Inherits the base object's attributes
+	ForemanObject
+	// Locations and organizations the authentication source is assigned to
+	ForemanTaxonomies
+
+	// Hostname or IP address of the LDAP server
+	Host string `json:"host"`
+	// Port of the LDAP server.  Foreman defaults to 389, or 636 with TLS.
+	Port int `json:"port,omitempty"`
+	// Whether to connect with LDAPS
+	TLS bool `json:"tls"`
+	// Type of the LDAP server: "posix", "free_ipa" or "active_directory"
+	ServerType string `json:"server_type"`
+	// Base DN the users are searched in
+	BaseDN string `json:"base_dn"`
+	// Base DN the groups are searched in
+	GroupsBase string `json:"groups_base"`
+	// Additional LDAP filter applied to the user search
+	LDAPFilter string `json:"ldap_filter"`
+	// Whether to use netgroups instead of groups
+	UseNetgroups bool `json:"use_netgroups"`
+
+	// Account used to bind to the LDAP server.  Use "$login" to bind with
+	// the credentials of the authenticating user.
+	Account string `json:"account"`
+	// Password of the bind account.  Never returned by the API.
+	AccountPassword string `json:"account_password,omitempty"`
+
+	// LDAP attributes mapped to the attributes of the users
+	AttrLogin     string `json:"attr_login"`
+	AttrFirstname string `json:"attr_firstname"`
+	AttrLastname  string `json:"attr_lastname"`
+	AttrMail      string `json:"attr_mail"`
+	AttrPhoto     string `json:"attr_photo"`
+
+	// Whether users are created on their first login
+	OntheflyRegister bool `json:"onthefly_register"`
+	// Whether the members of external usergroups are synchronized on login
+	UsergroupSync bool `json:"usergroup_sync"`
+}
+
+// ForemanAuthSourceLDAPTestResult is the result of the connection test of an
+// authentication source
+type ForemanAuthSourceLDAPTestResult struct {
+	// Whether Foreman was able to connect and bind to the LDAP server
+	Success bool `json:"success"`
+	// Message describing the result
+	Message string `json:"message"`
+}
+
+// Implement the Unmarshaler interface
+func (fa *ForemanAuthSourceLDAP) UnmarshalJSON(b []byte) error {
+	// Decode the authentication source properties with the default decoder -
+	// the type conversion drops this method to avoid the recursion
+	type foremanAuthSourceLDAP ForemanAuthSourceLDAP
+	var obj foremanAuthSourceLDAP
+	jsonDecErr := json.Unmarshal(b, &obj)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	*fa = ForemanAuthSourceLDAP(obj)
+
+	// Unmarshal the assigned locations and organizations
+	fa.ForemanTaxonomies, jsonDecErr = unmarshalForemanTaxonomies(b)
+	return jsonDecErr
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateAuthSourceLDAP creates a new ForemanAuthSourceLDAP with the
+// attributes of the supplied ForemanAuthSourceLDAP reference and returns the
+// created ForemanAuthSourceLDAP reference.  The returned reference will have
+// its ID and other API default values set by this function.
+func (c *Client) CreateAuthSourceLDAP(ctx context.Context, a *ForemanAuthSourceLDAP) (*ForemanAuthSourceLDAP, error) {
+	log.Tracef("foreman/api/auth_source_ldap.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", AuthSourceLDAPEndpointPrefix)
+
+	aJSONBytes, jsonEncErr := c.WrapJSONWithTaxonomy("auth_source_ldap", a)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	// NOTE(ALL): the request is not logged, it contains the account password.
+	//   WrapJSONWithTaxonomy only logs it with the password redacted.
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(aJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdAuthSourceLDAP ForemanAuthSourceLDAP
+	sendErr := c.SendAndParse(req, &createdAuthSourceLDAP)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdAuthSourceLDAP: [%+v]", createdAuthSourceLDAP)
+
+	return &createdAuthSourceLDAP, nil
+}
+
+// ReadAuthSourceLDAP reads the attributes of a ForemanAuthSourceLDAP
+// identified by the supplied ID and returns a ForemanAuthSourceLDAP
+// reference.
+func (c *Client) ReadAuthSourceLDAP(ctx context.Context, id int) (*ForemanAuthSourceLDAP, error) {
+	log.Tracef("foreman/api/auth_source_ldap.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", AuthSourceLDAPEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readAuthSourceLDAP ForemanAuthSourceLDAP
+	sendErr := c.SendAndParse(req, &readAuthSourceLDAP)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readAuthSourceLDAP: [%+v]", readAuthSourceLDAP)
+
+	return &readAuthSourceLDAP, nil
+}
+
+// UpdateAuthSourceLDAP updates a ForemanAuthSourceLDAP's attributes.  The
+// authentication source with the ID of the supplied ForemanAuthSourceLDAP
+// will be updated. A new ForemanAuthSourceLDAP reference is returned with the
+// attributes from the result of the update operation.
+func (c *Client) UpdateAuthSourceLDAP(ctx context.Context, a *ForemanAuthSourceLDAP) (*ForemanAuthSourceLDAP, error) {
+	log.Tracef("foreman/api/auth_source_ldap.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", AuthSourceLDAPEndpointPrefix, a.Id)
+
+	aJSONBytes, jsonEncErr := c.WrapJSONWithTaxonomy("auth_source_ldap", a)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	// NOTE(ALL): the request is not logged, it contains the account password.
+	//   WrapJSONWithTaxonomy only logs it with the password redacted.
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(aJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedAuthSourceLDAP ForemanAuthSourceLDAP
+	sendErr := c.SendAndParse(req, &updatedAuthSourceLDAP)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedAuthSourceLDAP: [%+v]", updatedAuthSourceLDAP)
+
+	return &updatedAuthSourceLDAP, nil
+}
+
+// DeleteAuthSourceLDAP deletes the ForemanAuthSourceLDAP identified by the
+// supplied ID
+func (c *Client) DeleteAuthSourceLDAP(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/auth_source_ldap.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", AuthSourceLDAPEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// TestAuthSourceLDAP tests the connection to the LDAP server of the
+// ForemanAuthSourceLDAP identified by the supplied ID
+func (c *Client) TestAuthSourceLDAP(ctx context.Context, id int) (*ForemanAuthSourceLDAPTestResult, error) {
+	log.Tracef("foreman/api/auth_source_ldap.go#Test")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/test", AuthSourceLDAPEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var testResult ForemanAuthSourceLDAPTestResult
+	sendErr := c.SendAndParse(req, &testResult)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("testResult: [%+v]", testResult)
+
+	return &testResult, nil
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryAuthSourceLDAP queries for a ForemanAuthSourceLDAP based on the
+// attributes of the supplied ForemanAuthSourceLDAP reference and returns a
+// QueryResponse struct containing query/response metadata and the matching
+// authentication sources.
+func (c *Client) QueryAuthSourceLDAP(ctx context.Context, a *ForemanAuthSourceLDAP) (QueryResponse, error) {
+	log.Tracef("foreman/api/auth_source_ldap.go#Search")
+
+	reqEndpoint := fmt.Sprintf("/%s", AuthSourceLDAPEndpointPrefix)
+	return searchQueryResponse[ForemanAuthSourceLDAP](ctx, c, reqEndpoint, SearchQuery{
+		Search: SearchEquals("name", a.Name),
+	})
+}
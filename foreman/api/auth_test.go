@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Authenticators
+// ----------------------------------------------------------------------------
+
+// Ensure the authenticators set the Authorization header of their method
+func TestAuthenticators_Header(t *testing.T) {
+	testCases := []struct {
+		authenticator Authenticator
+		expected      string
+	}{
+		{
+			authenticator: BasicAuthenticator{Username: "admin", Password: "changeme"},
+			expected:      "Basic YWRtaW46Y2hhbmdlbWU=",
+		},
+		{
+			authenticator: TokenAuthenticator{Token: "f0a1b2c3"},
+			expected:      "Bearer f0a1b2c3",
+		},
+	}
+
+	for _, testCase := range testCases {
+		req, _ := http.NewRequest(http.MethodGet, "https://foreman.example.com/api/hosts", nil)
+		if err := testCase.authenticator.Authenticate(req); err != nil {
+			t.Fatalf("%T returned an error: [%s]", testCase.authenticator, err)
+		}
+		if req.Header.Get("Authorization") != testCase.expected {
+			t.Fatalf(
+				"%T did not set the expected Authorization header. "+
+					"Expected [%s], got [%s].",
+				testCase.authenticator,
+				testCase.expected,
+				req.Header.Get("Authorization"),
+			)
+		}
+	}
+}
+
+// Ensure the OAuth signature of the request follows RFC 5849 and includes the
+// query parameters
+func TestOAuthAuthenticator_Signature(t *testing.T) {
+	authenticator := OAuthAuthenticator{
+		ConsumerKey:    "terraform",
+		ConsumerSecret: "s3cr3t!",
+		User:           "terraform-bot",
+		now:            func() time.Time { return time.Unix(1700000000, 0) },
+		nonce:          func() (string, error) { return "abc123", nil },
+	}
+
+	req, _ := http.NewRequest(
+		http.MethodGet,
+		"https://foreman.example.com:443/api/hosts?search=name+%3D+%22foo+bar%22&page=2",
+		nil,
+	)
+	if err := authenticator.Authenticate(req); err != nil {
+		t.Fatalf("OAuthAuthenticator returned an error: [%s]", err)
+	}
+
+	expected := `OAuth oauth_consumer_key="terraform", oauth_nonce="abc123", ` +
+		`oauth_signature="vaEEcZap4hhwQNJFDb%2BApMAAzRk%3D", ` +
+		`oauth_signature_method="HMAC-SHA1", oauth_timestamp="1700000000", ` +
+		`oauth_version="1.0"`
+	if req.Header.Get("Authorization") != expected {
+		t.Fatalf(
+			"OAuthAuthenticator did not set the expected Authorization header. "+
+				"Expected [%s], got [%s].",
+			expected,
+			req.Header.Get("Authorization"),
+		)
+	}
+	if req.Header.Get("FOREMAN-USER") != "terraform-bot" {
+		t.Fatalf(
+			"OAuthAuthenticator did not set the FOREMAN-USER header. Got [%s].",
+			req.Header.Get("FOREMAN-USER"),
+		)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Client
+// ----------------------------------------------------------------------------
+
+// Ensure the client authenticates every attempt of a request with the
+// configured authenticator
+func TestClient_AuthenticatesEveryAttempt(t *testing.T) {
+	nonces := 0
+	authenticator := OAuthAuthenticator{
+		ConsumerKey:    "terraform",
+		ConsumerSecret: "s3cr3t!",
+		nonce: func() (string, error) {
+			nonces++
+			return strings.Repeat("n", nonces), nil
+		},
+	}
+
+	mux, server, client := NewForemanAPIAndClient(
+		ClientCredentials{Username: "admin", Password: "changeme"},
+		ClientConfig{
+			Authenticator: authenticator,
+			RetryMax:      1,
+			RetryWaitMin:  time.Millisecond,
+			RetryWaitMax:  time.Millisecond,
+		},
+	)
+	defer server.Close()
+
+	seen := []string{}
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo", func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	req, _ := client.NewRequestWithContext(context.TODO(), http.MethodGet, "/foo", nil)
+	client.Send(req)
+
+	if len(seen) != 2 || seen[0] == seen[1] || !strings.HasPrefix(seen[1], "OAuth ") {
+		t.Fatalf(
+			"Client did not sign every attempt of the request. Got [%v].",
+			seen,
+		)
+	}
+	if nonces != len(seen) {
+		t.Fatalf(
+			"Client signed the request [%d] times for [%d] attempts.",
+			nonces,
+			len(seen),
+		)
+	}
+}
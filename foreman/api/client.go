@@ -1,22 +1,25 @@
 package api
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/HanseMerkur/terraform-provider-utils/log"
 	"github.com/dpotapov/go-spnego"
 	"github.com/terraform-coop/terraform-provider-foreman/foreman/utils"
 
 	cleanhttp "github.com/hashicorp/go-cleanhttp"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 const (
@@ -60,14 +63,46 @@ type ClientConfig struct {
 	//
 	// See 'pkg/crypto/tls/#Config.InsecureSkipVerify' for more information
 	TLSInsecureEnabled bool
+	// Certificate authorities to verify the server's certificate with.  If
+	// nil, the system's certificate pool is used.
+	TLSRootCAs *x509.CertPool
+	// Certificates presented to the server for mutual TLS authentication
+	TLSClientCertificates []tls.Certificate
+	// Name to verify the server's certificate against instead of the
+	// hostname of the server's URL
+	TLSServerName string
 
 	// Whether or not the client should try to authenticate to foreman
 	// through the HTTP negotiate mechanism.
 	NegotiateAuthEnabled bool
 
+	// Adds the credentials to the requests of the client.  If nil, the
+	// client uses HTTP basic authentication with its ClientCredentials.
+	Authenticator Authenticator
+
 	// Information as required by all API calls
 	LocationID     int
 	OrganizationID int
+
+	// How long to wait for an asynchronous task (ie: a Katello content view
+	// publish) to finish.  A value <= 0 waits until the context of the
+	// request is done.
+	TaskTimeout time.Duration
+	// Delay before the first poll of an asynchronous task.  The delay doubles
+	// after every poll up to TaskPollMaxInterval.  Values <= 0 use
+	// DefaultTaskPollInterval and DefaultTaskPollMaxInterval respectively.
+	TaskPollInterval    time.Duration
+	TaskPollMaxInterval time.Duration
+
+	// How many times a request is sent again after the server could not be
+	// reached, answered with 429 Too Many Requests or a 5xx status code.  A
+	// value <= 0 disables retries.
+	RetryMax int
+	// Delay before the first retry of a request.  The delay doubles after
+	// every attempt up to RetryWaitMax.  Values <= 0 use DefaultRetryWaitMin
+	// and DefaultRetryWaitMax respectively.
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
 }
 
 type Client struct {
@@ -75,6 +110,8 @@ type Client struct {
 	server Server
 	// Set of credentials to authenticate the client
 	credentials ClientCredentials
+	// Adds the credentials to the requests of the client
+	authenticator Authenticator
 	// Instance of the HTTP client used to communicate with the webservice.  After
 	// the intial setup, the client should never modify or interact directly with
 	// the underlying HTTP client and should instead use the helper functions.
@@ -84,29 +121,79 @@ type Client struct {
 	clientConfig ClientConfig
 }
 
-type HTTPError struct {
-	Endpoint   string
-	StatusCode int
-	RespBody   string
-}
-
-func (e HTTPError) Error() string {
-	return fmt.Sprintf(
-		"HTTP Error:{\n"+
-			"  endpoint:   [%s]\n"+
-			"  statusCode: [%d]\n"+
-			"  respBody:   [%s]\n"+
-			"}",
-		e.Endpoint,
-		e.StatusCode,
-		e.RespBody,
-	)
-}
-
 // KVParameters are used in all inline Parameter Maps. i.e. Host, HostGroup
 type ForemanKVParameter struct {
 	Name  string `json:"name"`
 	Value string `json:"value"`
+	// Type Foreman casts the value to, one of the ParameterType* constants.
+	// Foreman defaults to ParameterTypeString if empty.
+	ParameterType string `json:"parameter_type,omitempty"`
+}
+
+// Types of parameter values supported by Foreman.  Values are always sent
+// as strings and cast by Foreman, but read as their typed JSON value.
+const (
+	ParameterTypeString  = "string"
+	ParameterTypeBoolean = "boolean"
+	ParameterTypeInteger = "integer"
+	ParameterTypeReal    = "real"
+	ParameterTypeArray   = "array"
+	ParameterTypeHash    = "hash"
+	ParameterTypeYAML    = "yaml"
+	ParameterTypeJSON    = "json"
+)
+
+// ParameterTypes lists the ParameterType* constants
+var ParameterTypes = []string{
+	ParameterTypeString,
+	ParameterTypeBoolean,
+	ParameterTypeInteger,
+	ParameterTypeReal,
+	ParameterTypeArray,
+	ParameterTypeHash,
+	ParameterTypeYAML,
+	ParameterTypeJSON,
+}
+
+func (p *ForemanKVParameter) UnmarshalJSON(b []byte) error {
+	var pJSON struct {
+		Name          string          `json:"name"`
+		Value         json.RawMessage `json:"value"`
+		ParameterType string          `json:"parameter_type"`
+	}
+	if err := json.Unmarshal(b, &pJSON); err != nil {
+		return err
+	}
+
+	value, err := parameterValueToString(pJSON.Value)
+	if err != nil {
+		return err
+	}
+
+	p.Name = pJSON.Name
+	p.Value = value
+	p.ParameterType = pJSON.ParameterType
+	return nil
+}
+
+// parameterValueToString returns the string form of a typed parameter value
+// as returned by Foreman.  Strings are returned as is, other values as
+// compact JSON, ie: "true" for booleans or "[1,2]" for arrays.
+func parameterValueToString(raw json.RawMessage) (string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", nil
+	}
+
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		return str, nil
+	}
+
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, raw); err != nil {
+		return "", err
+	}
+	return compacted.String(), nil
 }
 
 // JSON obect for creating and updating puppetattributes on hosts and hostgroups
@@ -123,11 +210,39 @@ func FromKV(kv []ForemanKVParameter) (ret map[string]string) {
 	return ret
 }
 
-func ToKV(m map[string]interface{}) (ret []ForemanKVParameter) {
+// FromKVTypes returns the types of the parameters.  Parameters of the
+// default type ParameterTypeString are omitted.
+func FromKVTypes(kv []ForemanKVParameter) (ret map[string]string) {
+	ret = make(map[string]string)
+	for _, pair := range kv {
+		if pair.ParameterType != "" && pair.ParameterType != ParameterTypeString {
+			ret[pair.Name] = pair.ParameterType
+		}
+	}
+	return ret
+}
+
+// ToKV converts the map of parameter values and the map of their types to
+// KVParameters.  Values which are not strings are encoded as JSON.
+// Parameters missing in the types map use Foreman's default type.
+func ToKV(m map[string]interface{}, types map[string]interface{}) (ret []ForemanKVParameter) {
 	for key, value := range m {
+		var strValue string
+		switch v := value.(type) {
+		case string:
+			strValue = v
+		case nil:
+			strValue = ""
+		default:
+			valueJSON, _ := json.Marshal(v)
+			strValue = string(valueJSON)
+		}
+
+		parameterType, _ := types[key].(string)
 		ret = append(ret, ForemanKVParameter{
-			Name:  key,
-			Value: value.(string),
+			Name:          key,
+			Value:         strValue,
+			ParameterType: parameterType,
 		})
 	}
 	return ret
@@ -150,6 +265,9 @@ func NewClient(s Server, c ClientCredentials, cfg ClientConfig) *Client {
 	cleanClient := cleanhttp.DefaultClient()
 	tlsClientConfig := &tls.Config{
 		InsecureSkipVerify: cfg.TLSInsecureEnabled,
+		RootCAs:            cfg.TLSRootCAs,
+		Certificates:       cfg.TLSClientCertificates,
+		ServerName:         cfg.TLSServerName,
 	}
 	if cfg.NegotiateAuthEnabled {
 		transCfg := &spnego.Transport{}
@@ -161,12 +279,21 @@ func NewClient(s Server, c ClientCredentials, cfg ClientConfig) *Client {
 		cleanClient.Transport = transCfg
 	}
 
+	authenticator := cfg.Authenticator
+	if authenticator == nil {
+		authenticator = BasicAuthenticator{
+			Username: c.Username,
+			Password: c.Password,
+		}
+	}
+
 	// Initialize and return the unauthenticated client.
 	client := Client{
-		httpClient:   cleanClient,
-		server:       s,
-		credentials:  c,
-		clientConfig: cfg,
+		httpClient:    cleanClient,
+		server:        s,
+		credentials:   c,
+		authenticator: authenticator,
+		clientConfig:  cfg,
 	}
 	return &client
 }
@@ -246,6 +373,16 @@ func (client *Client) NewRequestWithContext(ctx context.Context, method string,
 		reqURL.String(),
 	)
 
+	// Buffer the body so that the request can be replayed when it is retried.
+	// net/http sets Request.GetBody for bytes.Reader bodies.
+	if body != nil {
+		bodyBytes, readErr := ioutil.ReadAll(body)
+		if readErr != nil {
+			return nil, readErr
+		}
+		body = bytes.NewReader(bodyBytes)
+	}
+
 	// Create the request object, bubble up errors if any were encountered
 	req, reqErr := http.NewRequestWithContext(
 		ctx,
@@ -265,7 +402,7 @@ func (client *Client) NewRequestWithContext(ctx context.Context, method string,
 	req.Header.Add("User-Agent", "terraform-provider-foreman")
 	req.Header.Add("Accept", "application/json,"+version_append)
 	req.Header.Add("Content-Type", "application/json")
-	req.SetBasicAuth(client.credentials.Username, client.credentials.Password)
+	// NOTE(ALL): Authentication is added by Client.Send() for every attempt
 	return req, nil
 }
 
@@ -302,6 +439,11 @@ func isValidRequestMethod(method string) bool {
 // the StatusCode, response. Serves as a facade to the Client's underlying
 // HTTP client.
 //
+// Requests which could not be delivered or were answered with a status code
+// worth retrying (see shouldRetry) are sent again up to ClientConfig.RetryMax
+// times with an exponential backoff between the attempts.  The response of
+// the last attempt is returned.
+//
 // If an error is encountered when reading the server's response, the returned
 // StatusCode will be -1.  If an error is encountered during any step of the
 // the send and response parsing, an empty slice will be returned as the
@@ -313,11 +455,53 @@ func isValidRequestMethod(method string) bool {
 func (client *Client) Send(request *http.Request) (int, []byte, error) {
 	utils.TraceFunctionCall()
 
-	emptySlice := []byte{}
-
 	if request == nil {
 		log.Errorf("Client trying to send a nil request")
-		return -1, emptySlice, fmt.Errorf("Client trying to send a nil request")
+		return -1, []byte{}, fmt.Errorf("Client trying to send a nil request")
+	}
+
+	for attempt := 0; ; attempt++ {
+		statusCode, header, respBody, sendErr := client.send(request)
+
+		if attempt >= client.clientConfig.RetryMax ||
+			!shouldRetry(request.Context(), request.Method, statusCode, sendErr) {
+			return statusCode, respBody, sendErr
+		}
+		if !rewindBody(request) {
+			log.Warningf(
+				"Not retrying [%s %s]: the request body cannot be replayed",
+				request.Method,
+				request.URL,
+			)
+			return statusCode, respBody, sendErr
+		}
+
+		wait := client.retryWait(attempt, header)
+		log.Infof(
+			"Retrying [%s %s] in [%s] (attempt [%d] of [%d]): statusCode [%d], error [%v]",
+			request.Method,
+			request.URL,
+			wait,
+			attempt+1,
+			client.clientConfig.RetryMax,
+			statusCode,
+			sendErr,
+		)
+		if waitErr := sleepContext(request.Context(), wait); waitErr != nil {
+			return statusCode, respBody, sendErr
+		}
+	}
+}
+
+// send performs a single attempt of sending the request and returns the
+// status code, headers and body of the server's response.
+func (client *Client) send(request *http.Request) (int, http.Header, []byte, error) {
+	emptySlice := []byte{}
+
+	// Authenticate every attempt - the URL of the request changes between
+	// the pages of a search and OAuth signatures must not be replayed
+	if authErr := client.authenticator.Authenticate(request); authErr != nil {
+		return -1, nil, emptySlice, authErr
 	}
 
 	// Send the request to the server
@@ -328,7 +512,7 @@ func (client *Client) Send(request *http.Request) (int, []byte, error) {
 				"  Error: %s",
 			respErr.Error(),
 		)
-		return -1, emptySlice, respErr
+		return -1, nil, emptySlice, respErr
 	}
 	// NOTE(ALL): Golang stdlib dictates that it is the caller's resposibility
 	//   to close the response body.  See net/http Response type for more
@@ -343,10 +527,10 @@ func (client *Client) Send(request *http.Request) (int, []byte, error) {
 				"  Error: %s",
 			readErr.Error(),
 		)
-		return resp.StatusCode, emptySlice, readErr
+		return resp.StatusCode, resp.Header, emptySlice, readErr
 	}
 
-	return resp.StatusCode, respBody, nil
+	return resp.StatusCode, resp.Header, respBody, nil
 }
 
 // SendAndParse sends an HTTP request generated by Client.NewRequestWithContext() and
@@ -389,7 +573,7 @@ func (client *Client) SendAndParse(req *http.Request, obj interface{}) error {
 
 		if asyncTask.Pending {
 			log.Debugf("KatelloResponse is pending")
-			finishedTask, err := client.waitForKatelloAsyncTask(asyncTask.Id)
+			finishedTask, err := client.waitForKatelloAsyncTask(req.Context(), asyncTask.Id)
 			if err != nil {
 				return err
 			}
@@ -403,8 +587,7 @@ func (client *Client) SendAndParse(req *http.Request, obj interface{}) error {
 					ForemanObject: ForemanObject{Id: int(output["content_view_id"].(float64))},
 				}
 
-				ctx := context.TODO()
-				updatedCv, err := client.ReadKatelloContentView(ctx, &cvToRead)
+				updatedCv, err := client.ReadKatelloContentView(req.Context(), &cvToRead)
 				if err != nil {
 					return err
 				}
@@ -426,7 +609,7 @@ func (client *Client) SendAndParse(req *http.Request, obj interface{}) error {
 	}
 
 	if statusCode < 200 || statusCode > 299 {
-		return HTTPError{req.URL.String(), statusCode, string(respBody[:])}
+		return newHTTPError(req.URL.String(), statusCode, respBody)
 	}
 
 	if obj != nil {
@@ -435,16 +618,68 @@ func (client *Client) SendAndParse(req *http.Request, obj interface{}) error {
 	return nil
 }
 
-// Taken from terraform-openstack-provider
-// CheckDeleted checks the error to see if it's a 404 (Not Found) and, if so,
-// sets the resource ID to the empty string instead of throwing an error.
-func CheckDeleted(d *schema.ResourceData, err error) error {
-	if httpError, ok := err.(HTTPError); ok && httpError.StatusCode == 404 {
-		d.SetId("")
-		return nil
-	}
+// queryResponsePage is used to decode a single page of a search response.
+// The results are kept as raw JSON so the caller can merge them regardless of
+// their shape (see QueryResponsePuppet).
+type queryResponsePage struct {
+	QueryResponse
+	Results json.RawMessage `json:"results"`
+}
+
+// sendAndParseQueryPages sends the search request once for every page of the
+// result set.  The raw results of each page are handed to mergePage, which
+// returns the number of results it merged.  Paging stops once a page is
+// empty, a page is shorter than the page size or all results reported by
+// the server's 'subtotal' have been merged.
+func (client *Client) sendAndParseQueryPages(req *http.Request, queryResponse *QueryResponse, mergePage func(json.RawMessage) (int, error)) error {
+	reqQuery := req.URL.Query()
+	merged := 0
+
+	for page := 1; ; page++ {
+		reqQuery.Set("page", strconv.Itoa(page))
+		pageReq := req.Clone(req.Context())
+		pageReq.URL.RawQuery = reqQuery.Encode()
+
+		var pageResponse queryResponsePage
+		sendErr := client.SendAndParse(pageReq, &pageResponse)
+		if sendErr != nil {
+			return sendErr
+		}
 
-	return err
+		// Foreman answers a page without results with "results": null
+		count := 0
+		if len(pageResponse.Results) > 0 {
+			var mergeErr error
+			count, mergeErr = mergePage(pageResponse.Results)
+			if mergeErr != nil {
+				return mergeErr
+			}
+		}
+		merged += count
+
+		if page == 1 {
+			queryResponse.Total = pageResponse.Total
+			queryResponse.Subtotal = pageResponse.Subtotal
+			queryResponse.Page = pageResponse.Page
+			queryResponse.PerPage = pageResponse.PerPage
+			queryResponse.Search = pageResponse.Search
+			queryResponse.Sort = pageResponse.Sort
+		}
+
+		log.Debugf(
+			"query page [%d]: [%d] results, [%d] of [%d] merged",
+			page,
+			count,
+			merged,
+			pageResponse.Subtotal,
+		)
+
+		if count == 0 ||
+			merged >= pageResponse.Subtotal ||
+			(pageResponse.PerPage > 0 && count < pageResponse.PerPage) {
+			return nil
+		}
+	}
 }
 
 // wrapParameter wraps the given parameters as an object of its own name
@@ -470,6 +705,48 @@ func (client *Client) wrapParameters(name interface{}, item interface{}) (map[st
 	return wrapped, nil
 }
 
+// secretAttributes are the attributes whose values are redacted from the
+// logged requests
+var secretAttributes = map[string]bool{
+	"password":         true,
+	"account_password": true,
+}
+
+// redactSecrets returns a copy of the JSON-encodable value with the values of
+// its secret attributes redacted, so that it can be logged.  Unset secrets are
+// kept to show whether the request removes them.
+func redactSecrets(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var redacted interface{}
+	if err := json.Unmarshal(data, &redacted); err != nil {
+		return nil
+	}
+	redactSecretValues(redacted)
+	return redacted
+}
+
+// redactSecretValues replaces the values of the secret attributes found in the
+// decoded JSON value
+func redactSecretValues(v interface{}) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, elem := range value {
+			if secret, ok := elem.(string); ok && secret != "" && secretAttributes[key] {
+				value[key] = "[REDACTED]"
+				continue
+			}
+			redactSecretValues(elem)
+		}
+	case []interface{}:
+		for _, elem := range value {
+			redactSecretValues(elem)
+		}
+	}
+}
+
 // WrapJSON wraps the given parameters as an object of its own name and marshals it to JSON
 func (client *Client) WrapJSON(name interface{}, item interface{}) ([]byte, error) {
 
@@ -480,6 +757,12 @@ func (client *Client) WrapJSON(name interface{}, item interface{}) ([]byte, erro
 
 // WrapJSONWithTaxonomy wraps the given parameters as an object of its own name,
 // includes additional information for the api call and marshals it to JSON
+//
+// The provider's location and organization are used as the taxonomy context
+// of the request - only if both are set, as before the items were able to
+// override them.  An item overriding either of them replaces the respective
+// taxonomy.  The taxonomy assignments of items embedding ForemanTaxonomies
+// are added to the wrapped object.
 func (client *Client) WrapJSONWithTaxonomy(name interface{}, item interface{}) ([]byte, error) {
 	utils.TraceFunctionCall()
 
@@ -488,12 +771,69 @@ func (client *Client) WrapJSONWithTaxonomy(name interface{}, item interface{}) (
 		return nil, err
 	}
 
+	locationId := -1
+	organizationId := -1
 	// Workaround for Foreman versions < 1.21 in case no default location/organization was defined for resources
 	if client.clientConfig.LocationID >= 0 && client.clientConfig.OrganizationID >= 0 {
-		wrapped["location_id"] = client.clientConfig.LocationID
-		wrapped["organization_id"] = client.clientConfig.OrganizationID
-		log.Debugf("client.go#WrapJSONWithTaxonomy: item %+v", wrapped)
+		locationId = client.clientConfig.LocationID
+		organizationId = client.clientConfig.OrganizationID
+	}
+
+	if tc, ok := item.(taxonomyContext); ok {
+		itemLocationId, itemOrganizationId := tc.taxonomyContext()
+		if itemLocationId != 0 {
+			locationId = itemLocationId
+		}
+		if itemOrganizationId != 0 {
+			organizationId = itemOrganizationId
+		}
 	}
 
+	if ta, ok := item.(taxonomyAssignments); ok {
+		if wrapped, err = client.wrapTaxonomies(wrapped, name, ta.taxonomies()); err != nil {
+			return nil, err
+		}
+	}
+
+	if locationId >= 0 {
+		wrapped["location_id"] = locationId
+	}
+	if organizationId >= 0 {
+		wrapped["organization_id"] = organizationId
+	}
+	log.Debugf("client.go#WrapJSONWithTaxonomy: item %+v", redactSecrets(wrapped))
+
 	return json.Marshal(wrapped)
 }
+
+// wrapTaxonomies adds the taxonomy assignments to the wrapped object.  Only
+// the taxonomies overridden by the object are added.
+func (client *Client) wrapTaxonomies(wrapped map[string]interface{}, name interface{}, ft ForemanTaxonomies) (map[string]interface{}, error) {
+	if ft.LocationIds == nil && ft.OrganizationIds == nil {
+		return wrapped, nil
+	}
+
+	obj := wrapped
+	if name != nil {
+		// Convert the wrapped item to a map to be able to add the assignments
+		key := fmt.Sprintf("%v", name)
+		itemBytes, err := json.Marshal(wrapped[key])
+		if err != nil {
+			return nil, err
+		}
+		obj = map[string]interface{}{}
+		if err = json.Unmarshal(itemBytes, &obj); err != nil {
+			return nil, err
+		}
+		wrapped[key] = obj
+	}
+
+	if ft.LocationIds != nil {
+		obj["location_ids"] = ft.LocationIds
+	}
+	if ft.OrganizationIds != nil {
+		obj["organization_ids"] = ft.OrganizationIds
+	}
+
+	return wrapped, nil
+}
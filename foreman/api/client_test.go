@@ -2,13 +2,16 @@ package api
 
 import (
 	"context"
+	"crypto/x509"
 	"encoding/base64"
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
 	"reflect"
+	"strconv"
 	"testing"
 
 	logger "github.com/HanseMerkur/terraform-provider-utils/log"
@@ -160,6 +163,67 @@ func TestNewClient_ConfigTLSInsecureEnabled(t *testing.T) {
 	}
 }
 
+// Ensures the client verifies the server's certificate against the configured
+// certificate authorities and server name.
+func TestNewClient_ConfigTLSRootCAs(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	serverURL, _ := url.Parse(server.URL)
+	// The certificate of the test server is issued by its own authority
+	serverTLSCfg := server.Client().Transport.(*http.Transport).TLSClientConfig
+
+	testCases := []struct {
+		RootCAs       *x509.CertPool
+		ServerName    string
+		ExpectedError bool
+	}{
+		{
+			RootCAs:       nil,
+			ExpectedError: true,
+		},
+		{
+			RootCAs:       serverTLSCfg.RootCAs,
+			ExpectedError: false,
+		},
+		{
+			RootCAs:       serverTLSCfg.RootCAs,
+			ServerName:    "example.com",
+			ExpectedError: false,
+		},
+		{
+			RootCAs:       serverTLSCfg.RootCAs,
+			ServerName:    "foreman.example.org",
+			ExpectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		client := NewClient(
+			Server{URL: *serverURL},
+			ClientCredentials{},
+			ClientConfig{
+				TLSRootCAs:    testCase.RootCAs,
+				TLSServerName: testCase.ServerName,
+			},
+		)
+
+		req, _ := client.NewRequestWithContext(context.TODO(), http.MethodGet, "/foo", nil)
+		_, _, sendErr := client.Send(req)
+
+		if (sendErr != nil) != testCase.ExpectedError {
+			t.Fatalf(
+				"Client did not verify the server's certificate as expected for "+
+					"server name [%s]. Expected error [%t], got [%v].",
+				testCase.ServerName,
+				testCase.ExpectedError,
+				sendErr,
+			)
+		}
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Client.NewRequestWithContext
 // ----------------------------------------------------------------------------
@@ -266,15 +330,15 @@ func TestNewRequest_RequestMethodToUpper(t *testing.T) {
 }
 
 // Ensures Client.NewRequestWithContext() sets the correct meta-data on the HTTP
-// request.
+// request and Client.Send() authenticates it.
 func TestNewRequest_Header(t *testing.T) {
-	serv := Server{}
 	cred := ClientCredentials{
 		Username: "Admin",
 		Password: "ChangeMe",
 	}
 	conf := ClientConfig{}
-	client := NewClient(serv, cred, conf)
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
 
 	// perform HTTP basic access authorization for the credentials
 	// SEE: RFC 7617
@@ -282,7 +346,14 @@ func TestNewRequest_Header(t *testing.T) {
 		[]byte(cred.Username+":"+cred.Password),
 	)
 
+	var sentHeader http.Header
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo", func(w http.ResponseWriter, r *http.Request) {
+		sentHeader = r.Header
+		w.WriteHeader(http.StatusOK)
+	})
+
 	req, _ := client.NewRequestWithContext(context.TODO(), http.MethodGet, "/foo", nil)
+	client.Send(req)
 
 	expectedHeader := http.Header{}
 	expectedHeader.Add("User-Agent", "terraform-provider-foreman")
@@ -291,12 +362,12 @@ func TestNewRequest_Header(t *testing.T) {
 	expectedHeader.Add("Authorization", credentialsEncoded)
 
 	for key := range expectedHeader {
-		if req.Header.Get(key) != expectedHeader.Get(key) {
+		if sentHeader.Get(key) != expectedHeader.Get(key) {
 			t.Fatalf(
 				"http.Request returned by Client.NewRequestWithContext() has incorrect HTTP header. "+
 					"Expected [%s], got [%s] for Header key [%s].\n",
 				expectedHeader.Get(key),
-				req.Header.Get(key),
+				sentHeader.Get(key),
 				key,
 			)
 		}
@@ -471,3 +542,418 @@ func TestSendAndParseStatusCodeNoError(t *testing.T) {
 		)
 	}
 }
+
+// ----------------------------------------------------------------------------
+// Client.sendAndParseQueryPages
+// ----------------------------------------------------------------------------
+
+// Serves the results as pages of the requested size, mimicking the way
+// Foreman paginates search results.
+func paginatedQueryHandler(t *testing.T, results []ForemanObject, perPage int, requests *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < 1 {
+			page = 1
+		}
+		start := (page - 1) * perPage
+		end := start + perPage
+		if start > len(results) {
+			start = len(results)
+		}
+		if end > len(results) {
+			end = len(results)
+		}
+		resp := map[string]interface{}{
+			"total":    len(results),
+			"subtotal": len(results),
+			"page":     page,
+			"per_page": perPage,
+			"results":  results[start:end],
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// Ensure Search() walks every page and merges the results
+func TestSearch_MultiplePages(t *testing.T) {
+	cred := ClientCredentials{}
+	conf := ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	results := []ForemanObject{}
+	for idx := 1; idx <= 5; idx++ {
+		results = append(results, ForemanObject{Id: idx, Name: "obj" + strconv.Itoa(idx)})
+	}
+	requests := 0
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo", paginatedQueryHandler(t, results, 2, &requests))
+
+	queryResponse, sendErr := searchQueryResponse[ForemanObject](context.TODO(), client, "/foo", SearchQuery{})
+	if sendErr != nil {
+		t.Fatalf("Search() returned an error: [%s]", sendErr)
+	}
+
+	if requests != 3 {
+		t.Fatalf(
+			"Search() did not request every page. "+
+				"Expected [3] requests, got [%d].",
+			requests,
+		)
+	}
+	if len(queryResponse.Results) != len(results) || queryResponse.Subtotal != len(results) {
+		t.Fatalf(
+			"Search() did not merge the results of every page. "+
+				"Expected [%d] results, got [%d] with subtotal [%d].",
+			len(results),
+			len(queryResponse.Results),
+			queryResponse.Subtotal,
+		)
+	}
+}
+
+// Ensure Search() keeps the page size chosen by the caller for every page it
+// requests
+func TestSearch_PerPage(t *testing.T) {
+	cred := ClientCredentials{}
+	conf := ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	results := []ForemanObject{{Id: 1}, {Id: 2}, {Id: 3}}
+	requests := 0
+	handler := paginatedQueryHandler(t, results, 1, &requests)
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("per_page") != "1" {
+			t.Fatalf(
+				"Search() did not keep the per_page query parameter. "+
+					"Expected [1], got [%s].",
+				r.URL.Query().Get("per_page"),
+			)
+		}
+		handler(w, r)
+	})
+
+	objs, sendErr := Search[ForemanObject](context.TODO(), client, "/foo", SearchQuery{PerPage: 1})
+	if sendErr != nil {
+		t.Fatalf("Search() returned an error: [%s]", sendErr)
+	}
+	if requests != 3 || len(objs) != 3 {
+		t.Fatalf(
+			"Search() did not walk every page. "+
+				"Expected [3] requests and results, got [%d] requests and [%d] results.",
+			requests,
+			len(objs),
+		)
+	}
+}
+
+// Ensure Search() returns an error if any of the pages fail
+func TestSearch_PageStatusCodeError(t *testing.T) {
+	cred := ClientCredentials{}
+	conf := ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	results := []ForemanObject{{Id: 1}, {Id: 2}}
+	requests := 0
+	handler := paginatedQueryHandler(t, results, 1, &requests)
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		handler(w, r)
+	})
+
+	_, sendErr := Search[ForemanObject](context.TODO(), client, "/foo", SearchQuery{})
+	if sendErr == nil {
+		t.Fatalf(
+			"Search() did not return an error when a page failed. " +
+				"Expected [error] got [nil]",
+		)
+	}
+}
+
+// Ensure the typed Query* functions return matches from every page
+func TestQueryArchitecture_MultiplePages(t *testing.T) {
+	cred := ClientCredentials{}
+	conf := ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	results := []ForemanObject{{Id: 1, Name: "x86_64"}, {Id: 2, Name: "x86_64"}, {Id: 3, Name: "x86_64"}}
+	requests := 0
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/"+ArchitectureEndpointPrefix, paginatedQueryHandler(t, results, 2, &requests))
+
+	queryResponse, queryErr := client.QueryArchitecture(context.TODO(), &ForemanArchitecture{ForemanObject: ForemanObject{Name: "x86_64"}})
+	if queryErr != nil {
+		t.Fatalf("Client.QueryArchitecture() returned an error: [%s]", queryErr)
+	}
+	if len(queryResponse.Results) != len(results) {
+		t.Fatalf(
+			"Client.QueryArchitecture() did not return the results of every page. "+
+				"Expected [%d], got [%d].",
+			len(results),
+			len(queryResponse.Results),
+		)
+	}
+	if arch, ok := queryResponse.Results[2].(ForemanArchitecture); !ok || arch.Id != 3 {
+		t.Fatalf(
+			"Client.QueryArchitecture() returned an unexpected result. "+
+				"Expected [ForemanArchitecture] with ID [3], got [%+v].",
+			queryResponse.Results[2],
+		)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// redactSecrets
+// ----------------------------------------------------------------------------
+
+// Ensure the secrets of the logged requests are redacted - also in nested
+// objects - without modifying the request
+func TestRedactSecrets(t *testing.T) {
+	password := "secret"
+	wrapped := map[string]interface{}{
+		"http_proxy": ForemanHTTPProxy{
+			ForemanObject: ForemanObject{Name: "proxy"},
+			Username:      "admin",
+			Password:      &password,
+		},
+		"interfaces": []map[string]interface{}{
+			{"username": "admin", "password": "secret"},
+			{"username": "admin", "password": ""},
+		},
+	}
+
+	expected := map[string]interface{}{
+		"http_proxy": map[string]interface{}{
+			"id":         float64(0),
+			"name":       "proxy",
+			"created_at": "",
+			"updated_at": "",
+			"url":        "",
+			"username":   "admin",
+			"password":   "[REDACTED]",
+		},
+		"interfaces": []interface{}{
+			map[string]interface{}{"username": "admin", "password": "[REDACTED]"},
+			map[string]interface{}{"username": "admin", "password": ""},
+		},
+	}
+
+	redacted := redactSecrets(wrapped)
+	if !reflect.DeepEqual(redacted, expected) {
+		t.Fatalf(
+			"redactSecrets did not redact the secrets. Expected [%+v], got [%+v].",
+			expected,
+			redacted,
+		)
+	}
+	if *wrapped["http_proxy"].(ForemanHTTPProxy).Password != "secret" {
+		t.Fatalf("redactSecrets modified the request")
+	}
+}
+
+// Ensure the account password of authentication sources is redacted
+func TestRedactSecrets_AuthSourceLDAP(t *testing.T) {
+	wrapped := map[string]interface{}{
+		"auth_source_ldap": ForemanAuthSourceLDAP{
+			Account:         "ldap-reader",
+			AccountPassword: "secret",
+		},
+	}
+
+	redacted := redactSecrets(wrapped).(map[string]interface{})
+	authSource := redacted["auth_source_ldap"].(map[string]interface{})
+	if authSource["account_password"] != "[REDACTED]" || authSource["account"] != "ldap-reader" {
+		t.Fatalf(
+			"redactSecrets did not redact the account password. Expected "+
+				"[[REDACTED]] and the account [ldap-reader], got [%+v].",
+			authSource,
+		)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// ForemanKVParameter
+// ----------------------------------------------------------------------------
+
+// Ensure typed parameter values returned by Foreman are decoded to their
+// string form
+func TestForemanKVParameter_UnmarshalJSON(t *testing.T) {
+	var kv []ForemanKVParameter
+	err := json.Unmarshal([]byte(`[
+		{"name": "s", "value": "foo", "parameter_type": "string"},
+		{"name": "b", "value": true, "parameter_type": "boolean"},
+		{"name": "i", "value": 42, "parameter_type": "integer"},
+		{"name": "a", "value": ["a", 1], "parameter_type": "array"},
+		{"name": "h", "value": {"a": {"b": null}}, "parameter_type": "hash"},
+		{"name": "n", "value": null}
+	]`), &kv)
+	if err != nil {
+		t.Fatalf("ForemanKVParameter UnmarshalJSON returned an error: [%s]", err)
+	}
+
+	expected := []ForemanKVParameter{
+		{Name: "s", Value: "foo", ParameterType: ParameterTypeString},
+		{Name: "b", Value: "true", ParameterType: ParameterTypeBoolean},
+		{Name: "i", Value: "42", ParameterType: ParameterTypeInteger},
+		{Name: "a", Value: `["a",1]`, ParameterType: ParameterTypeArray},
+		{Name: "h", Value: `{"a":{"b":null}}`, ParameterType: ParameterTypeHash},
+		{Name: "n", Value: ""},
+	}
+	if !reflect.DeepEqual(kv, expected) {
+		t.Fatalf(
+			"ForemanKVParameter UnmarshalJSON did not decode the typed values. "+
+				"Expected [%+v], got [%+v].",
+			expected,
+			kv,
+		)
+	}
+}
+
+// Ensure ToKV accepts values which are not strings and sets the types
+func TestToKV_Types(t *testing.T) {
+	kv := ToKV(
+		map[string]interface{}{"b": true, "s": "foo"},
+		map[string]interface{}{"b": ParameterTypeBoolean},
+	)
+
+	actual := map[string]ForemanKVParameter{}
+	for _, pair := range kv {
+		actual[pair.Name] = pair
+	}
+	expected := map[string]ForemanKVParameter{
+		"b": {Name: "b", Value: "true", ParameterType: ParameterTypeBoolean},
+		"s": {Name: "s", Value: "foo"},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf(
+			"ToKV did not convert the parameters. Expected [%+v], got [%+v].",
+			expected,
+			actual,
+		)
+	}
+
+	types := FromKVTypes(append(kv, ForemanKVParameter{Name: "t", ParameterType: ParameterTypeString}))
+	if !reflect.DeepEqual(types, map[string]string{"b": ParameterTypeBoolean}) {
+		t.Fatalf("FromKVTypes did not omit the string parameters. Got [%+v].", types)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// ForemanUser
+// ----------------------------------------------------------------------------
+
+// Ensure the builtin default role, which cannot be managed, is dropped from
+// the roles of the user
+func TestForemanUser_UnmarshalJSONDefaultRole(t *testing.T) {
+	var user ForemanUser
+	err := json.Unmarshal([]byte(`{
+		"id": 1,
+		"login": "jdoe",
+		"roles": [
+			{"id": 5, "name": "Viewer"},
+			{"id": 1, "name": "`+DefaultRoleName+`"},
+			{"id": 9, "name": "Manager"}
+		]
+	}`), &user)
+	if err != nil {
+		t.Fatalf("ForemanUser UnmarshalJSON returned an error: [%s]", err)
+	}
+
+	expected := []int{5, 9}
+	if !reflect.DeepEqual(user.RoleIds, expected) {
+		t.Fatalf(
+			"ForemanUser UnmarshalJSON did not drop the default role. "+
+				"Expected [%v], got [%v].",
+			expected,
+			user.RoleIds,
+		)
+	}
+}
+
+// Ensure the email notifications are only sent if they are set
+func TestForemanUser_MarshalJSONMailEnabled(t *testing.T) {
+	disabled := false
+	testCases := []struct {
+		mailEnabled *bool
+		expected    interface{}
+		sent        bool
+	}{
+		{mailEnabled: nil, sent: false},
+		{mailEnabled: &disabled, expected: false, sent: true},
+	}
+
+	for _, testCase := range testCases {
+		b, err := json.Marshal(ForemanUser{Login: "jdoe", MailEnabled: testCase.mailEnabled})
+		if err != nil {
+			t.Fatalf("ForemanUser MarshalJSON returned an error: [%s]", err)
+		}
+
+		var actual map[string]interface{}
+		json.Unmarshal(b, &actual)
+
+		mailEnabled, sent := actual["mail_enabled"]
+		if sent != testCase.sent || mailEnabled != testCase.expected {
+			t.Fatalf(
+				"ForemanUser MarshalJSON did not encode mail_enabled [%v]. "+
+					"Expected sent [%t] with [%v], got [%s].",
+				testCase.mailEnabled,
+				testCase.sent,
+				testCase.expected,
+				b,
+			)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// ForemanInterfacesAttribute
+// ----------------------------------------------------------------------------
+
+// Ensure interfaces tagged for removal only send their ID, while the other
+// interfaces send all of their attributes
+func TestForemanInterfacesAttribute_MarshalJSON(t *testing.T) {
+	ifaces := []ForemanInterfacesAttribute{
+		{Id: 1, Identifier: "eth0", Primary: true, Provision: true},
+		{Id: 2, Identifier: "eth1", Primary: true, Provision: true, Destroy: true},
+		{Id: 3, Identifier: "bond0", Type: "bond"},
+	}
+
+	b, err := json.Marshal(ifaces)
+	if err != nil {
+		t.Fatalf("ForemanInterfacesAttribute MarshalJSON returned an error: [%s]", err)
+	}
+
+	var actual []map[string]interface{}
+	json.Unmarshal(b, &actual)
+
+	if actual[0]["identifier"] != "eth0" || actual[0]["primary"] != true {
+		t.Fatalf(
+			"ForemanInterfacesAttribute MarshalJSON did not encode the attributes "+
+				"of the interface. Got [%s].",
+			b,
+		)
+	}
+	expected := map[string]interface{}{"id": float64(2), "_destroy": true}
+	if !reflect.DeepEqual(actual[1], expected) {
+		t.Fatalf(
+			"ForemanInterfacesAttribute MarshalJSON did not only encode the ID of "+
+				"the removed interface. Expected [%+v], got [%+v].",
+			expected,
+			actual[1],
+		)
+	}
+	if bondOptions, ok := actual[2]["bond_options"]; !ok || bondOptions != "" {
+		t.Fatalf(
+			"ForemanInterfacesAttribute MarshalJSON did not send the empty bond "+
+				"options to remove them. Got [%s].",
+			b,
+		)
+	}
+}
@@ -28,6 +28,32 @@ type ForemanCommonParameter struct {
 	// The CommonParameter we actually send
 	Name  string `json:"name"`
 	Value string `json:"value"`
+	// Type Foreman casts the value to, one of the ParameterType* constants
+	ParameterType string `json:"parameter_type,omitempty"`
+}
+
+func (cp *ForemanCommonParameter) UnmarshalJSON(b []byte) error {
+	var jsonDecErr error
+
+	// Unmarshal the common Foreman object properties
+	var fo ForemanObject
+	jsonDecErr = json.Unmarshal(b, &fo)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	cp.ForemanObject = fo
+
+	// The name, typed value and type of the parameter
+	var kv ForemanKVParameter
+	jsonDecErr = json.Unmarshal(b, &kv)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	cp.Name = kv.Name
+	cp.Value = kv.Value
+	cp.ParameterType = kv.ParameterType
+
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -71,6 +97,7 @@ func (c *Client) CreateCommonParameter(ctx context.Context, d *ForemanCommonPara
 	d.Id = createdCommonParameter.Id
 	d.Name = createdCommonParameter.Name
 	d.Value = createdCommonParameter.Value
+	d.ParameterType = createdCommonParameter.ParameterType
 	return d, nil
 }
 
@@ -102,6 +129,7 @@ func (c *Client) ReadCommonParameter(ctx context.Context, d *ForemanCommonParame
 	d.Id = readCommonParameter.Id
 	d.Name = readCommonParameter.Name
 	d.Value = readCommonParameter.Value
+	d.ParameterType = readCommonParameter.ParameterType
 	return d, nil
 }
 
@@ -140,6 +168,7 @@ func (c *Client) UpdateCommonParameter(ctx context.Context, d *ForemanCommonPara
 	d.Id = updatedCommonParameter.Id
 	d.Name = updatedCommonParameter.Name
 	d.Value = updatedCommonParameter.Value
+	d.ParameterType = updatedCommonParameter.ParameterType
 	return d, nil
 }
 
@@ -172,52 +201,8 @@ func (c *Client) DeleteCommonParameter(ctx context.Context, d *ForemanCommonPara
 func (c *Client) QueryCommonParameter(ctx context.Context, d *ForemanCommonParameter) (QueryResponse, error) {
 	log.Tracef("foreman/api/common_parameter.go#Search")
 
-	queryResponse := QueryResponse{}
-
 	reqEndpoint := fmt.Sprintf("/%s", CommonParameterEndpointPrefix)
-	req, reqErr := c.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		reqEndpoint,
-		nil,
-	)
-	if reqErr != nil {
-		return queryResponse, reqErr
-	}
-
-	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
-	name := `"` + d.Name + `"`
-	reqQuery.Set("search", "name="+name)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanCommonParameter for
-	// the results
-	results := []ForemanCommonParameter{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanCommonParameter to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return searchQueryResponse[ForemanCommonParameter](ctx, c, reqEndpoint, SearchQuery{
+		Search: SearchEquals("name", d.Name),
+	})
 }
@@ -140,54 +140,10 @@ func (c *Client) ReadComputeProfile(ctx context.Context, id int) (*ForemanComput
 func (c *Client) QueryComputeProfile(ctx context.Context, t *ForemanComputeProfile) (QueryResponse, error) {
 	log.Tracef("foreman/api/templatekind.go#Search")
 
-	queryResponse := QueryResponse{}
-
 	reqEndpoint := fmt.Sprintf("/%s", ComputeProfileEndpointPrefix)
-	req, reqErr := c.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		reqEndpoint,
-		nil,
-	)
-	if reqErr != nil {
-		return queryResponse, reqErr
-	}
-
-	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
-	name := `"` + t.Name + `"`
-	reqQuery.Set("search", "name="+name)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanComputeProfile for
-	// the results
-	results := []ForemanComputeProfile{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanComputeProfile to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return searchQueryResponse[ForemanComputeProfile](ctx, c, reqEndpoint, SearchQuery{
+		Search: SearchEquals("name", t.Name),
+	})
 }
 
 func (c *Client) CreateComputeprofile(ctx context.Context, d *ForemanComputeProfile) (*ForemanComputeProfile, error) {
@@ -25,6 +25,8 @@ const (
 type ForemanComputeResource struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Locations and organizations the object is assigned to
+	ForemanTaxonomies
 
 	Description string `json:"description"`
 	URL         string `json:"url"`
@@ -54,6 +56,12 @@ func (fcr *ForemanComputeResource) UnmarshalJSON(b []byte) error {
 	}
 	fcr.ForemanObject = fo
 
+	// Unmarshal the assigned locations and organizations
+	fcr.ForemanTaxonomies, jsonDecErr = unmarshalForemanTaxonomies(b)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+
 	// Unmarshal into mapstructure and set the rest of the struct properties
 	// NOTE(ALL): Properties unmarshalled are of type float64 as opposed to int, hence the below testing
 	// Without this, properties will define as default values in state file.
@@ -235,52 +243,8 @@ func (c *Client) DeleteComputeResource(ctx context.Context, id int) error {
 func (c *Client) QueryComputeResource(ctx context.Context, d *ForemanComputeResource) (QueryResponse, error) {
 	log.Tracef("foreman/api/computeresource.go#Search")
 
-	queryResponse := QueryResponse{}
-
 	reqEndpoint := fmt.Sprintf("/%s", ComputeResourceEndpointPrefix)
-	req, reqErr := c.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		reqEndpoint,
-		nil,
-	)
-	if reqErr != nil {
-		return queryResponse, reqErr
-	}
-
-	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
-	name := `"` + d.Name + `"`
-	reqQuery.Set("search", "name="+name)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanComputeResource for
-	// the results
-	results := []ForemanComputeResource{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanComputeResource to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return searchQueryResponse[ForemanComputeResource](ctx, c, reqEndpoint, SearchQuery{
+		Search: SearchEquals("name", d.Name),
+	})
 }
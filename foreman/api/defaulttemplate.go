@@ -164,52 +164,8 @@ func (c *Client) DeleteDefaultTemplate(ctx context.Context, d *ForemanDefaultTem
 func (c *Client) QueryDefaultTemplate(ctx context.Context, d *ForemanDefaultTemplate) (QueryResponse, error) {
 	log.Tracef("foreman/api/parameter.go#Search")
 
-	queryResponse := QueryResponse{}
-
 	reqEndpoint := fmt.Sprintf("/%s", DefaultTemplateEndpointPrefix)
-	req, reqErr := c.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		reqEndpoint,
-		nil,
-	)
-	if reqErr != nil {
-		return queryResponse, reqErr
-	}
-
-	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
-	name := `"` + d.Name + `"`
-	reqQuery.Set("search", "name="+name)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanDefaultTemplate for
-	// the results
-	results := []ForemanDefaultTemplate{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanDefaultTemplate to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return searchQueryResponse[ForemanDefaultTemplate](ctx, c, reqEndpoint, SearchQuery{
+		Search: SearchEquals("name", d.Name),
+	})
 }
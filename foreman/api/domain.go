@@ -24,14 +24,91 @@ const (
 type ForemanDomain struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Locations and organizations the domain is assigned to
+	ForemanTaxonomies
 
 	// Fully qualified domain name
 	Fullname string `json:"fullname"`
 
+	// ID of the smart proxy managing the DNS records of the domain
+	DnsId int `json:"dns_id"`
+
+	// IDs of the subnets the domain is assigned to
+	SubnetIds []int `json:"-"`
+
 	// Map of DomainParameters
 	DomainParameters []ForemanKVParameter `json:"domain_parameters_attributes,omitempty"`
 }
 
+// ForemanDomain struct used for JSON decode.  Foreman API returns the subnets
+// as list of ForemanObjects.  We are only interested in their IDs.
+type foremanDomainJSON struct {
+	Subnets []ForemanObject `json:"subnets"`
+}
+
+// Implement the Marshaler interface
+func (fd ForemanDomain) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/domain.go#MarshalJSON")
+
+	// Encode the domain properties with the default encoder - the type
+	// conversion drops this method to avoid the recursion
+	type foremanDomain ForemanDomain
+	fdBytes, jsonEncErr := json.Marshal(foremanDomain(fd))
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	var fdMap map[string]interface{}
+	jsonEncErr = json.Unmarshal(fdBytes, &fdMap)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	// An unset DNS proxy is sent as null to remove it from the domain
+	fdMap["dns_id"] = intIdToJSONString(fd.DnsId)
+	// Only send the subnets if they are managed.  An empty array removes the
+	// domain from all subnets - Foreman interprets the array as a REPLACE
+	// operation.
+	if fd.SubnetIds != nil {
+		fdMap["subnet_ids"] = fd.SubnetIds
+	}
+
+	return json.Marshal(fdMap)
+}
+
+// Implement the Unmarshaler interface
+func (fd *ForemanDomain) UnmarshalJSON(b []byte) error {
+	// Decode the domain properties with the default decoder - the type
+	// conversion drops this method to avoid the recursion
+	type foremanDomain ForemanDomain
+	var obj foremanDomain
+	jsonDecErr := json.Unmarshal(b, &obj)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	*fd = ForemanDomain(obj)
+
+	// Unmarshal the assigned locations and organizations
+	fd.ForemanTaxonomies, jsonDecErr = unmarshalForemanTaxonomies(b)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+
+	// Unmarshal to temporary JSON struct to get the properties with
+	// differently named keys.  Subnets missing in the response - ie: in
+	// search results - are left nil.
+	var fdJSON foremanDomainJSON
+	jsonDecErr = json.Unmarshal(b, &fdJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	if fdJSON.Subnets != nil {
+		fd.SubnetIds = foremanObjectArrayToIdIntArray(fdJSON.Subnets)
+	}
+
+	return nil
+}
+
 // -----------------------------------------------------------------------------
 // CRUD Implementation
 // -----------------------------------------------------------------------------
@@ -166,52 +243,8 @@ func (c *Client) DeleteDomain(ctx context.Context, id int) error {
 func (c *Client) QueryDomain(ctx context.Context, d *ForemanDomain) (QueryResponse, error) {
 	log.Tracef("foreman/api/domain.go#Search")
 
-	queryResponse := QueryResponse{}
-
 	reqEndpoint := fmt.Sprintf("/%s", DomainEndpointPrefix)
-	req, reqErr := c.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		reqEndpoint,
-		nil,
-	)
-	if reqErr != nil {
-		return queryResponse, reqErr
-	}
-
-	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
-	name := `"` + d.Name + `"`
-	reqQuery.Set("search", "name="+name)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanDomain for
-	// the results
-	results := []ForemanDomain{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanDomain to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return searchQueryResponse[ForemanDomain](ctx, c, reqEndpoint, SearchQuery{
+		Search: SearchEquals("name", d.Name),
+	})
 }
@@ -22,6 +22,25 @@ const (
 type ForemanEnvironment struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Locations and organizations the object is assigned to
+	ForemanTaxonomies
+}
+
+// Implement the Unmarshaler interface
+func (fe *ForemanEnvironment) UnmarshalJSON(b []byte) error {
+	// Decode the environment properties with the default decoder - the type
+	// conversion drops this method to avoid the recursion
+	type foremanEnvironment ForemanEnvironment
+	var obj foremanEnvironment
+	jsonDecErr := json.Unmarshal(b, &obj)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	*fe = ForemanEnvironment(obj)
+
+	// Unmarshal the assigned locations and organizations
+	fe.ForemanTaxonomies, jsonDecErr = unmarshalForemanTaxonomies(b)
+	return jsonDecErr
 }
 
 // -----------------------------------------------------------------------------
@@ -160,52 +179,8 @@ func (c *Client) DeleteEnvironment(ctx context.Context, id int) error {
 func (c *Client) QueryEnvironment(ctx context.Context, e *ForemanEnvironment) (QueryResponse, error) {
 	log.Tracef("foreman/api/environment.go#Search")
 
-	queryResponse := QueryResponse{}
-
 	reqEndpoint := fmt.Sprintf("/%s", EnvironmentEndpointPrefix)
-	req, reqErr := c.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		reqEndpoint,
-		nil,
-	)
-	if reqErr != nil {
-		return queryResponse, reqErr
-	}
-
-	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
-	name := `"` + e.Name + `"`
-	reqQuery.Set("search", "name="+name)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanEnvironment for
-	// the results
-	results := []ForemanEnvironment{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanEnvironment to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return searchQueryResponse[ForemanEnvironment](ctx, c, reqEndpoint, SearchQuery{
+		Search: SearchEquals("name", e.Name),
+	})
 }
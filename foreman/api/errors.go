@@ -0,0 +1,249 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ----------------------------------------------------------------------------
+// HTTPError Definition
+// ----------------------------------------------------------------------------
+
+// HTTPError is returned when the server answers a request with a status code
+// outside of the 2xx range.  If the response body holds one of the error
+// documents of Foreman or Katello, its messages are parsed into Message,
+// FullMessages and Errors.
+//
+// Foreman:
+//
+//	{"error": {"message": "...", "errors": {"name": ["..."]}, "full_messages": ["..."]}}
+//
+// Katello:
+//
+//	{"displayMessage": "...", "errors": ["..."]}
+type HTTPError struct {
+	Endpoint   string
+	StatusCode int
+	RespBody   string
+
+	// Summary of the error, ie: "Resource host not found by id '4'"
+	Message string
+	// Human readable validation errors, ie: "Name has already been taken"
+	FullMessages []string
+	// Validation errors by the name of the offending attribute, ie:
+	// {"name": ["has already been taken"]}
+	Errors map[string][]string
+}
+
+// foremanErrorJSON covers the error documents of Foreman and Katello.  The
+// 'errors' are either an array of messages or an object of messages by
+// attribute, depending on the endpoint.
+type foremanErrorJSON struct {
+	Error *struct {
+		Message      string          `json:"message"`
+		Errors       json.RawMessage `json:"errors"`
+		FullMessages []string        `json:"full_messages"`
+	} `json:"error"`
+	DisplayMessage string          `json:"displayMessage"`
+	Errors         json.RawMessage `json:"errors"`
+}
+
+// newHTTPError creates an HTTPError for the response and parses the error
+// messages from the response body.  Bodies which are not one of the known
+// error documents are kept in RespBody only.
+func newHTTPError(endpoint string, statusCode int, respBody []byte) HTTPError {
+	httpErr := HTTPError{
+		Endpoint:   endpoint,
+		StatusCode: statusCode,
+		RespBody:   string(respBody),
+	}
+
+	var errJSON foremanErrorJSON
+	if err := json.Unmarshal(respBody, &errJSON); err != nil {
+		return httpErr
+	}
+
+	errs := errJSON.Errors
+	if errJSON.Error != nil {
+		httpErr.Message = errJSON.Error.Message
+		httpErr.FullMessages = errJSON.Error.FullMessages
+		errs = errJSON.Error.Errors
+	} else {
+		httpErr.Message = errJSON.DisplayMessage
+	}
+
+	var messages []string
+	var attrMessages map[string][]string
+	if json.Unmarshal(errs, &messages) == nil {
+		if len(httpErr.FullMessages) == 0 {
+			httpErr.FullMessages = messages
+		}
+	} else if json.Unmarshal(errs, &attrMessages) == nil && len(attrMessages) > 0 {
+		httpErr.Errors = attrMessages
+	}
+
+	return httpErr
+}
+
+// Messages returns the parsed error messages.  If the response body could not
+// be parsed, the raw response body is returned instead.
+func (e HTTPError) Messages() []string {
+	messages := []string{}
+	if e.Message != "" {
+		messages = append(messages, e.Message)
+	}
+	for _, msg := range e.FullMessages {
+		if msg != e.Message {
+			messages = append(messages, msg)
+		}
+	}
+	if len(messages) == 0 && len(e.Errors) > 0 {
+		for _, attr := range e.sortedAttributes() {
+			for _, msg := range e.Errors[attr] {
+				messages = append(messages, attr+" "+msg)
+			}
+		}
+	}
+	if len(messages) == 0 && strings.TrimSpace(e.RespBody) != "" {
+		messages = append(messages, strings.TrimSpace(e.RespBody))
+	}
+	return messages
+}
+
+func (e HTTPError) Error() string {
+	msg := fmt.Sprintf(
+		"%d %s from [%s]",
+		e.StatusCode,
+		http.StatusText(e.StatusCode),
+		e.Endpoint,
+	)
+	if messages := e.Messages(); len(messages) > 0 {
+		msg += ": " + strings.Join(messages, "; ")
+	}
+	return msg
+}
+
+// sortedAttributes returns the names of the attributes with validation errors
+// in a stable order
+func (e HTTPError) sortedAttributes() []string {
+	attrs := make([]string, 0, len(e.Errors))
+	for attr := range e.Errors {
+		attrs = append(attrs, attr)
+	}
+	sort.Strings(attrs)
+	return attrs
+}
+
+// ----------------------------------------------------------------------------
+// Error Predicates
+// ----------------------------------------------------------------------------
+
+// hasStatusCode reports whether err is (or wraps) an HTTPError with one of
+// the supplied status codes
+func hasStatusCode(err error, statusCodes ...int) bool {
+	var httpErr HTTPError
+	if !errors.As(err, &httpErr) {
+		return false
+	}
+	for _, code := range statusCodes {
+		if httpErr.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// IsNotFound reports whether the server answered with 404 Not Found
+func IsNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether the server answered with 409 Conflict, ie: when
+// deleting an object which is still in use
+func IsConflict(err error) bool {
+	return hasStatusCode(err, http.StatusConflict)
+}
+
+// IsValidation reports whether the server rejected the object with
+// 422 Unprocessable Entity
+func IsValidation(err error) bool {
+	return hasStatusCode(err, http.StatusUnprocessableEntity)
+}
+
+// IsUnauthorized reports whether the server answered with 401 Unauthorized
+// (invalid credentials) or 403 Forbidden (missing permissions)
+func IsUnauthorized(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized, http.StatusForbidden)
+}
+
+// ----------------------------------------------------------------------------
+// Diagnostics
+// ----------------------------------------------------------------------------
+
+// Taken from terraform-openstack-provider
+// CheckDeleted checks the error to see if it's a 404 (Not Found) and, if so,
+// sets the resource ID to the empty string instead of throwing an error.
+func CheckDeleted(d *schema.ResourceData, err error) error {
+	if IsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+
+	return err
+}
+
+// DiagnosticsFromError converts the error into diagnostics.  The validation
+// errors of an HTTPError are reported per attribute and point at the
+// attribute of the resource if an attribute of the same name (or with an
+// '_id' suffix, ie: 'domain' -> 'domain_id') exists in its schema.  All other
+// errors are reported as a single diagnostic like diag.FromErr.
+func DiagnosticsFromError(d *schema.ResourceData, err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	var httpErr HTTPError
+	if !errors.As(err, &httpErr) || len(httpErr.Errors) == 0 {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	for _, attr := range httpErr.sortedAttributes() {
+		for _, msg := range httpErr.Errors[attr] {
+			diagnostic := diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("%s %s", attr, msg),
+				Detail:   httpErr.Error(),
+			}
+			if name, ok := schemaAttributeName(d, attr); ok {
+				diagnostic.AttributePath = cty.GetAttrPath(name)
+			}
+			diags = append(diags, diagnostic)
+		}
+	}
+	return diags
+}
+
+// schemaAttributeName returns the name of the resource's attribute an
+// attribute of the API refers to.
+func schemaAttributeName(d *schema.ResourceData, attr string) (string, bool) {
+	if d == nil {
+		return "", false
+	}
+	for _, name := range []string{attr, attr + "_id", attr + "_ids"} {
+		// NOTE(ALL): ResourceData.Get returns nil for keys not defined in the
+		//   schema and the zero value of the attribute's type otherwise
+		if d.Get(name) != nil {
+			return name, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,239 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ----------------------------------------------------------------------------
+// newHTTPError
+// ----------------------------------------------------------------------------
+
+// Ensure the error documents of Foreman and Katello are parsed
+func TestNewHTTPError_ParsesMessages(t *testing.T) {
+	testCases := []struct {
+		respBody string
+		expected HTTPError
+	}{
+		{
+			respBody: `{"error":{"message":"Resource host not found by id '4'"}}`,
+			expected: HTTPError{
+				Message: "Resource host not found by id '4'",
+			},
+		},
+		{
+			respBody: `{"error":{"id":null,"errors":{"name":["has already been taken"]},` +
+				`"full_messages":["Name has already been taken"]}}`,
+			expected: HTTPError{
+				FullMessages: []string{"Name has already been taken"},
+				Errors:       map[string][]string{"name": {"has already been taken"}},
+			},
+		},
+		{
+			respBody: `{"displayMessage":"Validation failed: Label has already been taken",` +
+				`"errors":["Validation failed: Label has already been taken"]}`,
+			expected: HTTPError{
+				Message:      "Validation failed: Label has already been taken",
+				FullMessages: []string{"Validation failed: Label has already been taken"},
+			},
+		},
+		{
+			respBody: `{"displayMessage":"Validation failed","errors":{"label":["is invalid"]}}`,
+			expected: HTTPError{
+				Message: "Validation failed",
+				Errors:  map[string][]string{"label": {"is invalid"}},
+			},
+		},
+		{
+			respBody: `<html>Internal Server Error</html>`,
+			expected: HTTPError{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		actual := newHTTPError("/api/foo", http.StatusUnprocessableEntity, []byte(testCase.respBody))
+
+		expected := testCase.expected
+		expected.Endpoint = "/api/foo"
+		expected.StatusCode = http.StatusUnprocessableEntity
+		expected.RespBody = testCase.respBody
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf(
+				"newHTTPError did not parse the response body [%s]. "+
+					"Expected [%+v], got [%+v].",
+				testCase.respBody,
+				expected,
+				actual,
+			)
+		}
+	}
+}
+
+// Ensure the error message includes the parsed messages instead of the raw
+// response body
+func TestHTTPError_Error(t *testing.T) {
+	httpErr := newHTTPError(
+		"/api/hosts",
+		http.StatusUnprocessableEntity,
+		[]byte(`{"error":{"errors":{"name":["has already been taken"]},"full_messages":["Name has already been taken"]}}`),
+	)
+
+	expected := "422 Unprocessable Entity from [/api/hosts]: Name has already been taken"
+	if httpErr.Error() != expected {
+		t.Fatalf(
+			"HTTPError.Error() did not return the parsed messages. "+
+				"Expected [%s], got [%s].",
+			expected,
+			httpErr.Error(),
+		)
+	}
+}
+
+// Ensure SendAndParse returns a parsed HTTPError
+func TestSendAndParse_ParsedHTTPError(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"message":"Resource foo not found by id '1'"}}`))
+	})
+
+	req, _ := client.NewRequestWithContext(context.TODO(), http.MethodGet, "/foo", nil)
+	sendErr := client.SendAndParse(req, nil)
+
+	httpErr, ok := sendErr.(HTTPError)
+	if !ok || httpErr.Message != "Resource foo not found by id '1'" {
+		t.Fatalf(
+			"SendAndParse did not return a parsed HTTPError. Got [%#v].",
+			sendErr,
+		)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Error Predicates
+// ----------------------------------------------------------------------------
+
+// Ensure the predicates match the status code of (wrapped) HTTPErrors only
+func TestErrorPredicates(t *testing.T) {
+	predicates := map[string]func(error) bool{
+		"IsNotFound":     IsNotFound,
+		"IsConflict":     IsConflict,
+		"IsValidation":   IsValidation,
+		"IsUnauthorized": IsUnauthorized,
+	}
+	testCases := map[int]string{
+		http.StatusNotFound:            "IsNotFound",
+		http.StatusConflict:            "IsConflict",
+		http.StatusUnprocessableEntity: "IsValidation",
+		http.StatusUnauthorized:        "IsUnauthorized",
+		http.StatusForbidden:           "IsUnauthorized",
+		http.StatusInternalServerError: "",
+	}
+
+	for statusCode, expected := range testCases {
+		httpErr := HTTPError{Endpoint: "/api/foo", StatusCode: statusCode}
+		for _, err := range []error{httpErr, fmt.Errorf("wrapped: %w", httpErr)} {
+			for name, predicate := range predicates {
+				if predicate(err) != (name == expected) {
+					t.Fatalf(
+						"%s returned [%t] for status code [%d].",
+						name,
+						predicate(err),
+						statusCode,
+					)
+				}
+			}
+		}
+	}
+
+	for name, predicate := range predicates {
+		if predicate(fmt.Errorf("not an HTTPError")) || predicate(nil) {
+			t.Fatalf("%s matched an error which is not an HTTPError.", name)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// DiagnosticsFromError
+// ----------------------------------------------------------------------------
+
+// Ensure validation errors are reported per attribute and point at the
+// attribute of the resource where possible
+func TestDiagnosticsFromError_AttributePath(t *testing.T) {
+	resourceSchema := map[string]*schema.Schema{
+		"name":      {Type: schema.TypeString, Optional: true},
+		"domain_id": {Type: schema.TypeInt, Optional: true},
+	}
+	d := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{})
+
+	httpErr := HTTPError{
+		Endpoint:   "/api/hosts",
+		StatusCode: http.StatusUnprocessableEntity,
+		Errors: map[string][]string{
+			"name":   {"has already been taken"},
+			"domain": {"can't be blank"},
+			"base":   {"is invalid"},
+		},
+	}
+
+	diags := DiagnosticsFromError(d, fmt.Errorf("wrapped: %w", httpErr))
+
+	expected := map[string]cty.Path{
+		"base is invalid":             nil,
+		"domain can't be blank":       cty.GetAttrPath("domain_id"),
+		"name has already been taken": cty.GetAttrPath("name"),
+	}
+	if len(diags) != len(expected) {
+		t.Fatalf(
+			"DiagnosticsFromError did not return a diagnostic per validation error. "+
+				"Expected [%d], got [%d].",
+			len(expected),
+			len(diags),
+		)
+	}
+	for _, diagnostic := range diags {
+		path, ok := expected[diagnostic.Summary]
+		if !ok || !reflect.DeepEqual(path, diagnostic.AttributePath) {
+			t.Fatalf(
+				"DiagnosticsFromError did not point [%s] at the expected attribute. "+
+					"Expected [%#v], got [%#v].",
+				diagnostic.Summary,
+				path,
+				diagnostic.AttributePath,
+			)
+		}
+	}
+}
+
+// Ensure other errors are reported as a single diagnostic
+func TestDiagnosticsFromError_OtherErrors(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+
+	for _, err := range []error{
+		fmt.Errorf("connection refused"),
+		HTTPError{Endpoint: "/api/hosts", StatusCode: http.StatusNotFound, Message: "not found"},
+	} {
+		diags := DiagnosticsFromError(d, err)
+		if len(diags) != 1 || !strings.Contains(diags[0].Summary, err.Error()) {
+			t.Fatalf(
+				"DiagnosticsFromError did not return a single diagnostic for [%s]. Got [%+v].",
+				err,
+				diags,
+			)
+		}
+	}
+
+	if diags := DiagnosticsFromError(d, nil); diags != nil {
+		t.Fatalf("DiagnosticsFromError returned diagnostics for nil. Got [%+v].", diags)
+	}
+}
@@ -0,0 +1,156 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	// Endpoint of the external usergroups of the usergroup with the given ID
+	ExternalUsergroupEndpointPrefix = "usergroups/%d/external_usergroups"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanExternalUsergroup API model maps a group of an external
+// authentication source, ie: an LDAP group, to a usergroup.  The members of
+// the external group become members of the usergroup when the external
+// usergroup is refreshed or the users log in.
+type ForemanExternalUsergroup struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// ID of the authentication source the group is defined in
+	AuthSourceId int `json:"auth_source_id"`
+}
+
+// Implement the Marshaler interface
+func (fe ForemanExternalUsergroup) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/external_usergroup.go#MarshalJSON")
+
+	feMap := map[string]interface{}{}
+
+	feMap["name"] = fe.Name
+	feMap["auth_source_id"] = intIdToJSONString(fe.AuthSourceId)
+
+	log.Debugf("feMap: [%v]", feMap)
+
+	return json.Marshal(feMap)
+}
+
+// Implement the Unmarshaler interface
+func (fe *ForemanExternalUsergroup) UnmarshalJSON(b []byte) error {
+	var jsonDecErr error
+
+	// Unmarshal the common Foreman object properties
+	var fo ForemanObject
+	jsonDecErr = json.Unmarshal(b, &fo)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fe.ForemanObject = fo
+
+	// Depending on the Foreman version, the authentication source is
+	// returned as ID or as object
+	var feJSON struct {
+		AuthSourceId int           `json:"auth_source_id"`
+		AuthSource   ForemanObject `json:"auth_source"`
+	}
+	jsonDecErr = json.Unmarshal(b, &feJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fe.AuthSourceId = feJSON.AuthSourceId
+	if fe.AuthSourceId == 0 {
+		fe.AuthSourceId = feJSON.AuthSource.Id
+	}
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateExternalUsergroup adds the supplied ForemanExternalUsergroup to the
+// usergroup with the given ID and returns the created
+// ForemanExternalUsergroup reference.
+func (c *Client) CreateExternalUsergroup(ctx context.Context, usergroupId int, e *ForemanExternalUsergroup) (*ForemanExternalUsergroup, error) {
+	log.Tracef("foreman/api/external_usergroup.go#Create")
+
+	reqEndpoint := "/" + fmt.Sprintf(ExternalUsergroupEndpointPrefix, usergroupId)
+
+	eJSONBytes, jsonEncErr := c.WrapJSON("external_usergroup", e)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("externalUsergroupJSONBytes: [%s]", eJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(eJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdExternalUsergroup ForemanExternalUsergroup
+	sendErr := c.SendAndParse(req, &createdExternalUsergroup)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdExternalUsergroup: [%+v]", createdExternalUsergroup)
+
+	return &createdExternalUsergroup, nil
+}
+
+// DeleteExternalUsergroup removes the ForemanExternalUsergroup identified by
+// the supplied ID from the usergroup with the given ID
+func (c *Client) DeleteExternalUsergroup(ctx context.Context, usergroupId int, id int) error {
+	log.Tracef("foreman/api/external_usergroup.go#Delete")
+
+	reqEndpoint := "/" + fmt.Sprintf(ExternalUsergroupEndpointPrefix+"/%d", usergroupId, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// RefreshExternalUsergroup synchronizes the members of the usergroup with
+// the members of the external group identified by the supplied ID
+func (c *Client) RefreshExternalUsergroup(ctx context.Context, usergroupId int, id int) error {
+	log.Tracef("foreman/api/external_usergroup.go#Refresh")
+
+	reqEndpoint := "/" + fmt.Sprintf(ExternalUsergroupEndpointPrefix+"/%d/refresh", usergroupId, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
@@ -0,0 +1,250 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	FilterEndpointPrefix = "filters"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanFilter API model represents a filter of a role.  A filter grants
+// permissions for the resources of one type, optionally limited by a search.
+type ForemanFilter struct {
+	// Inherits the base object's attributes.  Filters do not have a name.
+	ForemanObject
+	// Locations and organizations the filter is limited to.  Only sent if
+	// the filter overrides the taxonomies of its role.
+	ForemanTaxonomies
+
+	// ID of the role the filter belongs to
+	RoleId int `json:"role_id"`
+	// Search limiting the resources the permissions apply to, ie:
+	// `hostgroup = "web"`.  Empty for unlimited filters.
+	Search string `json:"search"`
+	// Type of the resources the permissions apply to, ie: "Host".  Foreman
+	// derives it from the permissions.
+	ResourceType string `json:"-"`
+	// IDs of the permissions granted by the filter
+	PermissionIds []int `json:"permission_ids"`
+	// Names of the permissions granted by the filter
+	PermissionNames []string `json:"-"`
+	// Whether the filter applies to all resources of its type
+	Unlimited bool `json:"-"`
+	// Whether the filter overrides the taxonomies of its role
+	Override bool `json:"override"`
+}
+
+// ForemanFilter struct used for JSON decode.
+type foremanFilterJSON struct {
+	Search       *string             `json:"search"`
+	ResourceType *string             `json:"resource_type"`
+	Unlimited    bool                `json:"unlimited"`
+	Override     bool                `json:"override"`
+	Role         ForemanObject       `json:"role"`
+	Permissions  []ForemanPermission `json:"permissions"`
+}
+
+// Implement the Marshaler interface
+func (ff ForemanFilter) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/filter.go#MarshalJSON")
+
+	ffMap := map[string]interface{}{}
+
+	ffMap["role_id"] = intIdToJSONString(ff.RoleId)
+	ffMap["search"] = ff.Search
+	ffMap["override"] = ff.Override
+	ffMap["permission_ids"] = ff.PermissionIds
+	if ff.PermissionIds == nil {
+		ffMap["permission_ids"] = []int{}
+	}
+
+	// Foreman replaces the taxonomies of filters which do not override them
+	// with the taxonomies of the role
+	if ff.Override {
+		if ff.LocationIds != nil {
+			ffMap["location_ids"] = ff.LocationIds
+		}
+		if ff.OrganizationIds != nil {
+			ffMap["organization_ids"] = ff.OrganizationIds
+		}
+	}
+
+	log.Debugf("ffMap: [%v]", ffMap)
+
+	return json.Marshal(ffMap)
+}
+
+// Implement the Unmarshaler interface
+func (ff *ForemanFilter) UnmarshalJSON(b []byte) error {
+	var jsonDecErr error
+
+	// Unmarshal the common Foreman object properties
+	var fo ForemanObject
+	jsonDecErr = json.Unmarshal(b, &fo)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	ff.ForemanObject = fo
+
+	var ffJSON foremanFilterJSON
+	jsonDecErr = json.Unmarshal(b, &ffJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	if ffJSON.Search != nil {
+		ff.Search = *ffJSON.Search
+	}
+	if ffJSON.ResourceType != nil {
+		ff.ResourceType = *ffJSON.ResourceType
+	}
+	ff.Unlimited = ffJSON.Unlimited
+	ff.Override = ffJSON.Override
+	ff.RoleId = ffJSON.Role.Id
+
+	ff.PermissionIds = make([]int, len(ffJSON.Permissions))
+	ff.PermissionNames = make([]string, len(ffJSON.Permissions))
+	for idx, permission := range ffJSON.Permissions {
+		ff.PermissionIds[idx] = permission.Id
+		ff.PermissionNames[idx] = permission.Name
+	}
+
+	// Unmarshal the assigned locations and organizations
+	ff.ForemanTaxonomies, jsonDecErr = unmarshalForemanTaxonomies(b)
+	return jsonDecErr
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateFilter creates a new ForemanFilter with the attributes of the
+// supplied ForemanFilter reference and returns the created ForemanFilter
+// reference.  The returned reference will have its ID and other API default
+// values set by this function.
+func (c *Client) CreateFilter(ctx context.Context, f *ForemanFilter) (*ForemanFilter, error) {
+	log.Tracef("foreman/api/filter.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", FilterEndpointPrefix)
+
+	filterJSONBytes, jsonEncErr := c.WrapJSON("filter", f)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("filterJSONBytes: [%s]", filterJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(filterJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdFilter ForemanFilter
+	sendErr := c.SendAndParse(req, &createdFilter)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdFilter: [%+v]", createdFilter)
+
+	return &createdFilter, nil
+}
+
+// ReadFilter reads the attributes of a ForemanFilter identified by the
+// supplied ID and returns a ForemanFilter reference.
+func (c *Client) ReadFilter(ctx context.Context, id int) (*ForemanFilter, error) {
+	log.Tracef("foreman/api/filter.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", FilterEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readFilter ForemanFilter
+	sendErr := c.SendAndParse(req, &readFilter)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readFilter: [%+v]", readFilter)
+
+	return &readFilter, nil
+}
+
+// UpdateFilter updates a ForemanFilter's attributes.  The filter with the ID
+// of the supplied ForemanFilter will be updated. A new ForemanFilter
+// reference is returned with the attributes from the result of the update
+// operation.
+func (c *Client) UpdateFilter(ctx context.Context, f *ForemanFilter) (*ForemanFilter, error) {
+	log.Tracef("foreman/api/filter.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", FilterEndpointPrefix, f.Id)
+
+	filterJSONBytes, jsonEncErr := c.WrapJSON("filter", f)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("filterJSONBytes: [%s]", filterJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(filterJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedFilter ForemanFilter
+	sendErr := c.SendAndParse(req, &updatedFilter)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedFilter: [%+v]", updatedFilter)
+
+	return &updatedFilter, nil
+}
+
+// DeleteFilter deletes the ForemanFilter identified by the supplied ID
+func (c *Client) DeleteFilter(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/filter.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", FilterEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
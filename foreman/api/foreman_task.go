@@ -2,13 +2,22 @@ package api
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"github.com/HanseMerkur/terraform-provider-utils/log"
 	"net/http"
+	"strings"
 	"time"
 )
 
+const (
+	// Delay before polling the state of an asynchronous task for the first
+	// time, if not configured otherwise
+	DefaultTaskPollInterval = time.Second
+	// Upper bound of the exponential backoff between two polls of an
+	// asynchronous task, if not configured otherwise
+	DefaultTaskPollMaxInterval = 30 * time.Second
+)
+
 // ForemanTask is either the task from /foreman_tasks/.../<uuid> or a response
 // from a Katello endpoint, which uses the async_task (in Foreman source code) function.
 // The most important fields are covered, but there are more.
@@ -40,36 +49,103 @@ type ForemanTask struct {
 	} `json:"available_actions"`
 }
 
-// waitForKatelloAsyncTask provides a method to wait for a Katello asynchronous task to finish.
-func (c *Client) waitForKatelloAsyncTask(taskID string) (*ForemanTask, error) {
+// ForemanTaskError is returned when an asynchronous task failed, was paused
+// or did not finish in time.  It carries the last known state of the task.
+type ForemanTaskError struct {
+	// The last state of the task read from the API
+	Task ForemanTask
+	// Why waiting for the task was aborted
+	Reason string
+}
+
+func (e ForemanTaskError) Error() string {
+	msg := fmt.Sprintf(
+		"Foreman task [%s] (%s) %s: state [%s], result [%s], progress [%.0f%%]",
+		e.Task.Id,
+		e.Task.Label,
+		e.Reason,
+		e.Task.State,
+		e.Task.Result,
+		e.Task.Progress*100,
+	)
+	if len(e.Task.Humanized.Errors) > 0 {
+		msg += ", errors: " + strings.Join(e.Task.Humanized.Errors, "; ")
+	}
+	return msg
+}
+
+// waitForKatelloAsyncTask provides a method to wait for a Katello asynchronous
+// task to finish.  The task is polled with an exponential backoff between
+// ClientConfig.TaskPollInterval and ClientConfig.TaskPollMaxInterval until it
+// is no longer pending, the ClientConfig.TaskTimeout expired or the context
+// is done - whichever comes first.  A paused task or a task with the result
+// "error" is reported as a ForemanTaskError.
+func (c *Client) waitForKatelloAsyncTask(ctx context.Context, taskID string) (*ForemanTask, error) {
 	log.Tracef("waitForKatelloAsyncTask")
 
-	ctx := context.TODO()
-	const endpoint = "/foreman_tasks/api/tasks/%s"
-	req, err := c.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(endpoint, taskID), nil)
-	if err != nil {
-		return nil, err
+	if c.clientConfig.TaskTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.clientConfig.TaskTimeout)
+		defer cancel()
+	}
+
+	interval := c.clientConfig.TaskPollInterval
+	if interval <= 0 {
+		interval = DefaultTaskPollInterval
+	}
+	maxInterval := c.clientConfig.TaskPollMaxInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultTaskPollMaxInterval
 	}
 
-	// This works as a retry counter, currently set to 5 tries, e.g. 4 retries
-	for counter := 0; counter < 5; counter++ {
-		log.Tracef("waitForKatelloAsyncTask retry loop with counter %d", counter)
+	const endpoint = "/foreman_tasks/api/tasks/%s"
+	var task ForemanTask
+
+	for {
+		req, err := c.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(endpoint, taskID), nil)
+		if err != nil {
+			return nil, err
+		}
 
-		var task ForemanTask
-		err = c.SendAndParse(req, &task)
+		var polledTask ForemanTask
+		err = c.SendAndParse(req, &polledTask)
 		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ForemanTaskError{Task: task, Reason: "did not finish in time"}
+			}
 			return nil, err
 		}
+		task = polledTask
 
 		log.Debugf("task: %+v", task)
+
+		if task.State == "paused" {
+			return nil, ForemanTaskError{Task: task, Reason: "was paused"}
+		}
+		if task.Result == "error" {
+			return nil, ForemanTaskError{Task: task, Reason: "failed"}
+		}
 		if !task.Pending {
 			return &task, nil
 		}
 
-		log.Infof("Task %s is still pending, sleeping for %d seconds and then retrying…", task.Id, counter)
-		time.Sleep(time.Duration(counter) * time.Second)
-	}
+		log.Infof(
+			"Task %s is still pending (state [%s], progress [%.0f%%]), polling again in %s",
+			task.Id,
+			task.State,
+			task.Progress*100,
+			interval,
+		)
+
+		select {
+		case <-ctx.Done():
+			return nil, ForemanTaskError{Task: task, Reason: "did not finish in time"}
+		case <-time.After(interval):
+		}
 
-	// The retries should produce a success. If not, fail with error
-	return nil, errors.New("Error in retrying to wait for task " + taskID)
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
 }
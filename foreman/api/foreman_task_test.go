@@ -0,0 +1,147 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Test Helper Functions
+// ----------------------------------------------------------------------------
+
+const testTaskID = "d4d4d4d4-0000-1111-2222-333333333333"
+
+// Creates a client polling tasks with short intervals and a mock task
+// endpoint.  Every poll is answered with the next task from the supplied
+// list - the last task is repeated once the list is exhausted.
+func newTaskAPIAndClient(t *testing.T, conf ClientConfig, tasks []ForemanTask) (*Client, func(), *int) {
+	if conf.TaskPollInterval == 0 {
+		conf.TaskPollInterval = time.Millisecond
+	}
+	if conf.TaskPollMaxInterval == 0 {
+		conf.TaskPollMaxInterval = 2 * time.Millisecond
+	}
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, conf)
+
+	polls := 0
+	mux.HandleFunc(FOREMAN_TASKS_API_URL_PREFIX+"/tasks/"+testTaskID, func(w http.ResponseWriter, r *http.Request) {
+		idx := polls
+		if idx >= len(tasks) {
+			idx = len(tasks) - 1
+		}
+		polls++
+		if err := json.NewEncoder(w).Encode(tasks[idx]); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	return client, server.Close, &polls
+}
+
+func pendingTask(progress float64) ForemanTask {
+	task := ForemanTask{Id: testTaskID, Pending: true, State: "running", Result: "pending", Progress: progress}
+	task.Label = "Actions::Katello::ContentView::Publish"
+	return task
+}
+
+// ----------------------------------------------------------------------------
+// Client.waitForKatelloAsyncTask
+// ----------------------------------------------------------------------------
+
+// Ensure the task is polled until it is no longer pending
+func TestWaitForKatelloAsyncTask_PollsUntilFinished(t *testing.T) {
+	finished := ForemanTask{Id: testTaskID, State: "stopped", Result: "success", Progress: 1}
+	tasks := []ForemanTask{pendingTask(0.1), pendingTask(0.5), pendingTask(0.9), finished}
+	client, closeServer, polls := newTaskAPIAndClient(t, ClientConfig{}, tasks)
+	defer closeServer()
+
+	task, err := client.waitForKatelloAsyncTask(context.TODO(), testTaskID)
+	if err != nil {
+		t.Fatalf("waitForKatelloAsyncTask returned an error: [%s]", err)
+	}
+	if *polls != len(tasks) || task.Result != "success" {
+		t.Fatalf(
+			"waitForKatelloAsyncTask did not poll until the task finished. "+
+				"Expected [%d] polls, got [%d] with result [%s].",
+			len(tasks),
+			*polls,
+			task.Result,
+		)
+	}
+}
+
+// Ensure paused and failed tasks are reported as ForemanTaskError including
+// the task's errors
+func TestWaitForKatelloAsyncTask_FailedTask(t *testing.T) {
+	paused := pendingTask(0.4)
+	paused.State = "paused"
+	paused.Humanized.Errors = []string{"Pulp is unreachable"}
+
+	failed := ForemanTask{Id: testTaskID, State: "stopped", Result: "error", Progress: 1}
+	failed.Humanized.Errors = []string{"Repository is missing"}
+
+	for _, task := range []ForemanTask{paused, failed} {
+		client, closeServer, _ := newTaskAPIAndClient(t, ClientConfig{}, []ForemanTask{pendingTask(0), task})
+
+		_, err := client.waitForKatelloAsyncTask(context.TODO(), testTaskID)
+		closeServer()
+
+		var taskErr ForemanTaskError
+		if !errors.As(err, &taskErr) {
+			t.Fatalf(
+				"waitForKatelloAsyncTask did not return a ForemanTaskError for state [%s] "+
+					"and result [%s]. Got [%v].",
+				task.State,
+				task.Result,
+				err,
+			)
+		}
+		if !strings.Contains(err.Error(), task.Humanized.Errors[0]) {
+			t.Fatalf(
+				"ForemanTaskError does not include the task's errors. "+
+					"Expected [%s] in [%s].",
+				task.Humanized.Errors[0],
+				err.Error(),
+			)
+		}
+	}
+}
+
+// Ensure the configured task timeout aborts polling
+func TestWaitForKatelloAsyncTask_Timeout(t *testing.T) {
+	conf := ClientConfig{TaskTimeout: 20 * time.Millisecond}
+	client, closeServer, _ := newTaskAPIAndClient(t, conf, []ForemanTask{pendingTask(0.3)})
+	defer closeServer()
+
+	_, err := client.waitForKatelloAsyncTask(context.TODO(), testTaskID)
+
+	var taskErr ForemanTaskError
+	if !errors.As(err, &taskErr) || taskErr.Task.Progress != 0.3 {
+		t.Fatalf(
+			"waitForKatelloAsyncTask did not time out with the last task state. Got [%v].",
+			err,
+		)
+	}
+}
+
+// Ensure the deadline of the caller's context aborts polling
+func TestWaitForKatelloAsyncTask_ContextDeadline(t *testing.T) {
+	client, closeServer, _ := newTaskAPIAndClient(t, ClientConfig{}, []ForemanTask{pendingTask(0)})
+	defer closeServer()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.waitForKatelloAsyncTask(ctx, testTaskID)
+	if err == nil {
+		t.Fatalf(
+			"waitForKatelloAsyncTask did not return an error when the context " +
+				"deadline was exceeded. Expected [error] got [nil]",
+		)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/HanseMerkur/terraform-provider-utils/log"
 )
@@ -28,6 +29,8 @@ const (
 	PowerCycle = "cycle"
 	// PowerState : Power state check operation
 	PowerState = "state"
+	// PowerStatusSuffix : Suffix appended to API url for reading the power state
+	PowerStatusSuffix = "power_status"
 	// BootSuffix : Suffix appended to API url for power operations
 	BootSuffix = "boot"
 	// BootDisk : Boot to Disk
@@ -40,6 +43,14 @@ const (
 	PowerBios = "bios"
 )
 
+// Build states of a host as defined by Foreman
+const (
+	HostBuildStatusBuilt        = 0
+	HostBuildStatusPending      = 1
+	HostBuildStatusTokenExpired = 2
+	HostBuildStatusBuildFailed  = 3
+)
+
 // -----------------------------------------------------------------------------
 // Struct Definition and Helpers
 // -----------------------------------------------------------------------------
@@ -79,6 +90,11 @@ type ForemanHost struct {
 	EnvironmentId *int `json:"environment_id,omitempty"`
 	// ID of the hostgroup to assign the host
 	HostgroupId *int `json:"hostgroup_id,omitempty"`
+	// ID of the location of the host.  Overrides the provider's location.
+	LocationId int `json:"location_id,omitempty"`
+	// ID of the organization of the host.  Overrides the provider's
+	// organization.
+	OrganizationId int `json:"organization_id,omitempty"`
 	// ID of the architecture of this host
 	ArchitectureId *int `json:"architecture_id,omitempty"`
 	// Name of the architecture of this host
@@ -126,7 +142,32 @@ type ForemanHost struct {
 }
 
 func (fh *ForemanHost) isBuilt() bool {
-	return fh.BuildStatus == 0
+	return fh.BuildStatus == HostBuildStatusBuilt
+}
+
+// buildFailed returns whether the build of the host failed or its build
+// token expired before the host finished its installation
+func (fh *ForemanHost) buildFailed() bool {
+	return fh.BuildStatus == HostBuildStatusBuildFailed ||
+		fh.BuildStatus == HostBuildStatusTokenExpired
+}
+
+// HostBuildError is returned when a host failed to build or did not finish
+// its build in time.  It carries the last known state of the host.
+type HostBuildError struct {
+	// The last state of the host read from the API
+	Host ForemanHost
+	// Why waiting for the build was aborted
+	Reason string
+}
+
+func (e HostBuildError) Error() string {
+	return fmt.Sprintf(
+		"host [%s] %s: build status [%s]",
+		e.Host.Name,
+		e.Reason,
+		e.Host.BuildStatusLabel,
+	)
 }
 
 // ForemanInterfacesAttribute representing a hosts defined network interfaces
@@ -149,6 +190,16 @@ type ForemanInterfacesAttribute struct {
 	AttachedDevices string `json:"attached_devices,omitempty"`
 	AttachedTo      string `json:"attached_to,omitempty"`
 
+	// VLAN ID of virtual interfaces
+	Tag string `json:"tag"`
+	// MTU of the interface, overrides the MTU of the subnet
+	MTU int `json:"mtu,omitempty"`
+	// Bonding mode of bond interfaces, ie: "802.3ad"
+	Mode string `json:"mode,omitempty"`
+	// Space separated options of bond interfaces, ie: "miimon=100".  Always
+	// sent, an empty string removes the options.
+	BondOptions string `json:"bond_options"`
+
 	// NOTE(ALL): These settings only apply to virtual machines
 	// ComputeAttributes are hypervisor specific features
 	ComputeAttributes map[string]interface{} `json:"compute_attributes,omitempty"`
@@ -165,6 +216,31 @@ type ForemanInterfacesAttribute struct {
 	Destroy bool `json:"_destroy,omitempty"`
 }
 
+// Implement the Marshaler interface
+func (fia ForemanInterfacesAttribute) MarshalJSON() ([]byte, error) {
+	// Only send the ID of interfaces tagged for removal.  Their other
+	// attributes - ie: "primary" - would still be validated by Foreman
+	// against the remaining interfaces.
+	if fia.Destroy {
+		return json.Marshal(map[string]interface{}{
+			"id":       fia.Id,
+			"_destroy": true,
+		})
+	}
+
+	// Encode the interface with the default encoder - the type conversion
+	// drops this method to avoid the recursion
+	type foremanInterfacesAttribute ForemanInterfacesAttribute
+	return json.Marshal(foremanInterfacesAttribute(fia))
+}
+
+// A host belongs to exactly one location and organization.  They are used as
+// the taxonomy context of the request, so that Foreman scopes the request to
+// the host's taxonomies.
+func (fh ForemanHost) taxonomyContext() (int, int) {
+	return fh.LocationId, fh.OrganizationId
+}
+
 // foremanHostDecode struct used for JSON decode.
 type foremanHostDecode struct {
 	ForemanHost
@@ -198,7 +274,7 @@ type BMCBoot struct {
 // BMCBoot type struct populated with an action
 //
 // Example: https://<foreman>/api/hosts/<hostname>/boot
-func (c *Client) SendPowerCommand(ctx context.Context, h *ForemanHost, cmd interface{}, retryCount int) error {
+func (c *Client) SendPowerCommand(ctx context.Context, h *ForemanHost, cmd interface{}) error {
 	// Initialize suffix variable,
 	suffix := ""
 
@@ -226,20 +302,7 @@ func (c *Client) SendPowerCommand(ctx context.Context, h *ForemanHost, cmd inter
 		return reqErr
 	}
 
-	retry := 0
-	var sendErr error
-	// retry until the successful Operation
-	// or until # of allowed retries is reached
-	for retry < retryCount {
-		log.Debugf("SendPower: Retry #[%d]", retry)
-		sendErr = c.SendAndParse(req, &cmd)
-		if sendErr != nil {
-			retry++
-		} else {
-			break
-		}
-	}
-
+	sendErr := c.SendAndParse(req, &cmd)
 	if sendErr != nil {
 		return sendErr
 	}
@@ -257,6 +320,49 @@ func (c *Client) SendPowerCommand(ctx context.Context, h *ForemanHost, cmd inter
 	return nil
 }
 
+// ReadHostPowerState reads the power state of the host and returns either
+// PowerOn or PowerOff.  Unlike the PowerState operation, reading the power
+// status does not send a PUT request - it is safe to use while refreshing.
+// Compute resources may report the state of virtual machines in their own
+// terms, ie: "poweredOn" or "running", these are normalized as well.
+func (c *Client) ReadHostPowerState(ctx context.Context, h *ForemanHost) (string, error) {
+	log.Tracef("foreman/api/host.go#ReadHostPowerState")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/%s", HostEndpointPrefix, h.Id, PowerStatusSuffix)
+
+	req, reqErr := c.NewRequestWithContext(ctx, http.MethodGet, reqEndpoint, nil)
+	if reqErr != nil {
+		return "", reqErr
+	}
+
+	var powerStatus struct {
+		State      string `json:"state"`
+		StatusText string `json:"statusText"`
+	}
+	sendErr := c.SendAndParse(req, &powerStatus)
+	if sendErr != nil {
+		return "", sendErr
+	}
+
+	log.Debugf("Power Status: [%+v]", powerStatus)
+
+	switch strings.ToLower(powerStatus.State) {
+	case "on", "poweredon", "running", "up":
+		return PowerOn, nil
+	case "off", "poweredoff", "stopped", "shutoff", "down":
+		return PowerOff, nil
+	}
+	if powerStatus.StatusText != "" {
+		return "", fmt.Errorf(
+			"unknown power state [%s] of host [%s]: %s",
+			powerStatus.State,
+			h.Name,
+			powerStatus.StatusText,
+		)
+	}
+	return "", fmt.Errorf("unknown power state [%s] of host [%s]", powerStatus.State, h.Name)
+}
+
 // -----------------------------------------------------------------------------
 // CRUD Implementation
 // -----------------------------------------------------------------------------
@@ -265,7 +371,7 @@ func (c *Client) SendPowerCommand(ctx context.Context, h *ForemanHost, cmd inter
 // ForemanHost reference and returns the created ForemanHost reference.  The
 // returned reference will have its ID and other API default values set by this
 // function.
-func (c *Client) CreateHost(ctx context.Context, h *ForemanHost, retryCount int) (*ForemanHost, error) {
+func (c *Client) CreateHost(ctx context.Context, h *ForemanHost) (*ForemanHost, error) {
 	log.Tracef("foreman/api/host.go#CreateHost")
 
 	reqEndpoint := fmt.Sprintf("/%s", HostEndpointPrefix)
@@ -288,21 +394,7 @@ func (c *Client) CreateHost(ctx context.Context, h *ForemanHost, retryCount int)
 	}
 
 	var createdHost foremanHostDecode
-
-	retry := 0
-	var sendErr error
-	// retry until successful Host creation
-	// or until # of allowed retries is reached
-	for retry < retryCount {
-		log.Debugf("CreatedHost: Retry #[%d]", retry)
-		sendErr = c.SendAndParse(req, &createdHost)
-		if sendErr != nil {
-			retry++
-		} else {
-			break
-		}
-	}
-
+	sendErr := c.SendAndParse(req, &createdHost)
 	if sendErr != nil {
 		return nil, sendErr
 	}
@@ -368,7 +460,7 @@ func (c *Client) ReadHost(ctx context.Context, id int) (*ForemanHost, error) {
 // UpdateHost updates a ForemanHost's attributes.  The host with the ID of the
 // supplied ForemanHost will be updated. A new ForemanHost reference is
 // returned with the attributes from the result of the update operation.
-func (c *Client) UpdateHost(ctx context.Context, h *ForemanHost, retryCount int) (*ForemanHost, error) {
+func (c *Client) UpdateHost(ctx context.Context, h *ForemanHost) (*ForemanHost, error) {
 	log.Tracef("foreman/api/host.go#UpdateHost")
 
 	reqEndpoint := fmt.Sprintf("/%s/%d", HostEndpointPrefix, h.Id)
@@ -391,20 +483,7 @@ func (c *Client) UpdateHost(ctx context.Context, h *ForemanHost, retryCount int)
 	}
 
 	var updatedHost foremanHostDecode
-	retry := 0
-	var sendErr error
-	// retry until the successful Host Update
-	// or until # of allowed retries is reached
-	for retry < retryCount {
-		log.Debugf("UpdateHost: Retry #[%d]", retry)
-		sendErr = c.SendAndParse(req, &updatedHost)
-		if sendErr != nil {
-			retry++
-		} else {
-			break
-		}
-	}
-
+	sendErr := c.SendAndParse(req, &updatedHost)
 	if sendErr != nil {
 		return nil, sendErr
 	}
@@ -445,6 +524,61 @@ func (c *Client) DeleteHost(ctx context.Context, id int) error {
 	return c.SendAndParse(req, nil)
 }
 
+// WaitForHostBuild polls the host identified by the supplied ID until it is
+// built.  The host is polled with the same exponential backoff as
+// asynchronous tasks, see ClientConfig.TaskPollInterval, until the build
+// failed, the token expired or the context is done.  These cases are reported
+// as a HostBuildError.
+func (c *Client) WaitForHostBuild(ctx context.Context, id int) (*ForemanHost, error) {
+	log.Tracef("foreman/api/host.go#WaitForHostBuild")
+
+	interval := c.clientConfig.TaskPollInterval
+	if interval <= 0 {
+		interval = DefaultTaskPollInterval
+	}
+	maxInterval := c.clientConfig.TaskPollMaxInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultTaskPollMaxInterval
+	}
+
+	var host ForemanHost
+	for {
+		readHost, readErr := c.ReadHost(ctx, id)
+		if readErr != nil {
+			if ctx.Err() != nil {
+				return nil, HostBuildError{Host: host, Reason: "did not finish its build in time"}
+			}
+			return nil, readErr
+		}
+		host = *readHost
+
+		if host.isBuilt() {
+			return &host, nil
+		}
+		if host.buildFailed() {
+			return nil, HostBuildError{Host: host, Reason: "failed to build"}
+		}
+
+		log.Infof(
+			"Host %s is still building (build status [%s]), polling again in %s",
+			host.Name,
+			host.BuildStatusLabel,
+			interval,
+		)
+
+		select {
+		case <-ctx.Done():
+			return nil, HostBuildError{Host: host, Reason: "did not finish its build in time"}
+		case <-time.After(interval):
+		}
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
 // Compute Attributes are only available via dedicated API endpoint. readComputeAttributes gets this endpoint.
 func (c *Client) readComputeAttributes(ctx context.Context, id int) (map[string]interface{}, error) {
 	log.Tracef("foreman/api/host.go#readComputeAttributes")
@@ -3,7 +3,6 @@ package api
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -29,6 +28,8 @@ const (
 type ForemanHostgroup struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Locations and organizations the hostgroup is assigned to
+	ForemanTaxonomies
 
 	// The title is a computed property representing the fullname of the
 	// hostgroup.  A hostgroup's title is a path-like string from the head
@@ -88,6 +89,7 @@ type ForemanHostgroup struct {
 // types. However, we are only interested in the IDs returned.
 type foremanHostGroupDecode struct {
 	ForemanHostgroup
+	foremanTaxonomiesJSON
 	PuppetClassesDecode       []ForemanObject      `json:"puppetclasses"`
 	ConfigGroupsDecode        []ForemanObject      `json:"config_groups"`
 	HostGroupParametersDecode []ForemanKVParameter `json:"parameters,omitempty"`
@@ -160,6 +162,7 @@ func (c *Client) ReadHostgroup(ctx context.Context, id int) (*ForemanHostgroup,
 	readHostgroup.PuppetClassIds = foremanObjectArrayToIdIntArray(readHostgroup.PuppetClassesDecode)
 	readHostgroup.ConfigGroupIds = foremanObjectArrayToIdIntArray(readHostgroup.ConfigGroupsDecode)
 	readHostgroup.HostGroupParameters = readHostgroup.HostGroupParametersDecode
+	readHostgroup.ForemanTaxonomies = readHostgroup.toForemanTaxonomies()
 
 	log.Debugf("readHostgroup: [%+v]", readHostgroup)
 
@@ -201,6 +204,7 @@ func (c *Client) UpdateHostgroup(ctx context.Context, h *ForemanHostgroup) (*For
 	updatedHostgroup.PuppetClassIds = foremanObjectArrayToIdIntArray(updatedHostgroup.PuppetClassesDecode)
 	updatedHostgroup.ConfigGroupIds = foremanObjectArrayToIdIntArray(updatedHostgroup.ConfigGroupsDecode)
 	updatedHostgroup.HostGroupParameters = updatedHostgroup.HostGroupParametersDecode
+	updatedHostgroup.ForemanTaxonomies = updatedHostgroup.toForemanTaxonomies()
 
 	log.Debugf("updatedHostgroup: [%+v]", updatedHostgroup)
 
@@ -236,52 +240,8 @@ func (c *Client) DeleteHostgroup(ctx context.Context, id int) error {
 func (c *Client) QueryHostgroup(ctx context.Context, h *ForemanHostgroup) (QueryResponse, error) {
 	log.Tracef("foreman/api/hostgroup.go#Search")
 
-	queryResponse := QueryResponse{}
-
 	reqEndpoint := fmt.Sprintf("/%s", HostgroupEndpointPrefix)
-	req, reqErr := c.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		reqEndpoint,
-		nil,
-	)
-	if reqErr != nil {
-		return queryResponse, reqErr
-	}
-
-	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
-	title := `"` + h.Title + `"`
-	reqQuery.Set("search", "title="+title)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanHostgroup for
-	// the results
-	results := []ForemanHostgroup{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanHostgroup to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return searchQueryResponse[ForemanHostgroup](ctx, c, reqEndpoint, SearchQuery{
+		Search: SearchEquals("title", h.Title),
+	})
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -24,8 +25,51 @@ type ForemanHTTPProxy struct {
 	// Inherits the base object's attributes
 	ForemanObject
 
+	// Inherits the location and organization assignments
+	ForemanTaxonomies
+
 	// Uniform resource locator of the proxy (ie: https://server:8008)
 	URL string `json:"url"`
+	// Username used to authenticate with the proxy
+	Username string `json:"username"`
+	// Password used to authenticate with the proxy.  Foreman does not return
+	// the password.  Nil keeps the current password, an empty string removes
+	// it.
+	Password *string `json:"password,omitempty"`
+	// PEM encoded CA certificate used to verify the proxy's TLS certificate.
+	// Nil if the server did not return the attribute.
+	CACert *string `json:"cacert,omitempty"`
+}
+
+// ForemanHTTPProxyTestResult is the result of a connection test through a
+// HTTP proxy
+type ForemanHTTPProxyTestResult struct {
+	// Either "success" or "error"
+	Status string `json:"status"`
+	// Message describing the result
+	Message string `json:"message"`
+}
+
+// Success returns whether the connection test succeeded
+func (r ForemanHTTPProxyTestResult) Success() bool {
+	return r.Status == "success"
+}
+
+// Implement the Unmarshaler interface
+func (fp *ForemanHTTPProxy) UnmarshalJSON(b []byte) error {
+	// Decode the http proxy properties with the default decoder - the type
+	// conversion drops this method to avoid the recursion
+	type foremanHTTPProxy ForemanHTTPProxy
+	var obj foremanHTTPProxy
+	jsonDecErr := json.Unmarshal(b, &obj)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	*fp = ForemanHTTPProxy(obj)
+
+	// Unmarshal the assigned locations and organizations
+	fp.ForemanTaxonomies, jsonDecErr = unmarshalForemanTaxonomies(b)
+	return jsonDecErr
 }
 
 // -----------------------------------------------------------------------------
@@ -46,8 +90,6 @@ func (c *Client) CreateHTTPProxy(ctx context.Context, s *ForemanHTTPProxy) (*For
 		return nil, jsonEncErr
 	}
 
-	log.Debugf("HTTPProxyJSONBytes: [%s]", sJSONBytes)
-
 	req, reqErr := c.NewRequestWithContext(
 		ctx,
 		http.MethodPost,
@@ -111,8 +153,6 @@ func (c *Client) UpdateHTTPProxy(ctx context.Context, s *ForemanHTTPProxy) (*For
 		return nil, jsonEncErr
 	}
 
-	log.Debugf("HTTPProxyJSONBytes: [%s]", sJSONBytes)
-
 	req, reqErr := c.NewRequestWithContext(
 		ctx,
 		http.MethodPut,
@@ -153,62 +193,67 @@ func (c *Client) DeleteHTTPProxy(ctx context.Context, id int) error {
 	return c.SendAndParse(req, nil)
 }
 
-// -----------------------------------------------------------------------------
-// Query Implementation
-// -----------------------------------------------------------------------------
+// TestHTTPProxyConnection tests the connection to the supplied test URL
+// through the ForemanHTTPProxy.  The proxy does not need to exist, so it can
+// be tested before it is created or updated.  Foreman answers a failed test with
+// 422 Unprocessable Entity - the result is returned instead of an error in
+// that case.
+func (c *Client) TestHTTPProxyConnection(ctx context.Context, p *ForemanHTTPProxy, testURL string) (*ForemanHTTPProxyTestResult, error) {
+	log.Tracef("foreman/api/HTTPProxy.go#TestConnection")
 
-// QueryHTTPProxy queries for a ForemanHTTPProxy based on the attributes of
-// the supplied ForemanHTTPProxy reference and returns a QueryResponse struct
-// containing query/response metadata and the matching smart proxy.
-func (c *Client) QueryHTTPProxy(ctx context.Context, s *ForemanHTTPProxy) (QueryResponse, error) {
-	log.Tracef("foreman/api/HTTPProxy.go#Search")
+	reqEndpoint := fmt.Sprintf("/%s/test_connection", HTTPProxyEndpointPrefix)
 
-	queryResponse := QueryResponse{}
+	testReq := map[string]interface{}{
+		"test_url":   testURL,
+		"http_proxy": p,
+	}
+	// Without an ID, the proxy is tested with the supplied attributes only.
+	// Otherwise Foreman uses the current password if none is supplied.
+	if p.Id != 0 {
+		testReq["id"] = p.Id
+	}
+	pJSONBytes, jsonEncErr := json.Marshal(testReq)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
 
-	reqEndpoint := fmt.Sprintf("/%s", HTTPProxyEndpointPrefix)
 	req, reqErr := c.NewRequestWithContext(
 		ctx,
-		http.MethodGet,
+		http.MethodPut,
 		reqEndpoint,
-		nil,
+		bytes.NewBuffer(pJSONBytes),
 	)
 	if reqErr != nil {
-		return queryResponse, reqErr
+		return nil, reqErr
 	}
 
-	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
-	name := `"` + s.Name + `"`
-	reqQuery.Set("search", "name="+name)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	var testResult ForemanHTTPProxyTestResult
+	sendErr := c.SendAndParse(req, &testResult)
 	if sendErr != nil {
-		return queryResponse, sendErr
+		var httpErr HTTPError
+		if !IsValidation(sendErr) || !errors.As(sendErr, &httpErr) ||
+			json.Unmarshal([]byte(httpErr.RespBody), &testResult) != nil {
+			return nil, sendErr
+		}
 	}
 
-	log.Debugf("queryResponse: [%+v]", queryResponse)
+	log.Debugf("testResult: [%+v]", testResult)
 
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanHTTPProxy for
-	// the results
-	results := []ForemanHTTPProxy{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanHTTPProxy to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
+	return &testResult, nil
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryHTTPProxy queries for a ForemanHTTPProxy based on the attributes of
+// the supplied ForemanHTTPProxy reference and returns a QueryResponse struct
+// containing query/response metadata and the matching smart proxy.
+func (c *Client) QueryHTTPProxy(ctx context.Context, s *ForemanHTTPProxy) (QueryResponse, error) {
+	log.Tracef("foreman/api/HTTPProxy.go#Search")
 
-	return queryResponse, nil
+	reqEndpoint := fmt.Sprintf("/%s", HTTPProxyEndpointPrefix)
+	return searchQueryResponse[ForemanHTTPProxy](ctx, c, reqEndpoint, SearchQuery{
+		Search: SearchEquals("name", s.Name),
+	})
 }
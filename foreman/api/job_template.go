@@ -3,7 +3,6 @@ package api
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"sort"
@@ -86,44 +85,10 @@ func (c *Client) CreateJobTemplate(ctx context.Context, jtObj *ForemanJobTemplat
 func (c *Client) QueryJobTemplate(ctx context.Context, jt *ForemanJobTemplate) (QueryResponse, error) {
 	utils.TraceFunctionCall()
 
-	qresp := QueryResponse{}
 	const endpoint = "/" + JobTemplateEndpointPrefix
-
-	req, err := c.NewRequestWithContext(
-		ctx, http.MethodGet, endpoint, nil,
-	)
-	if err != nil {
-		return qresp, err
-	}
-
-	reqQuery := req.URL.Query()
-	name := `"` + jt.Name + `"`
-	reqQuery.Set("search", "name="+name)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	err = c.SendAndParse(req, &qresp)
-	if err != nil {
-		return qresp, err
-	}
-
-	results := []ForemanJobTemplate{}
-	resultsBytes, err := json.Marshal(qresp.Results)
-	if err != nil {
-		return qresp, err
-	}
-
-	err = json.Unmarshal(resultsBytes, &results)
-	if err != nil {
-		return qresp, err
-	}
-
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	qresp.Results = iArr
-
-	return qresp, nil
+	return searchQueryResponse[ForemanJobTemplate](ctx, c, endpoint, SearchQuery{
+		Search: SearchEquals("name", jt.Name),
+	})
 }
 
 func (c *Client) ReadJobTemplate(ctx context.Context, id int) (*ForemanJobTemplate, error) {
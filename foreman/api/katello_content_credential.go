@@ -3,7 +3,6 @@ package api
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -163,58 +162,7 @@ func (c *Client) DeleteKatelloContentCredential(ctx context.Context, id int) err
 func (c *Client) QueryKatelloContentCredential(ctx context.Context, s *ForemanKatelloContentCredential) (QueryResponse, error) {
 	log.Tracef("foreman/api/katello_content_credential.go#Search")
 
-	queryResponse := QueryResponse{}
-
-	sJSONBytes, jsonEncErr := c.WrapJSONWithTaxonomy(nil, s)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-
-	log.Debugf("KatelloContentCredentialJSONBytes: [%s]", sJSONBytes)
-
-	req, reqErr := c.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		KatelloContentCredentialEndpointPrefix,
-		bytes.NewBuffer(sJSONBytes),
-	)
-	if reqErr != nil {
-		return queryResponse, reqErr
-	}
-
-	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
-	name := `"` + s.Name + `"`
-	reqQuery.Set("search", "name="+name)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanKatelloContentCredential for
-	// the results
-	results := []ForemanKatelloContentCredential{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanKatelloContentCredential to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return searchQueryResponse[ForemanKatelloContentCredential](ctx, c, KatelloContentCredentialEndpointPrefix, SearchQuery{
+		Search: SearchEquals("name", s.Name),
+	})
 }
@@ -52,39 +52,9 @@ type ContentViewFilterRule struct {
 // QueryContentViewFilters returns the filters including their rules
 func (c *Client) QueryContentViewFilters(ctx context.Context, cvId int) (QueryResponse, error) {
 	utils.TraceFunctionCall()
-	queryResponse := QueryResponse{}
 
 	endpoint := fmt.Sprintf(ContentViewFilters, cvId)
-	req, err := c.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
-	if err != nil {
-		return queryResponse, err
-	}
-
-	err = c.SendAndParse(req, &queryResponse)
-	if err != nil {
-		return queryResponse, err
-	}
-
-	utils.Debugf("queryResponse: %+v", queryResponse)
-
-	var results []ContentViewFilter
-	resultsBytes, err := json.Marshal(queryResponse.Results)
-	if err != nil {
-		return queryResponse, err
-	}
-
-	err = json.Unmarshal(resultsBytes, &results)
-	if err != nil {
-		return queryResponse, err
-	}
-
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return searchQueryResponse[ContentViewFilter](ctx, c, endpoint, SearchQuery{})
 }
 
 func (c *Client) CreateKatelloContentViewFilters(ctx context.Context, cvId int, cvfs *[]ContentViewFilter) (*[]ContentViewFilter, error) {
@@ -162,19 +132,13 @@ func (c *Client) CreateKatelloContentViewFilterRules(ctx context.Context, cvfId
 func (c *Client) ReadKatelloContentViewFilters(ctx context.Context, cvId int) (*[]ContentViewFilter, error) {
 	utils.TraceFunctionCall()
 
-	qr, err := c.QueryContentViewFilters(ctx, cvId)
+	endpoint := fmt.Sprintf(ContentViewFilters, cvId)
+
+	cvfs, err := Search[ContentViewFilter](ctx, c, endpoint, SearchQuery{})
 	if err != nil {
 		return nil, err
 	}
 
-	utils.Debugf("qr: %+v", qr)
-	var cvfs []ContentViewFilter
-
-	// TODO: this is redundant if queryResponse.Results already did the conversion
-	for _, item := range qr.Results {
-		cvfs = append(cvfs, item.(ContentViewFilter))
-	}
-
 	utils.Debugf("read content_view filters: %+v", cvfs)
 
 	return &cvfs, nil
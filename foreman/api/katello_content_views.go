@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/terraform-coop/terraform-provider-foreman/foreman/utils"
 	"net/http"
@@ -126,44 +125,10 @@ func (cv *ContentView) MarshalJSON() ([]byte, error) {
 func (c *Client) QueryContentView(ctx context.Context, d *ContentView) (QueryResponse, error) {
 	utils.TraceFunctionCall()
 
-	queryResponse := QueryResponse{}
-
 	endpoint := ContentViewEndpointPrefix
-	req, err := c.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
-	if err != nil {
-		return queryResponse, err
-	}
-
-	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
-	name := `"` + d.Name + `"`
-	reqQuery.Set("search", "name="+name)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	err = c.SendAndParse(req, &queryResponse)
-	if err != nil {
-		return queryResponse, err
-	}
-
-	utils.Debugf("queryResponse: %+v", queryResponse)
-
-	var results []ContentView
-	resultsBytes, err := json.Marshal(queryResponse.Results)
-	if err != nil {
-		return queryResponse, err
-	}
-	err = json.Unmarshal(resultsBytes, &results)
-	if err != nil {
-		return queryResponse, err
-	}
-
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return searchQueryResponse[ContentView](ctx, c, endpoint, SearchQuery{
+		Search: SearchEquals("name", d.Name),
+	})
 }
 
 func (c *Client) CreateKatelloContentView(ctx context.Context, cv *ContentView) (*ContentView, error) {
@@ -255,27 +220,12 @@ func (c *Client) ReadContentViewFilters(ctx context.Context, cvId int) (*[]Conte
 	utils.TraceFunctionCall()
 
 	reqEndpoint := fmt.Sprintf(ContentViewFilters, cvId)
-	var cvfs []ContentViewFilter
-	var queryResponse QueryResponse
-
-	req, err := c.NewRequestWithContext(ctx, http.MethodGet, reqEndpoint, nil)
-	if err != nil {
-		return nil, err
-	}
 
-	err = c.SendAndParse(req, &queryResponse)
+	cvfs, err := Search[ContentViewFilter](ctx, c, reqEndpoint, SearchQuery{})
 	if err != nil {
 		return nil, err
 	}
 
-	for _, item := range queryResponse.Results {
-		cvf, ok := item.(ContentViewFilter)
-		if !ok {
-			return nil, errors.New("failed to cast content view filter from results[]")
-		}
-		cvfs = append(cvfs, cvf)
-	}
-
 	utils.Debugf("read content_view filter: %+v", cvfs)
 
 	return &cvfs, nil
@@ -0,0 +1,279 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	LocationEndpointPrefix = "locations"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanLocation API model represents a location.  Together with
+// organizations, locations are the taxonomies used to scope the other objects
+// of Foreman (hosts, subnets, domains, ...).  Locations can be nested.
+type ForemanLocation struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// Name of the location including the names of its parents, ie:
+	// "Europe/DC1".  This is a computed value.
+	Title string `json:"title"`
+	// Description of the location
+	Description string `json:"description"`
+	// ID of the parent location
+	ParentId int `json:"parent_id"`
+
+	// IDs of the objects assigned to this location
+	OrganizationIds    []int `json:"organization_ids"`
+	DomainIds          []int `json:"domain_ids"`
+	SubnetIds          []int `json:"subnet_ids"`
+	SmartProxyIds      []int `json:"smart_proxy_ids"`
+	ComputeResourceIds []int `json:"compute_resource_ids"`
+}
+
+// ForemanLocation struct used for JSON decode.  Foreman API returns the
+// assigned objects as lists of ForemanObjects.  We are only interested in
+// their IDs.
+type foremanLocationJSON struct {
+	Organizations    []ForemanObject `json:"organizations"`
+	Domains          []ForemanObject `json:"domains"`
+	Subnets          []ForemanObject `json:"subnets"`
+	SmartProxies     []ForemanObject `json:"smart_proxies"`
+	ComputeResources []ForemanObject `json:"compute_resources"`
+}
+
+// Implement the Marshaler interface
+func (fo ForemanLocation) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/location.go#MarshalJSON")
+
+	// NOTE(ALL): omit the "title" property from the JSON marshal since it is
+	//   a computed value
+	foMap := map[string]interface{}{}
+
+	foMap["name"] = fo.Name
+	foMap["description"] = fo.Description
+	foMap["parent_id"] = intIdToJSONString(fo.ParentId)
+
+	// Only send the assignments which are managed.  An empty array removes
+	// all assignments of that type - Foreman interprets the arrays as a
+	// REPLACE operation.
+	idArrays := map[string][]int{
+		"organization_ids":     fo.OrganizationIds,
+		"domain_ids":           fo.DomainIds,
+		"subnet_ids":           fo.SubnetIds,
+		"smart_proxy_ids":      fo.SmartProxyIds,
+		"compute_resource_ids": fo.ComputeResourceIds,
+	}
+	for key, ids := range idArrays {
+		if ids != nil {
+			foMap[key] = ids
+		}
+	}
+
+	log.Debugf("foMap: [%v]", foMap)
+
+	return json.Marshal(foMap)
+}
+
+// Implement the Unmarshaler interface
+func (fo *ForemanLocation) UnmarshalJSON(b []byte) error {
+	var jsonDecErr error
+
+	// Unmarshal the common Foreman object properties
+	var obj ForemanObject
+	jsonDecErr = json.Unmarshal(b, &obj)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fo.ForemanObject = obj
+
+	// Unmarshal to temporary JSON struct to get the properties with
+	// differently named keys
+	var foJSON foremanLocationJSON
+	jsonDecErr = json.Unmarshal(b, &foJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fo.OrganizationIds = foremanObjectArrayToIdIntArray(foJSON.Organizations)
+	fo.DomainIds = foremanObjectArrayToIdIntArray(foJSON.Domains)
+	fo.SubnetIds = foremanObjectArrayToIdIntArray(foJSON.Subnets)
+	fo.SmartProxyIds = foremanObjectArrayToIdIntArray(foJSON.SmartProxies)
+	fo.ComputeResourceIds = foremanObjectArrayToIdIntArray(foJSON.ComputeResources)
+
+	// Unmarshal into mapstructure and set the rest of the struct properties
+	var foMap map[string]interface{}
+	jsonDecErr = json.Unmarshal(b, &foMap)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	var ok bool
+	if fo.Title, ok = foMap["title"].(string); !ok {
+		fo.Title = ""
+	}
+	if fo.Description, ok = foMap["description"].(string); !ok {
+		fo.Description = ""
+	}
+	fo.ParentId = unmarshalInteger(foMap["parent_id"])
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateLocation creates a new ForemanLocation with the attributes of
+// the supplied ForemanLocation reference and returns the created
+// ForemanLocation reference.  The returned reference will have its ID and
+// other API default values set by this function.
+func (c *Client) CreateLocation(ctx context.Context, l *ForemanLocation) (*ForemanLocation, error) {
+	log.Tracef("foreman/api/location.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", LocationEndpointPrefix)
+
+	// NOTE(ALL): locations are not scoped by the provider's taxonomy
+	lJSONBytes, jsonEncErr := c.WrapJSON("location", l)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("locationJSONBytes: [%s]", lJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(lJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdLocation ForemanLocation
+	sendErr := c.SendAndParse(req, &createdLocation)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdLocation: [%+v]", createdLocation)
+
+	return &createdLocation, nil
+}
+
+// ReadLocation reads the attributes of a ForemanLocation identified by
+// the supplied ID and returns a ForemanLocation reference.
+func (c *Client) ReadLocation(ctx context.Context, id int) (*ForemanLocation, error) {
+	log.Tracef("foreman/api/location.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", LocationEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readLocation ForemanLocation
+	sendErr := c.SendAndParse(req, &readLocation)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readLocation: [%+v]", readLocation)
+
+	return &readLocation, nil
+}
+
+// UpdateLocation updates a ForemanLocation's attributes.  The
+// location with the ID of the supplied ForemanLocation will be
+// updated. A new ForemanLocation reference is returned with the attributes
+// from the result of the update operation.
+func (c *Client) UpdateLocation(ctx context.Context, l *ForemanLocation) (*ForemanLocation, error) {
+	log.Tracef("foreman/api/location.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", LocationEndpointPrefix, l.Id)
+
+	lJSONBytes, jsonEncErr := c.WrapJSON("location", l)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("locationJSONBytes: [%s]", lJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(lJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedLocation ForemanLocation
+	sendErr := c.SendAndParse(req, &updatedLocation)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedLocation: [%+v]", updatedLocation)
+
+	return &updatedLocation, nil
+}
+
+// DeleteLocation deletes the ForemanLocation identified by the supplied ID
+func (c *Client) DeleteLocation(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/location.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", LocationEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryLocation queries for a ForemanLocation based on the attributes
+// of the supplied ForemanLocation reference and returns a QueryResponse
+// struct containing query/response metadata and the matching locations.
+//
+// Nested locations may share their name - if the title is set, the location
+// is searched by its title instead.
+func (c *Client) QueryLocation(ctx context.Context, l *ForemanLocation) (QueryResponse, error) {
+	log.Tracef("foreman/api/location.go#Search")
+
+	search := SearchEquals("name", l.Name)
+	if l.Title != "" {
+		search = SearchEquals("title", l.Title)
+	}
+
+	reqEndpoint := fmt.Sprintf("/%s", LocationEndpointPrefix)
+	return searchQueryResponse[ForemanLocation](ctx, c, reqEndpoint, SearchQuery{
+		Search: search,
+	})
+}
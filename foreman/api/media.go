@@ -22,6 +22,8 @@ const (
 type ForemanMedia struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Locations and organizations the object is assigned to
+	ForemanTaxonomies
 
 	// The path to the medium, can be a URL or a valid NFS server (exclusive
 	// of the architecture).  For example:
@@ -62,6 +64,12 @@ func (fm *ForemanMedia) UnmarshalJSON(b []byte) error {
 	}
 	fm.ForemanObject = fo
 
+	// Unmarshal the assigned locations and organizations
+	fm.ForemanTaxonomies, jsonDecErr = unmarshalForemanTaxonomies(b)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+
 	// Unmarshal to temporary JSON struct to get the properties with
 	// differently named keys
 	var fmJSON foremanMediaJSON
@@ -222,52 +230,8 @@ func (c *Client) DeleteMedia(ctx context.Context, id int) error {
 func (c *Client) QueryMedia(ctx context.Context, m *ForemanMedia) (QueryResponse, error) {
 	log.Tracef("foreman/api/media.go#Search")
 
-	queryResponse := QueryResponse{}
-
 	reqEndpoint := fmt.Sprintf("/%s", MediaEndpointPrefix)
-	req, reqErr := c.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		reqEndpoint,
-		nil,
-	)
-	if reqErr != nil {
-		return queryResponse, reqErr
-	}
-
-	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
-	name := `"` + m.Name + `"`
-	reqQuery.Set("search", "name="+name)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanMedia for
-	// the results
-	results := []ForemanMedia{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanMedia to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return searchQueryResponse[ForemanMedia](ctx, c, reqEndpoint, SearchQuery{
+		Search: SearchEquals("name", m.Name),
+	})
 }
@@ -0,0 +1,307 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	OrganizationEndpointPrefix = "organizations"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanOrganization API model represents an organization.  Together
+// with locations, organizations are the taxonomies used to scope the other
+// objects of Foreman (hosts, subnets, domains, ...).
+type ForemanOrganization struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// Name of the organization including the names of its parents, ie:
+	// "Parent Org/Child Org".  This is a computed value.
+	Title string `json:"title"`
+	// Unique label of the organization.  Only available if Katello is
+	// installed - it can not be changed after the organization was created.
+	Label string `json:"label"`
+	// Description of the organization
+	Description string `json:"description"`
+	// ID of the parent organization
+	ParentId int `json:"parent_id"`
+
+	// IDs of the objects assigned to this organization
+	LocationIds        []int `json:"location_ids"`
+	DomainIds          []int `json:"domain_ids"`
+	SubnetIds          []int `json:"subnet_ids"`
+	SmartProxyIds      []int `json:"smart_proxy_ids"`
+	ComputeResourceIds []int `json:"compute_resource_ids"`
+	MediumIds          []int `json:"medium_ids"`
+	HostgroupIds       []int `json:"hostgroup_ids"`
+	UserIds            []int `json:"user_ids"`
+}
+
+// ForemanOrganization struct used for JSON decode.  Foreman API returns the
+// assigned objects as lists of ForemanObjects.  We are only interested in
+// their IDs.
+type foremanOrganizationJSON struct {
+	Locations        []ForemanObject `json:"locations"`
+	Domains          []ForemanObject `json:"domains"`
+	Subnets          []ForemanObject `json:"subnets"`
+	SmartProxies     []ForemanObject `json:"smart_proxies"`
+	ComputeResources []ForemanObject `json:"compute_resources"`
+	Media            []ForemanObject `json:"media"`
+	Hostgroups       []ForemanObject `json:"hostgroups"`
+	Users            []ForemanObject `json:"users"`
+}
+
+// Implement the Marshaler interface
+func (fo ForemanOrganization) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/organization.go#MarshalJSON")
+
+	// NOTE(ALL): omit the "title" property from the JSON marshal since it is
+	//   a computed value.  The label is only sent if set, since Foreman
+	//   without Katello does not know the attribute.
+	foMap := map[string]interface{}{}
+
+	foMap["name"] = fo.Name
+	foMap["description"] = fo.Description
+	foMap["parent_id"] = intIdToJSONString(fo.ParentId)
+	if fo.Label != "" {
+		foMap["label"] = fo.Label
+	}
+
+	// Only send the assignments which are managed.  An empty array removes
+	// all assignments of that type - Foreman interprets the arrays as a
+	// REPLACE operation.
+	idArrays := map[string][]int{
+		"location_ids":         fo.LocationIds,
+		"domain_ids":           fo.DomainIds,
+		"subnet_ids":           fo.SubnetIds,
+		"smart_proxy_ids":      fo.SmartProxyIds,
+		"compute_resource_ids": fo.ComputeResourceIds,
+		"medium_ids":           fo.MediumIds,
+		"hostgroup_ids":        fo.HostgroupIds,
+		"user_ids":             fo.UserIds,
+	}
+	for key, ids := range idArrays {
+		if ids != nil {
+			foMap[key] = ids
+		}
+	}
+
+	log.Debugf("foMap: [%v]", foMap)
+
+	return json.Marshal(foMap)
+}
+
+// Implement the Unmarshaler interface
+func (fo *ForemanOrganization) UnmarshalJSON(b []byte) error {
+	var jsonDecErr error
+
+	// Unmarshal the common Foreman object properties
+	var obj ForemanObject
+	jsonDecErr = json.Unmarshal(b, &obj)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fo.ForemanObject = obj
+
+	// Unmarshal to temporary JSON struct to get the properties with
+	// differently named keys
+	var foJSON foremanOrganizationJSON
+	jsonDecErr = json.Unmarshal(b, &foJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fo.LocationIds = foremanObjectArrayToIdIntArray(foJSON.Locations)
+	fo.DomainIds = foremanObjectArrayToIdIntArray(foJSON.Domains)
+	fo.SubnetIds = foremanObjectArrayToIdIntArray(foJSON.Subnets)
+	fo.SmartProxyIds = foremanObjectArrayToIdIntArray(foJSON.SmartProxies)
+	fo.ComputeResourceIds = foremanObjectArrayToIdIntArray(foJSON.ComputeResources)
+	fo.MediumIds = foremanObjectArrayToIdIntArray(foJSON.Media)
+	fo.HostgroupIds = foremanObjectArrayToIdIntArray(foJSON.Hostgroups)
+	fo.UserIds = foremanObjectArrayToIdIntArray(foJSON.Users)
+
+	// Unmarshal into mapstructure and set the rest of the struct properties
+	var foMap map[string]interface{}
+	jsonDecErr = json.Unmarshal(b, &foMap)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	var ok bool
+	if fo.Title, ok = foMap["title"].(string); !ok {
+		fo.Title = ""
+	}
+	if fo.Label, ok = foMap["label"].(string); !ok {
+		fo.Label = ""
+	}
+	if fo.Description, ok = foMap["description"].(string); !ok {
+		fo.Description = ""
+	}
+	fo.ParentId = unmarshalInteger(foMap["parent_id"])
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateOrganization creates a new ForemanOrganization with the attributes of
+// the supplied ForemanOrganization reference and returns the created
+// ForemanOrganization reference.  The returned reference will have its ID and
+// other API default values set by this function.
+func (c *Client) CreateOrganization(ctx context.Context, o *ForemanOrganization) (*ForemanOrganization, error) {
+	log.Tracef("foreman/api/organization.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", OrganizationEndpointPrefix)
+
+	// NOTE(ALL): organizations are not scoped by the provider's taxonomy
+	oJSONBytes, jsonEncErr := c.WrapJSON("organization", o)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("organizationJSONBytes: [%s]", oJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(oJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdOrganization ForemanOrganization
+	sendErr := c.SendAndParse(req, &createdOrganization)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdOrganization: [%+v]", createdOrganization)
+
+	return &createdOrganization, nil
+}
+
+// ReadOrganization reads the attributes of a ForemanOrganization identified by
+// the supplied ID and returns a ForemanOrganization reference.
+func (c *Client) ReadOrganization(ctx context.Context, id int) (*ForemanOrganization, error) {
+	log.Tracef("foreman/api/organization.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", OrganizationEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readOrganization ForemanOrganization
+	sendErr := c.SendAndParse(req, &readOrganization)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readOrganization: [%+v]", readOrganization)
+
+	return &readOrganization, nil
+}
+
+// UpdateOrganization updates a ForemanOrganization's attributes.  The
+// organization with the ID of the supplied ForemanOrganization will be
+// updated. A new ForemanOrganization reference is returned with the attributes
+// from the result of the update operation.
+func (c *Client) UpdateOrganization(ctx context.Context, o *ForemanOrganization) (*ForemanOrganization, error) {
+	log.Tracef("foreman/api/organization.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", OrganizationEndpointPrefix, o.Id)
+
+	oJSONBytes, jsonEncErr := c.WrapJSON("organization", o)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("organizationJSONBytes: [%s]", oJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(oJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedOrganization ForemanOrganization
+	sendErr := c.SendAndParse(req, &updatedOrganization)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedOrganization: [%+v]", updatedOrganization)
+
+	return &updatedOrganization, nil
+}
+
+// DeleteOrganization deletes the ForemanOrganization identified by the
+// supplied ID.
+//
+// With Katello installed, the organization is destroyed by an asynchronous
+// task ("Actions::Katello::Organization::Destroy") and the API answers with
+// 202 Accepted.  SendAndParse waits for the task to finish, so the function
+// only returns once the organization is gone or the task failed.
+func (c *Client) DeleteOrganization(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/organization.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", OrganizationEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryOrganization queries for a ForemanOrganization based on the attributes
+// of the supplied ForemanOrganization reference and returns a QueryResponse
+// struct containing query/response metadata and the matching organizations.
+//
+// Nested organizations may share their name - if the title is set, the
+// organization is searched by its title instead.
+func (c *Client) QueryOrganization(ctx context.Context, o *ForemanOrganization) (QueryResponse, error) {
+	log.Tracef("foreman/api/organization.go#Search")
+
+	search := SearchEquals("name", o.Name)
+	if o.Title != "" {
+		search = SearchEquals("title", o.Title)
+	}
+
+	reqEndpoint := fmt.Sprintf("/%s", OrganizationEndpointPrefix)
+	return searchQueryResponse[ForemanOrganization](ctx, c, reqEndpoint, SearchQuery{
+		Search: search,
+	})
+}
@@ -61,21 +61,8 @@ func (fp *ForemanParameter) UnmarshalJSON(b []byte) error {
 	}
 	fp.ForemanObject = fo
 
-	var fpMap map[string]interface{}
-	jsonDecErr = json.Unmarshal(b, &fpMap)
-	if jsonDecErr != nil {
-		return jsonDecErr
-	}
-
-	var ok bool
-	if fp.Parameter.Name, ok = fpMap["name"].(string); !ok {
-		fp.Parameter.Name = ""
-	}
-	if fp.Parameter.Value, ok = fpMap["value"].(string); !ok {
-		fp.Parameter.Value = ""
-	}
-
-	return nil
+	// The name, typed value and type of the parameter
+	return json.Unmarshal(b, &fp.Parameter)
 }
 
 // -----------------------------------------------------------------------------
@@ -221,52 +208,8 @@ func (c *Client) DeleteParameter(ctx context.Context, d *ForemanParameter, id in
 func (c *Client) QueryParameter(ctx context.Context, d *ForemanParameter) (QueryResponse, error) {
 	log.Tracef("foreman/api/parameter.go#Search")
 
-	queryResponse := QueryResponse{}
-
 	reqEndpoint := fmt.Sprintf("/%s", ParameterEndpointPrefix)
-	req, reqErr := c.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		reqEndpoint,
-		nil,
-	)
-	if reqErr != nil {
-		return queryResponse, reqErr
-	}
-
-	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
-	name := `"` + d.Name + `"`
-	reqQuery.Set("search", "name="+name)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanParameter for
-	// the results
-	results := []ForemanParameter{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanParameter to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return searchQueryResponse[ForemanParameter](ctx, c, reqEndpoint, SearchQuery{
+		Search: SearchEquals("name", d.Name),
+	})
 }
@@ -24,6 +24,8 @@ const (
 type ForemanPartitionTable struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Locations and organizations the object is assigned to
+	ForemanTaxonomies
 
 	// The script that defines the partition table layout
 	Layout string `json:"layout"`
@@ -70,6 +72,12 @@ func (ft *ForemanPartitionTable) UnmarshalJSON(b []byte) error {
 	}
 	ft.ForemanObject = fo
 
+	// Unmarshal the assigned locations and organizations
+	ft.ForemanTaxonomies, jsonDecErr = unmarshalForemanTaxonomies(b)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+
 	// Unmarshal to temporary JSON struct to get the properties with differently
 	// named keys
 	var ftJSON foremanPartitionTableJSON
@@ -245,52 +253,8 @@ func (c *Client) DeletePartitionTable(ctx context.Context, id int) error {
 func (c *Client) QueryPartitionTable(ctx context.Context, t *ForemanPartitionTable) (QueryResponse, error) {
 	log.Tracef("foreman/api/partitiontable.go#Search")
 
-	queryResponse := QueryResponse{}
-
 	reqEndpoint := fmt.Sprintf("/%s", PartitionTableEndpointPrefix)
-	req, reqErr := c.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		reqEndpoint,
-		nil,
-	)
-	if reqErr != nil {
-		return queryResponse, reqErr
-	}
-
-	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
-	name := `"` + t.Name + `"`
-	reqQuery.Set("search", "name="+name)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanPartitionTable for
-	// the results
-	results := []ForemanPartitionTable{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanPartitionTable to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return searchQueryResponse[ForemanPartitionTable](ctx, c, reqEndpoint, SearchQuery{
+		Search: SearchEquals("name", t.Name),
+	})
 }
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	PermissionEndpointPrefix = "permissions"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanPermission API model represents a permission, ie: "view_hosts".
+// Permissions are granted through the filters of a role.
+type ForemanPermission struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// Type of the resources the permission applies to, ie: "Host".  Empty
+	// for permissions which do not apply to a resource.
+	ResourceType string `json:"resource_type"`
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryPermission queries for a ForemanPermission based on the attributes of
+// the supplied ForemanPermission reference and returns a QueryResponse struct
+// containing query/response metadata and the matching permissions.
+func (c *Client) QueryPermission(ctx context.Context, p *ForemanPermission) (QueryResponse, error) {
+	log.Tracef("foreman/api/permission.go#Search")
+
+	reqEndpoint := fmt.Sprintf("/%s", PermissionEndpointPrefix)
+	return searchQueryResponse[ForemanPermission](ctx, c, reqEndpoint, SearchQuery{
+		Search: SearchEquals("name", p.Name),
+	})
+}
+
+// SearchPermissionsByName returns the ForemanPermissions matching any of the
+// supplied names.  Names without a matching permission are not part of the
+// result.
+func (c *Client) SearchPermissionsByName(ctx context.Context, names []string) ([]ForemanPermission, error) {
+	log.Tracef("foreman/api/permission.go#SearchByName")
+
+	if len(names) == 0 {
+		return []ForemanPermission{}, nil
+	}
+
+	conditions := make([]string, len(names))
+	for idx, name := range names {
+		conditions[idx] = SearchEquals("name", name)
+	}
+
+	reqEndpoint := fmt.Sprintf("/%s", PermissionEndpointPrefix)
+	return Search[ForemanPermission](ctx, c, reqEndpoint, SearchQuery{
+		Search: strings.Join(conditions, " or "),
+	})
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	PersonalAccessTokenEndpointPrefix = "users/%d/personal_access_tokens"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanPersonalAccessToken API model represents a personal access token
+// of a user.  Tokens authenticate API requests in place of the user's
+// password.
+type ForemanPersonalAccessToken struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// ID of the user the token belongs to
+	UserId int `json:"user_id"`
+	// Expiry of the token.  Empty for tokens which never expire.
+	ExpiresAt string `json:"expires_at,omitempty"`
+	// Value of the token.  Only returned when the token is created.
+	TokenValue string `json:"token_value,omitempty"`
+	// Timestamp of the last request authenticated with the token
+	LastUsedAt string `json:"last_used_at,omitempty"`
+	// Whether the token has been revoked
+	Revoked bool `json:"revoked"`
+	// Whether the token can be used, ie: it is neither revoked nor expired
+	Active bool `json:"active"`
+}
+
+// Implement the Marshaler interface
+func (ft ForemanPersonalAccessToken) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/personal_access_token.go#MarshalJSON")
+
+	ftMap := map[string]interface{}{}
+
+	ftMap["name"] = ft.Name
+	if ft.ExpiresAt != "" {
+		ftMap["expires_at"] = ft.ExpiresAt
+	}
+
+	return json.Marshal(ftMap)
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreatePersonalAccessToken creates a new ForemanPersonalAccessToken for the
+// user with the ID of the supplied ForemanPersonalAccessToken and returns the
+// created ForemanPersonalAccessToken reference, including the value of the
+// token.
+func (c *Client) CreatePersonalAccessToken(ctx context.Context, t *ForemanPersonalAccessToken) (*ForemanPersonalAccessToken, error) {
+	log.Tracef("foreman/api/personal_access_token.go#Create")
+
+	reqEndpoint := "/" + fmt.Sprintf(PersonalAccessTokenEndpointPrefix, t.UserId)
+
+	tJSONBytes, jsonEncErr := c.WrapJSON("personal_access_token", t)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("personalAccessTokenJSONBytes: [%s]", tJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(tJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdToken ForemanPersonalAccessToken
+	sendErr := c.SendAndParse(req, &createdToken)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	// NOTE(ALL): the token value is not logged
+	log.Debugf("createdToken: [%d]", createdToken.Id)
+
+	return &createdToken, nil
+}
+
+// ReadPersonalAccessToken reads the attributes of the
+// ForemanPersonalAccessToken of the user identified by the supplied IDs and
+// returns a ForemanPersonalAccessToken reference.
+func (c *Client) ReadPersonalAccessToken(ctx context.Context, userId int, id int) (*ForemanPersonalAccessToken, error) {
+	log.Tracef("foreman/api/personal_access_token.go#Read")
+
+	reqEndpoint := "/" + fmt.Sprintf(PersonalAccessTokenEndpointPrefix+"/%d", userId, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readToken ForemanPersonalAccessToken
+	sendErr := c.SendAndParse(req, &readToken)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readToken: [%+v]", readToken)
+
+	return &readToken, nil
+}
+
+// RevokePersonalAccessToken revokes the ForemanPersonalAccessToken of the
+// user identified by the supplied IDs.  Foreman keeps revoked tokens.
+func (c *Client) RevokePersonalAccessToken(ctx context.Context, userId int, id int) error {
+	log.Tracef("foreman/api/personal_access_token.go#Revoke")
+
+	reqEndpoint := "/" + fmt.Sprintf(PersonalAccessTokenEndpointPrefix+"/%d", userId, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
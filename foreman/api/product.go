@@ -3,9 +3,9 @@ package api
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 
 	"github.com/HanseMerkur/terraform-provider-utils/log"
@@ -175,53 +175,11 @@ func (c *Client) DeleteKatelloProduct(ctx context.Context, id int) error {
 func (c *Client) QueryKatelloProduct(ctx context.Context, p *ForemanKatelloProduct) (QueryResponse, error) {
 	log.Tracef("foreman/api/product.go#Search")
 
-	queryResponse := QueryResponse{}
-
-	req, reqErr := c.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		KatelloProductEndpointPrefix,
-		nil,
-	)
-	if reqErr != nil {
-		return queryResponse, reqErr
-	}
-
-	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
-	name := `"` + p.Name + `"`
-	reqQuery.Set("search", "name="+name)
-
-	// organization_id is a required parameter
-	orgId := strconv.Itoa(c.clientConfig.OrganizationID)
-	reqQuery.Set("organization_id", orgId)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanKatelloProduct for
-	// the results
-	results := []ForemanKatelloProduct{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanKatelloProduct to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return searchQueryResponse[ForemanKatelloProduct](ctx, c, KatelloProductEndpointPrefix, SearchQuery{
+		Search: SearchEquals("name", p.Name),
+		// organization_id is a required parameter
+		Params: url.Values{
+			"organization_id": []string{strconv.Itoa(c.clientConfig.OrganizationID)},
+		},
+	})
 }
@@ -24,6 +24,8 @@ const (
 type ForemanProvisioningTemplate struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Locations and organizations the object is assigned to
+	ForemanTaxonomies
 
 	// The markup and code of the provisioning template
 	Template string
@@ -142,6 +144,12 @@ func (ft *ForemanProvisioningTemplate) UnmarshalJSON(b []byte) error {
 	}
 	ft.ForemanObject = fo
 
+	// Unmarshal the assigned locations and organizations
+	ft.ForemanTaxonomies, jsonDecErr = unmarshalForemanTaxonomies(b)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+
 	// Unmarshal to temporary JSON struct to get the properties with differently
 	// named keys
 	var ftJSON foremanProvisioningTemplateJSON
@@ -324,52 +332,8 @@ func (c *Client) DeleteProvisioningTemplate(ctx context.Context, id int) error {
 func (c *Client) QueryProvisioningTemplate(ctx context.Context, t *ForemanProvisioningTemplate) (QueryResponse, error) {
 	log.Tracef("foreman/api/provisioningtemplate.go#Query")
 
-	queryResponse := QueryResponse{}
-
 	reqEndpoint := fmt.Sprintf("/%s", ProvisioningTemplateEndpointPrefix)
-	req, reqErr := c.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		reqEndpoint,
-		nil,
-	)
-	if reqErr != nil {
-		return queryResponse, reqErr
-	}
-
-	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
-	name := "\"" + t.Name + "\""
-	reqQuery.Set("search", "name="+name)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanProvisioningTemplate for
-	// the results
-	results := []ForemanProvisioningTemplate{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanProvisioningTemplate to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return searchQueryResponse[ForemanProvisioningTemplate](ctx, c, reqEndpoint, SearchQuery{
+		Search: SearchEquals("name", t.Name),
+	})
 }
@@ -86,7 +86,22 @@ func (c *Client) QueryPuppetClass(ctx context.Context, t *ForemanPuppetClass) (Q
 	reqQuery.Set("search", "name="+name)
 
 	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	queryResponse.Results = map[string]interface{}{}
+	sendErr := c.sendAndParseQueryPages(req, &queryResponse.QueryResponse, func(results json.RawMessage) (int, error) {
+		// Each page is a map of module name to classes - merge the classes of
+		// modules spread across pages
+		var pageResults map[string][]interface{}
+		if err := json.Unmarshal(results, &pageResults); err != nil {
+			return 0, err
+		}
+		count := 0
+		for module, classes := range pageResults {
+			merged, _ := queryResponse.Results[module].([]interface{})
+			queryResponse.Results[module] = append(merged, classes...)
+			count += len(classes)
+		}
+		return count, nil
+	})
 	if sendErr != nil {
 		return QueryResponse{}, sendErr
 	}
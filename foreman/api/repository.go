@@ -259,49 +259,7 @@ func (c *Client) DeleteKatelloRepository(ctx context.Context, id int) error {
 func (c *Client) QueryKatelloRepository(ctx context.Context, p *ForemanKatelloRepository) (QueryResponse, error) {
 	log.Tracef("foreman/api/repository.go#Search")
 
-	queryResponse := QueryResponse{}
-
-	req, reqErr := c.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		KatelloRepositoryEndpointPrefix,
-		nil,
-	)
-	if reqErr != nil {
-		return queryResponse, reqErr
-	}
-
-	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
-	name := `"` + p.Name + `"`
-	reqQuery.Set("search", "name="+name)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanKatelloRepository for
-	// the results
-	results := []ForemanKatelloRepository{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanKatelloRepository to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return searchQueryResponse[ForemanKatelloRepository](ctx, c, KatelloRepositoryEndpointPrefix, SearchQuery{
+		Search: SearchEquals("name", p.Name),
+	})
 }
@@ -0,0 +1,165 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	// Delay before the first retry of a failed request, if not configured
+	// otherwise
+	DefaultRetryWaitMin = time.Second
+	// Upper bound of the exponential backoff between two attempts of a
+	// request, if not configured otherwise
+	DefaultRetryWaitMax = 30 * time.Second
+)
+
+// ----------------------------------------------------------------------------
+// Retry Policy
+// ----------------------------------------------------------------------------
+
+// shouldRetry reports whether a request which was answered with the supplied
+// status code (or failed with the supplied error) is worth sending again.
+//
+// Safe requests (GET, HEAD and OPTIONS) are retried when the server could not
+// be reached, when it asks the client to slow down (429 Too Many Requests) or
+// when it reports a temporary failure (5xx except 501 Not Implemented).
+//
+// Any other request may already have been processed by Foreman when the
+// connection broke or a gateway answered with 502 or 504 - sending it again
+// could create an object twice.  Those requests are only retried if they
+// provably never reached the server: when the connection could not be
+// established, or when the server answered with 429 Too Many Requests or
+// 503 Service Unavailable.
+func shouldRetry(ctx context.Context, method string, statusCode int, err error) bool {
+	// Do not retry if the request was cancelled or its deadline exceeded
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return isSafeMethod(method) || isDialError(err)
+	}
+	if statusCode == http.StatusTooManyRequests ||
+		statusCode == http.StatusServiceUnavailable {
+		return true
+	}
+	return isSafeMethod(method) &&
+		statusCode >= 500 &&
+		statusCode != http.StatusNotImplemented
+}
+
+// isSafeMethod reports whether the HTTP method does not change any state on
+// the server and can therefore be sent any number of times.
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// isDialError reports whether the error occurred while connecting to the
+// server, i.e. before any part of the request was sent.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// retryWait returns how long to wait before the next attempt of a request.
+// The wait doubles with every attempt, starting at ClientConfig.RetryWaitMin,
+// and is capped at ClientConfig.RetryWaitMax.  A random jitter of up to half
+// the wait is applied so that concurrent resources do not retry in lockstep.
+//
+// If the server sent a Retry-After header (either in seconds or as an HTTP
+// date), its value is used instead - still capped at RetryWaitMax.
+func (client *Client) retryWait(attempt int, header http.Header) time.Duration {
+	waitMin := client.clientConfig.RetryWaitMin
+	if waitMin <= 0 {
+		waitMin = DefaultRetryWaitMin
+	}
+	waitMax := client.clientConfig.RetryWaitMax
+	if waitMax <= 0 {
+		waitMax = DefaultRetryWaitMax
+	}
+	if waitMax < waitMin {
+		waitMax = waitMin
+	}
+
+	if retryAfter, ok := parseRetryAfter(header); ok {
+		if retryAfter > waitMax {
+			return waitMax
+		}
+		return retryAfter
+	}
+
+	wait := waitMin
+	for i := 0; i < attempt && wait < waitMax; i++ {
+		wait *= 2
+	}
+	if wait > waitMax {
+		wait = waitMax
+	}
+
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// parseRetryAfter reads the Retry-After header of a response.  The header
+// either holds the number of seconds to wait or an HTTP date.
+func parseRetryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// rewindBody replaces the already consumed body of the request with a fresh
+// copy, so that the request can be sent again.  Returns false if the body
+// cannot be replayed.
+func rewindBody(req *http.Request) bool {
+	if req.Body == nil || req.Body == http.NoBody {
+		return true
+	}
+	if req.GetBody == nil {
+		return false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		log.Errorf("Failed to rewind the request body: [%s]", err)
+		return false
+	}
+	req.Body = body
+	return true
+}
+
+// sleepContext waits for the supplied duration or until the context is done,
+// whichever happens first.
+func sleepContext(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,271 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// ----------------------------------------------------------------------------
+// Test Helper Functions
+// ----------------------------------------------------------------------------
+
+// Creates a client retrying requests with short waits and a mock endpoint
+// answering every request with the next status code from the supplied list.
+// The body of every request received is recorded.
+func newRetryAPIAndClient(t *testing.T, retryMax int, statusCodes []int, header http.Header) (*Client, func(), *[]string) {
+	conf := ClientConfig{
+		RetryMax:     retryMax,
+		RetryWaitMin: time.Millisecond,
+		RetryWaitMax: 2 * time.Millisecond,
+	}
+	mux, server, client := NewForemanAPIAndClient(ClientCredentials{}, conf)
+
+	bodies := []string{}
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo", func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		statusCode := statusCodes[len(statusCodes)-1]
+		if len(bodies) < len(statusCodes) {
+			statusCode = statusCodes[len(bodies)]
+		}
+		bodies = append(bodies, string(body))
+
+		for key, values := range header {
+			w.Header()[key] = values
+		}
+		w.WriteHeader(statusCode)
+	})
+
+	return client, server.Close, &bodies
+}
+
+// ----------------------------------------------------------------------------
+// Client.Send
+// ----------------------------------------------------------------------------
+
+// Ensure temporary failures are retried and the request body is replayed on
+// every attempt
+func TestSend_RetryReplaysBody(t *testing.T) {
+	statusCodes := []int{
+		http.StatusServiceUnavailable,
+		http.StatusTooManyRequests,
+		http.StatusOK,
+	}
+	client, closeServer, bodies := newRetryAPIAndClient(t, 3, statusCodes, nil)
+	defer closeServer()
+
+	reqBody := `{"host":{"name":"foo"}}`
+	req, _ := client.NewRequestWithContext(
+		context.TODO(),
+		http.MethodPost,
+		"/foo",
+		bytes.NewBufferString(reqBody),
+	)
+
+	statusCode, _, sendErr := client.Send(req)
+	if sendErr != nil || statusCode != http.StatusOK {
+		t.Fatalf(
+			"Client.Send() did not retry until the request succeeded. "+
+				"Got status code [%d] and error [%v].",
+			statusCode,
+			sendErr,
+		)
+	}
+	if len(*bodies) != len(statusCodes) {
+		t.Fatalf(
+			"Client.Send() did not send the expected number of attempts. "+
+				"Expected [%d], got [%d].",
+			len(statusCodes),
+			len(*bodies),
+		)
+	}
+	for idx, body := range *bodies {
+		if body != reqBody {
+			t.Fatalf(
+				"Client.Send() did not replay the request body on attempt [%d]. "+
+					"Expected [%s], got [%s].",
+				idx,
+				reqBody,
+				body,
+			)
+		}
+	}
+}
+
+// Ensure requests are sent at most RetryMax+1 times and the last response is
+// returned
+func TestSend_RetryMaxExhausted(t *testing.T) {
+	header := http.Header{"Retry-After": []string{"0"}}
+	client, closeServer, bodies := newRetryAPIAndClient(t, 2, []int{http.StatusTooManyRequests}, header)
+	defer closeServer()
+
+	req, _ := client.NewRequestWithContext(context.TODO(), http.MethodGet, "/foo", nil)
+
+	statusCode, _, _ := client.Send(req)
+	if statusCode != http.StatusTooManyRequests || len(*bodies) != 3 {
+		t.Fatalf(
+			"Client.Send() did not give up after RetryMax retries. "+
+				"Expected [3] attempts with status code [%d], got [%d] with [%d].",
+			http.StatusTooManyRequests,
+			len(*bodies),
+			statusCode,
+		)
+	}
+}
+
+// Ensure client errors and non-temporary server errors are not retried
+func TestSend_NoRetry(t *testing.T) {
+	statusCodes := []int{
+		http.StatusNotFound,
+		http.StatusUnprocessableEntity,
+		http.StatusNotImplemented,
+	}
+	for _, code := range statusCodes {
+		client, closeServer, bodies := newRetryAPIAndClient(t, 3, []int{code}, nil)
+
+		req, _ := client.NewRequestWithContext(context.TODO(), http.MethodGet, "/foo", nil)
+		client.Send(req)
+		closeServer()
+
+		if len(*bodies) != 1 {
+			t.Fatalf(
+				"Client.Send() retried a request answered with status code [%d]. "+
+					"Expected [1] attempt, got [%d].",
+				code,
+				len(*bodies),
+			)
+		}
+	}
+}
+
+// Ensure requests which may already have been processed by the server are
+// not sent again - e.g. a POST answered by a gateway with 502 or 504
+func TestSend_NoRetryNonIdempotent(t *testing.T) {
+	statusCodes := []int{
+		http.StatusBadGateway,
+		http.StatusGatewayTimeout,
+		http.StatusInternalServerError,
+	}
+	for _, code := range statusCodes {
+		client, closeServer, bodies := newRetryAPIAndClient(t, 3, []int{code}, nil)
+
+		req, _ := client.NewRequestWithContext(
+			context.TODO(),
+			http.MethodPost,
+			"/foo",
+			bytes.NewBufferString(`{"host":{"name":"foo"}}`),
+		)
+		client.Send(req)
+		closeServer()
+
+		if len(*bodies) != 1 {
+			t.Fatalf(
+				"Client.Send() retried a POST request answered with status code [%d]. "+
+					"Expected [1] attempt, got [%d].",
+				code,
+				len(*bodies),
+			)
+		}
+	}
+}
+
+// Ensure a POST request is sent again if the connection to the server could
+// not be established, while a GET request is retried after any network error
+func TestShouldRetry_NetworkError(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	readErr := &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+
+	if !shouldRetry(context.TODO(), http.MethodPost, -1, dialErr) {
+		t.Fatalf("shouldRetry() did not retry a POST request which failed to connect")
+	}
+	if shouldRetry(context.TODO(), http.MethodPost, -1, readErr) {
+		t.Fatalf("shouldRetry() retried a POST request whose connection was reset")
+	}
+	if !shouldRetry(context.TODO(), http.MethodGet, -1, readErr) {
+		t.Fatalf("shouldRetry() did not retry a GET request whose connection was reset")
+	}
+}
+
+// Ensure retries are disabled with the zero value of the client configuration
+func TestSend_RetryDisabled(t *testing.T) {
+	client, closeServer, bodies := newRetryAPIAndClient(t, 0, []int{http.StatusServiceUnavailable}, nil)
+	defer closeServer()
+
+	req, _ := client.NewRequestWithContext(context.TODO(), http.MethodGet, "/foo", nil)
+	client.Send(req)
+
+	if len(*bodies) != 1 {
+		t.Fatalf(
+			"Client.Send() retried a request with retries disabled. "+
+				"Expected [1] attempt, got [%d].",
+			len(*bodies),
+		)
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Client.retryWait
+// ----------------------------------------------------------------------------
+
+// Ensure the wait grows exponentially, stays within its bounds and honors the
+// Retry-After header
+func TestRetryWait(t *testing.T) {
+	client := NewClient(Server{}, ClientCredentials{}, ClientConfig{
+		RetryWaitMin: 2 * time.Second,
+		RetryWaitMax: 10 * time.Second,
+	})
+
+	testCases := []struct {
+		attempt int
+		header  http.Header
+		min     time.Duration
+		max     time.Duration
+	}{
+		{0, nil, 1 * time.Second, 2 * time.Second},
+		{1, nil, 2 * time.Second, 4 * time.Second},
+		{2, nil, 4 * time.Second, 8 * time.Second},
+		{3, nil, 5 * time.Second, 10 * time.Second},
+		{10, nil, 5 * time.Second, 10 * time.Second},
+		{0, http.Header{"Retry-After": []string{"7"}}, 7 * time.Second, 7 * time.Second},
+		{0, http.Header{"Retry-After": []string{"120"}}, 10 * time.Second, 10 * time.Second},
+		{0, http.Header{"Retry-After": []string{"invalid"}}, 1 * time.Second, 2 * time.Second},
+	}
+
+	for _, testCase := range testCases {
+		wait := client.retryWait(testCase.attempt, testCase.header)
+		if wait < testCase.min || wait > testCase.max {
+			t.Fatalf(
+				"retryWait returned a wait out of bounds for attempt [%d] and header [%v]. "+
+					"Expected [%s] - [%s], got [%s].",
+				testCase.attempt,
+				testCase.header,
+				testCase.min,
+				testCase.max,
+				wait,
+			)
+		}
+	}
+}
+
+// Ensure Retry-After headers holding an HTTP date are understood
+func TestParseRetryAfter_HTTPDate(t *testing.T) {
+	date := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+
+	wait, ok := parseRetryAfter(http.Header{"Retry-After": []string{date}})
+	if !ok || wait <= 0 || wait > time.Minute {
+		t.Fatalf(
+			"parseRetryAfter did not parse the HTTP date [%s]. Got [%s], [%t].",
+			date,
+			wait,
+			ok,
+		)
+	}
+}
@@ -0,0 +1,284 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+)
+
+const (
+	RoleEndpointPrefix = "roles"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// The ForemanRole API model represents a role.  A role grants the
+// permissions of its filters to the users and usergroups it is assigned to.
+type ForemanRole struct {
+	// Inherits the base object's attributes
+	ForemanObject
+	// Locations and organizations the role is assigned to.  Its filters
+	// inherit them unless they override them.
+	ForemanTaxonomies
+
+	// Description of the role
+	Description string `json:"description"`
+	// Roles shipped with Foreman, ie: "Viewer", are builtin.  They cannot be
+	// changed, but can be cloned.
+	Builtin bool `json:"-"`
+	// Origin of roles shipped by Foreman or a plugin, ie: "foreman_remote_execution"
+	Origin string `json:"-"`
+	// ID of the role this role was cloned from
+	ClonedFromId int `json:"-"`
+	// IDs of the filters of the role
+	FilterIds []int `json:"-"`
+}
+
+// ForemanRole struct used for JSON decode.
+type foremanRoleJSON struct {
+	Builtin      interface{}     `json:"builtin"`
+	Origin       string          `json:"origin"`
+	ClonedFromId int             `json:"cloned_from_id"`
+	Filters      []ForemanObject `json:"filters"`
+}
+
+// Implement the Marshaler interface
+func (fr ForemanRole) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/role.go#MarshalJSON")
+
+	frMap := map[string]interface{}{}
+
+	frMap["name"] = fr.Name
+	frMap["description"] = fr.Description
+
+	log.Debugf("frMap: [%v]", frMap)
+
+	return json.Marshal(frMap)
+}
+
+// Implement the Unmarshaler interface
+func (fr *ForemanRole) UnmarshalJSON(b []byte) error {
+	var jsonDecErr error
+
+	// Unmarshal the common Foreman object properties
+	var fo ForemanObject
+	jsonDecErr = json.Unmarshal(b, &fo)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fr.ForemanObject = fo
+
+	var frMap map[string]interface{}
+	jsonDecErr = json.Unmarshal(b, &frMap)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+
+	var ok bool
+	if fr.Description, ok = frMap["description"].(string); !ok {
+		fr.Description = ""
+	}
+
+	var frJSON foremanRoleJSON
+	jsonDecErr = json.Unmarshal(b, &frJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	// Depending on the Foreman version, builtin is returned as boolean or as
+	// the number of the builtin role type
+	switch builtin := frJSON.Builtin.(type) {
+	case bool:
+		fr.Builtin = builtin
+	case float64:
+		fr.Builtin = builtin != 0
+	}
+	fr.Origin = frJSON.Origin
+	fr.ClonedFromId = frJSON.ClonedFromId
+	fr.FilterIds = foremanObjectArrayToIdIntArray(frJSON.Filters)
+
+	// Unmarshal the assigned locations and organizations
+	fr.ForemanTaxonomies, jsonDecErr = unmarshalForemanTaxonomies(b)
+	return jsonDecErr
+}
+
+// -----------------------------------------------------------------------------
+// CRUD Implementation
+// -----------------------------------------------------------------------------
+
+// CreateRole creates a new ForemanRole with the attributes of the supplied
+// ForemanRole reference and returns the created ForemanRole reference.  The
+// returned reference will have its ID and other API default values set by
+// this function.
+func (c *Client) CreateRole(ctx context.Context, r *ForemanRole) (*ForemanRole, error) {
+	log.Tracef("foreman/api/role.go#Create")
+
+	reqEndpoint := fmt.Sprintf("/%s", RoleEndpointPrefix)
+
+	roleJSONBytes, jsonEncErr := c.WrapJSONWithTaxonomy("role", r)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("roleJSONBytes: [%s]", roleJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(roleJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var createdRole ForemanRole
+	sendErr := c.SendAndParse(req, &createdRole)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("createdRole: [%+v]", createdRole)
+
+	return &createdRole, nil
+}
+
+// CloneRole creates a new ForemanRole with the attributes of the supplied
+// ForemanRole reference and a copy of the filters of the role identified by
+// the supplied ID.  Returns the created ForemanRole reference.
+func (c *Client) CloneRole(ctx context.Context, id int, r *ForemanRole) (*ForemanRole, error) {
+	log.Tracef("foreman/api/role.go#Clone")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d/clone", RoleEndpointPrefix, id)
+
+	roleJSONBytes, jsonEncErr := c.WrapJSONWithTaxonomy("role", r)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("roleJSONBytes: [%s]", roleJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		reqEndpoint,
+		bytes.NewBuffer(roleJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var clonedRole ForemanRole
+	sendErr := c.SendAndParse(req, &clonedRole)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("clonedRole: [%+v]", clonedRole)
+
+	return &clonedRole, nil
+}
+
+// ReadRole reads the attributes of a ForemanRole identified by the supplied ID
+// and returns a ForemanRole reference.
+func (c *Client) ReadRole(ctx context.Context, id int) (*ForemanRole, error) {
+	log.Tracef("foreman/api/role.go#Read")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", RoleEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var readRole ForemanRole
+	sendErr := c.SendAndParse(req, &readRole)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("readRole: [%+v]", readRole)
+
+	return &readRole, nil
+}
+
+// UpdateRole updates a ForemanRole's attributes.  The role with the ID of the
+// supplied ForemanRole will be updated. A new ForemanRole reference is
+// returned with the attributes from the result of the update operation.
+func (c *Client) UpdateRole(ctx context.Context, r *ForemanRole) (*ForemanRole, error) {
+	log.Tracef("foreman/api/role.go#Update")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", RoleEndpointPrefix, r.Id)
+
+	roleJSONBytes, jsonEncErr := c.WrapJSONWithTaxonomy("role", r)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("roleJSONBytes: [%s]", roleJSONBytes)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodPut,
+		reqEndpoint,
+		bytes.NewBuffer(roleJSONBytes),
+	)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	var updatedRole ForemanRole
+	sendErr := c.SendAndParse(req, &updatedRole)
+	if sendErr != nil {
+		return nil, sendErr
+	}
+
+	log.Debugf("updatedRole: [%+v]", updatedRole)
+
+	return &updatedRole, nil
+}
+
+// DeleteRole deletes the ForemanRole identified by the supplied ID
+func (c *Client) DeleteRole(ctx context.Context, id int) error {
+	log.Tracef("foreman/api/role.go#Delete")
+
+	reqEndpoint := fmt.Sprintf("/%s/%d", RoleEndpointPrefix, id)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodDelete,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return reqErr
+	}
+
+	return c.SendAndParse(req, nil)
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QueryRole queries for a ForemanRole based on the attributes of the supplied
+// ForemanRole reference and returns a QueryResponse struct containing
+// query/response metadata and the matching roles.
+func (c *Client) QueryRole(ctx context.Context, r *ForemanRole) (QueryResponse, error) {
+	log.Tracef("foreman/api/role.go#Search")
+
+	reqEndpoint := fmt.Sprintf("/%s", RoleEndpointPrefix)
+	return searchQueryResponse[ForemanRole](ctx, c, reqEndpoint, SearchQuery{
+		Search: SearchEquals("name", r.Name),
+	})
+}
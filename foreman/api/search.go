@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/utils"
+)
+
+// -----------------------------------------------------------------------------
+// Search Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// SearchQuery describes a search against one of the API's index endpoints
+// (following the format /api/<resource name>).
+type SearchQuery struct {
+	// Foreman search expression, ie: `name = "x86_64" or name = "i386"`.
+	// An empty expression matches every object of the endpoint.
+	Search string
+	// How many results to request per page.  A value <= 0 uses the page size
+	// configured on the server ('entries_per_page' setting).  Every page is
+	// requested regardless of the page size.
+	PerPage int
+	// Additional query parameters sent along with the search, ie: the
+	// organization_id required by some of the Katello endpoints.
+	Params url.Values
+}
+
+// SearchEquals returns a search expression matching the objects whose field
+// equals the supplied value.  The value is quoted so that it may contain
+// whitespace and operators.
+func SearchEquals(field string, value string) string {
+	return field + `="` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
+
+// -----------------------------------------------------------------------------
+// Search Implementation
+// -----------------------------------------------------------------------------
+
+// Search sends the SearchQuery to the endpoint and returns the matching
+// objects of every result page decoded into a slice of T.
+//
+// Go does not allow type parameters on methods, therefore the client is
+// passed as an argument:
+//
+//	archs, err := api.Search[api.ForemanArchitecture](ctx, client, "/architectures", api.SearchQuery{
+//		Search: `name ~ "x86"`,
+//	})
+func Search[T any](ctx context.Context, c *Client, endpoint string, query SearchQuery) ([]T, error) {
+	results, _, err := search[T](ctx, c, endpoint, query)
+	return results, err
+}
+
+// searchQueryResponse performs the same search as Search, but returns the
+// results wrapped in a QueryResponse as expected from the Query* functions.
+func searchQueryResponse[T any](ctx context.Context, c *Client, endpoint string, query SearchQuery) (QueryResponse, error) {
+	results, queryResponse, err := search[T](ctx, c, endpoint, query)
+	if err != nil {
+		return queryResponse, err
+	}
+
+	queryResponse.Results = make([]interface{}, len(results))
+	for idx, val := range results {
+		queryResponse.Results[idx] = val
+	}
+
+	return queryResponse, nil
+}
+
+// search builds the request for the SearchQuery and decodes every page of
+// results into T.  The returned QueryResponse only carries the metadata of
+// the search - its Results are left empty.
+func search[T any](ctx context.Context, c *Client, endpoint string, query SearchQuery) ([]T, QueryResponse, error) {
+	utils.TraceFunctionCall()
+
+	queryResponse := QueryResponse{}
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		endpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, queryResponse, reqErr
+	}
+
+	reqQuery := req.URL.Query()
+	for key, values := range query.Params {
+		for _, value := range values {
+			reqQuery.Add(key, value)
+		}
+	}
+	if query.Search != "" {
+		reqQuery.Set("search", query.Search)
+	}
+	if query.PerPage > 0 {
+		reqQuery.Set("per_page", strconv.Itoa(query.PerPage))
+	}
+	req.URL.RawQuery = reqQuery.Encode()
+
+	results := []T{}
+	sendErr := c.sendAndParseQueryPages(req, &queryResponse, func(page json.RawMessage) (int, error) {
+		var pageResults []T
+		if err := json.Unmarshal(page, &pageResults); err != nil {
+			return 0, err
+		}
+		results = append(results, pageResults...)
+		return len(pageResults), nil
+	})
+	if sendErr != nil {
+		return nil, queryResponse, sendErr
+	}
+
+	log.Debugf("queryResponse: [%+v], results: [%+v]", queryResponse, results)
+
+	return results, queryResponse, nil
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// SearchEquals
+// ----------------------------------------------------------------------------
+
+// Ensure SearchEquals quotes the value and escapes embedded quotes
+func TestSearchEquals(t *testing.T) {
+	testCases := map[string]string{
+		"x86_64":          `name="x86_64"`,
+		"with space":      `name="with space"`,
+		`quote"inside`:    `name="quote\"inside"`,
+		"":                `name=""`,
+		"a = b or c != d": `name="a = b or c != d"`,
+	}
+
+	for value, expected := range testCases {
+		actual := SearchEquals("name", value)
+		if actual != expected {
+			t.Fatalf(
+				"SearchEquals did not return the correct expression. "+
+					"Expected [%s], got [%s] for value [%s].",
+				expected,
+				actual,
+				value,
+			)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Search
+// ----------------------------------------------------------------------------
+
+// Ensure Search sends the search expression, page size and additional
+// parameters and decodes the results into the requested type
+func TestSearch_TypedResults(t *testing.T) {
+	cred := ClientCredentials{}
+	conf := ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	results := []ForemanObject{{Id: 1, Name: "i386"}, {Id: 2, Name: "x86_64"}}
+	requests := 0
+	handler := paginatedQueryHandler(t, results, 1, &requests)
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/"+ArchitectureEndpointPrefix, func(w http.ResponseWriter, r *http.Request) {
+		reqQuery := r.URL.Query()
+		expectedQuery := map[string]string{
+			"search":          `name ~ "86"`,
+			"per_page":        "1",
+			"organization_id": "3",
+		}
+		for key, value := range expectedQuery {
+			if reqQuery.Get(key) != value {
+				t.Fatalf(
+					"Search did not send the correct query parameter [%s]. "+
+						"Expected [%s], got [%s].",
+					key,
+					value,
+					reqQuery.Get(key),
+				)
+			}
+		}
+		handler(w, r)
+	})
+
+	archs, searchErr := Search[ForemanArchitecture](
+		context.TODO(),
+		client,
+		ArchitectureEndpointPrefix,
+		SearchQuery{
+			Search:  `name ~ "86"`,
+			PerPage: 1,
+			Params: url.Values{
+				"organization_id": []string{"3"},
+			},
+		},
+	)
+	if searchErr != nil {
+		t.Fatalf("Search returned an error: [%s]", searchErr)
+	}
+
+	if len(archs) != len(results) {
+		t.Fatalf(
+			"Search did not return the results of every page. "+
+				"Expected [%d], got [%d].",
+			len(results),
+			len(archs),
+		)
+	}
+	for idx, arch := range archs {
+		if arch.ForemanObject != results[idx] {
+			t.Fatalf(
+				"Search did not decode the results. "+
+					"Expected [%+v], got [%+v].",
+				results[idx],
+				arch.ForemanObject,
+			)
+		}
+	}
+}
+
+// Ensure Search returns an error when the server responds with a status
+// code not in the 2xx range
+func TestSearch_StatusCodeError(t *testing.T) {
+	cred := ClientCredentials{}
+	conf := ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	mux.HandleFunc(FOREMAN_API_URL_PREFIX+"/foo", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	_, searchErr := Search[ForemanObject](context.TODO(), client, "/foo", SearchQuery{})
+	if searchErr == nil {
+		t.Fatalf(
+			"Search did not return an error when the server responded with a " +
+				"non-2xx status code. Expected [error] got [nil]",
+		)
+	}
+}
@@ -1,8 +1,8 @@
 package api
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -82,59 +82,54 @@ func (c *Client) ReadSetting(ctx context.Context, id string) (*ForemanSetting, e
 	return &readSetting, nil
 }
 
-// QuerySetting queries for a ForemanSetting based on the attributes of the
-// supplied ForemanSetting reference and returns a QueryResponse struct
-// containing query/response metadata and the matching settings.
-// TODO: Copied from QueryDomains.
-func (c *Client) QuerySetting(ctx context.Context, d *ForemanSetting) (QueryResponse, error) {
-	log.Tracef("foreman/api/setting.go#Query")
+// UpdateSetting updates the value of the ForemanSetting identified by the
+// supplied ForemanSetting's ID and returns the updated setting.  Only the
+// value of a setting can be changed.  The value has to match the setting's
+// SettingsType, ie: a boolean for "boolean" settings.
+func (c *Client) UpdateSetting(ctx context.Context, s *ForemanSetting) (*ForemanSetting, error) {
+	log.Tracef("foreman/api/setting.go#Update")
 
-	queryResponse := QueryResponse{}
+	reqEndpoint := fmt.Sprintf("/%s/%s", SettingEndpointPrefix, s.Id)
+
+	sJSONBytes, jsonEncErr := c.WrapJSON("setting", map[string]interface{}{
+		"value": s.Value,
+	})
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	log.Debugf("settingJSONBytes: [%s]", sJSONBytes)
 
-	reqEndpoint := fmt.Sprintf("/%s", SettingEndpointPrefix)
 	req, reqErr := c.NewRequestWithContext(
 		ctx,
-		http.MethodGet,
+		http.MethodPut,
 		reqEndpoint,
-		nil,
+		bytes.NewBuffer(sJSONBytes),
 	)
 	if reqErr != nil {
-		return queryResponse, reqErr
+		return nil, reqErr
 	}
 
-	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
-	name := `"` + d.Name + `"`
-	reqQuery.Set("search", "name="+name)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	var updatedSetting ForemanSetting
+	sendErr := c.SendAndParse(req, &updatedSetting)
 	if sendErr != nil {
-		return queryResponse, sendErr
+		return nil, sendErr
 	}
 
-	log.Debugf("queryResponse: [%+v]", queryResponse)
+	log.Debugf("updatedSetting: [%+v]", updatedSetting)
 
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanSetting for
-	// the results
-	results := []ForemanSetting{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanSetting to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
+	return &updatedSetting, nil
+}
+
+// QuerySetting queries for a ForemanSetting based on the attributes of the
+// supplied ForemanSetting reference and returns a QueryResponse struct
+// containing query/response metadata and the matching settings.
+// TODO: Copied from QueryDomains.
+func (c *Client) QuerySetting(ctx context.Context, d *ForemanSetting) (QueryResponse, error) {
+	log.Tracef("foreman/api/setting.go#Query")
 
-	return queryResponse, nil
+	reqEndpoint := fmt.Sprintf("/%s", SettingEndpointPrefix)
+	return searchQueryResponse[ForemanSetting](ctx, c, reqEndpoint, SearchQuery{
+		Search: SearchEquals("name", d.Name),
+	})
 }
@@ -2,7 +2,6 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -70,53 +69,7 @@ func (c *Client) QuerySmartClassParameter(ctx context.Context, t *ForemanSmartCl
 	log.Tracef("foreman/api/smartclassparameter.go#Search")
 
 	reqEndpoint := fmt.Sprintf(SmartClassParameterQueryEndpointPrefix, t.PuppetClassId)
-
-	queryResponse := QueryResponse{}
-
-	req, reqErr := c.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		reqEndpoint,
-		nil,
-	)
-	if reqErr != nil {
-		return QueryResponse{}, reqErr
-	}
-
-	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
-	param := `"` + t.Parameter + `"`
-	reqQuery.Set("search", "parameter="+param)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return QueryResponse{}, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	// Encode back to JSON, then Unmarshal into []ForemanPuppetClass for
-	// the results
-	results := []ForemanSmartClassParameter{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return QueryResponse{}, jsonEncErr
-	}
-
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return QueryResponse{}, jsonDecErr
-	}
-	// convert the search results from []ForemanSmartParameterClass to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return searchQueryResponse[ForemanSmartClassParameter](ctx, c, reqEndpoint, SearchQuery{
+		Search: SearchEquals("parameter", t.Parameter),
+	})
 }
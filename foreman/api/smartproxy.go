@@ -12,6 +12,7 @@ import (
 
 const (
 	SmartProxyEndpointPrefix = "smart_proxies"
+	StatusesEndpoint         = "statuses"
 )
 
 // -----------------------------------------------------------------------------
@@ -31,9 +32,77 @@ const (
 type ForemanSmartProxy struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Locations and organizations the smart proxy is assigned to
+	ForemanTaxonomies
 
 	// Uniform resource locator of the proxy (ie: https://server:8008)
 	URL string `json:"url"`
+
+	// Features the proxy provides.  Only returned by the API, never sent.
+	Features []ForemanSmartProxyFeature `json:"features,omitempty"`
+}
+
+// ForemanSmartProxyFeature is a feature a smart proxy provides, ie: "DHCP"
+// or "External IPAM"
+type ForemanSmartProxyFeature struct {
+	// Inherits the base object's attributes
+	ForemanObject
+
+	// Capabilities of the feature
+	Capabilities []string `json:"capabilities,omitempty"`
+}
+
+// ForemanSmartProxyStatus is the status of a smart proxy as reported by
+// Foreman's status page
+type ForemanSmartProxyStatus struct {
+	// Name of the smart proxy
+	Name string `json:"name"`
+	// Status of the smart proxy, "ok" or "FAIL"
+	Status string `json:"status"`
+	// Version of the smart proxy
+	Version string `json:"version"`
+	// Versions of the working features by the name of the feature
+	Features map[string]string `json:"features"`
+	// Error messages of the failing features by the name of the feature
+	FailedFeatures map[string]string `json:"failed_features"`
+}
+
+// foremanStatusesJSON is used for JSON decode of Foreman's status page.  We
+// are only interested in the status of the smart proxies.
+type foremanStatusesJSON struct {
+	Results struct {
+		Foreman struct {
+			SmartProxies []ForemanSmartProxyStatus `json:"smart_proxies"`
+		} `json:"foreman"`
+	} `json:"results"`
+}
+
+// Implement the Unmarshaler interface
+func (fs *ForemanSmartProxy) UnmarshalJSON(b []byte) error {
+	// Decode the smart proxy properties with the default decoder - the type
+	// conversion drops this method to avoid the recursion
+	type foremanSmartProxy ForemanSmartProxy
+	var obj foremanSmartProxy
+	jsonDecErr := json.Unmarshal(b, &obj)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	*fs = ForemanSmartProxy(obj)
+
+	// Unmarshal the assigned locations and organizations
+	fs.ForemanTaxonomies, jsonDecErr = unmarshalForemanTaxonomies(b)
+	return jsonDecErr
+}
+
+// HasFeature returns whether the smart proxy provides the feature with the
+// supplied name
+func (fs ForemanSmartProxy) HasFeature(name string) bool {
+	for _, feature := range fs.Features {
+		if feature.Name == name {
+			return true
+		}
+	}
+	return false
 }
 
 // -----------------------------------------------------------------------------
@@ -161,62 +230,75 @@ func (c *Client) DeleteSmartProxy(ctx context.Context, id int) error {
 	return c.SendAndParse(req, nil)
 }
 
-// -----------------------------------------------------------------------------
-// Query Implementation
-// -----------------------------------------------------------------------------
-
-// QuerySmartProxy queries for a ForemanSmartProxy based on the attributes of
-// the supplied ForemanSmartProxy reference and returns a QueryResponse struct
-// containing query/response metadata and the matching smart proxy.
-func (c *Client) QuerySmartProxy(ctx context.Context, s *ForemanSmartProxy) (QueryResponse, error) {
-	log.Tracef("foreman/api/smartproxy.go#Search")
+// RefreshSmartProxy refreshes the features of the ForemanSmartProxy
+// identified by the supplied ID and returns the refreshed ForemanSmartProxy
+// reference
+func (c *Client) RefreshSmartProxy(ctx context.Context, id int) (*ForemanSmartProxy, error) {
+	log.Tracef("foreman/api/smartproxy.go#Refresh")
 
-	queryResponse := QueryResponse{}
+	reqEndpoint := fmt.Sprintf("/%s/%d/refresh", SmartProxyEndpointPrefix, id)
 
-	reqEndpoint := fmt.Sprintf("/%s", SmartProxyEndpointPrefix)
 	req, reqErr := c.NewRequestWithContext(
 		ctx,
-		http.MethodGet,
+		http.MethodPut,
 		reqEndpoint,
 		nil,
 	)
 	if reqErr != nil {
-		return queryResponse, reqErr
+		return nil, reqErr
 	}
 
-	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
-	name := `"` + s.Name + `"`
-	reqQuery.Set("search", "name="+name)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
+	var refreshedSmartProxy ForemanSmartProxy
+	sendErr := c.SendAndParse(req, &refreshedSmartProxy)
 	if sendErr != nil {
-		return queryResponse, sendErr
+		return nil, sendErr
 	}
 
-	log.Debugf("queryResponse: [%+v]", queryResponse)
+	log.Debugf("refreshedSmartProxy: [%+v]", refreshedSmartProxy)
 
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanSmartProxy for
-	// the results
-	results := []ForemanSmartProxy{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
+	return &refreshedSmartProxy, nil
+}
+
+// ReadSmartProxyStatuses reads the status of all smart proxies from Foreman's
+// status page
+func (c *Client) ReadSmartProxyStatuses(ctx context.Context) ([]ForemanSmartProxyStatus, error) {
+	log.Tracef("foreman/api/smartproxy.go#ReadStatuses")
+
+	reqEndpoint := fmt.Sprintf("/%s", StatusesEndpoint)
+
+	req, reqErr := c.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		reqEndpoint,
+		nil,
+	)
+	if reqErr != nil {
+		return nil, reqErr
 	}
-	// convert the search results from []ForemanSmartProxy to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
+
+	var statuses foremanStatusesJSON
+	sendErr := c.SendAndParse(req, &statuses)
+	if sendErr != nil {
+		return nil, sendErr
 	}
-	queryResponse.Results = iArr
 
-	return queryResponse, nil
+	log.Debugf("statuses: [%+v]", statuses)
+
+	return statuses.Results.Foreman.SmartProxies, nil
+}
+
+// -----------------------------------------------------------------------------
+// Query Implementation
+// -----------------------------------------------------------------------------
+
+// QuerySmartProxy queries for a ForemanSmartProxy based on the attributes of
+// the supplied ForemanSmartProxy reference and returns a QueryResponse struct
+// containing query/response metadata and the matching smart proxy.
+func (c *Client) QuerySmartProxy(ctx context.Context, s *ForemanSmartProxy) (QueryResponse, error) {
+	log.Tracef("foreman/api/smartproxy.go#Search")
+
+	reqEndpoint := fmt.Sprintf("/%s", SmartProxyEndpointPrefix)
+	return searchQueryResponse[ForemanSmartProxy](ctx, c, reqEndpoint, SearchQuery{
+		Search: SearchEquals("name", s.Name),
+	})
 }
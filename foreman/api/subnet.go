@@ -12,6 +12,11 @@ import (
 
 const (
 	SubnetEndpointPrefix = "subnets"
+
+	// IP address management modes of subnets which require a smart proxy
+	// with the feature of the same name
+	SubnetIpamDHCP         = "DHCP"
+	SubnetIpamExternalIPAM = "External IPAM"
 )
 
 // -----------------------------------------------------------------------------
@@ -28,6 +33,8 @@ type Domain struct {
 type ForemanSubnet struct {
 	// Inherits the base object's attributes
 	ForemanObject
+	// Locations and organizations the object is assigned to
+	ForemanTaxonomies
 
 	// Subnet network (ie: 192.168.100.0)
 	Network string `json:"network"`
@@ -62,6 +69,15 @@ type ForemanSubnet struct {
 	TemplateID *int `json:"template_id"`
 	// DHCP ID
 	DhcpID *int `json:"dhcp_id"`
+	// DNS ID
+	DnsID *int `json:"dns_id"`
+	// External IPAM ID
+	ExternalIpamID *int `json:"externalipam_id"`
+	// Discovery ID
+	DiscoveryID *int `json:"discovery_id"`
+	// IDs of the smart proxies executing remote jobs on the hosts of the
+	// subnet
+	RemoteExecutionProxyIds []int `json:"-"`
 	// BMC ID
 	BmcID *int `json:"bmc_id"`
 	// TFTP ID
@@ -74,6 +90,80 @@ type ForemanSubnet struct {
 	Domains []Domain `json:"domains"`
 	// Network Type
 	NetworkType string `json:"network_type"`
+	// Map of SubnetParameters
+	SubnetParameters []ForemanKVParameter `json:"subnet_parameters_attributes,omitempty"`
+}
+
+// ForemanSubnet struct used for JSON decode.  Foreman API returns the remote
+// execution proxies as list of ForemanObjects and the parameters under a
+// different key.
+type foremanSubnetJSON struct {
+	RemoteExecutionProxies []ForemanObject      `json:"remote_execution_proxies"`
+	Parameters             []ForemanKVParameter `json:"parameters"`
+}
+
+// Implement the Marshaler interface
+func (fs ForemanSubnet) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/subnet.go#MarshalJSON")
+
+	// Encode the subnet properties with the default encoder - the type
+	// conversion drops this method to avoid the recursion
+	type foremanSubnet ForemanSubnet
+	fsBytes, jsonEncErr := json.Marshal(foremanSubnet(fs))
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	var fsMap map[string]interface{}
+	jsonEncErr = json.Unmarshal(fsBytes, &fsMap)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	// Only send the remote execution proxies if they are managed.  An empty
+	// array removes all of them - Foreman interprets the array as a REPLACE
+	// operation.
+	if fs.RemoteExecutionProxyIds != nil {
+		fsMap["remote_execution_proxy_ids"] = fs.RemoteExecutionProxyIds
+	}
+
+	return json.Marshal(fsMap)
+}
+
+// Implement the Unmarshaler interface
+func (fs *ForemanSubnet) UnmarshalJSON(b []byte) error {
+	// Decode the subnet properties with the default decoder - the type
+	// conversion drops this method to avoid the recursion
+	type foremanSubnet ForemanSubnet
+	var obj foremanSubnet
+	jsonDecErr := json.Unmarshal(b, &obj)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	*fs = ForemanSubnet(obj)
+
+	// Unmarshal the assigned locations and organizations
+	fs.ForemanTaxonomies, jsonDecErr = unmarshalForemanTaxonomies(b)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+
+	// Unmarshal to temporary JSON struct to get the properties with
+	// differently named keys.  Properties missing in the response - ie: in
+	// search results - are left nil.
+	var fsJSON foremanSubnetJSON
+	jsonDecErr = json.Unmarshal(b, &fsJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	if fsJSON.RemoteExecutionProxies != nil {
+		fs.RemoteExecutionProxyIds = foremanObjectArrayToIdIntArray(fsJSON.RemoteExecutionProxies)
+	}
+	if fsJSON.Parameters != nil {
+		fs.SubnetParameters = fsJSON.Parameters
+	}
+
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -215,57 +305,17 @@ func (c *Client) DeleteSubnet(ctx context.Context, id int) error {
 func (c *Client) QuerySubnet(ctx context.Context, s *ForemanSubnet) (QueryResponse, error) {
 	log.Tracef("foreman/api/subnet.go#Search")
 
-	queryResponse := QueryResponse{}
-
 	reqEndpoint := fmt.Sprintf("/%s", SubnetEndpointPrefix)
-	req, reqErr := c.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		reqEndpoint,
-		nil,
-	)
-	if reqErr != nil {
-		return queryResponse, reqErr
-	}
 
 	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
+	var search string
 	if s.Name != "" {
-		name := `"` + s.Name + `"`
-		reqQuery.Set("search", "name="+name)
+		search = SearchEquals("name", s.Name)
 	} else if s.Network != "" {
-		network := `"` + s.Network + `"`
-		reqQuery.Set("search", "network="+network)
-	}
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanSubnet for
-	// the results
-	results := []ForemanSubnet{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanSubnet to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
+		search = SearchEquals("network", s.Network)
 	}
-	queryResponse.Results = iArr
 
-	return queryResponse, nil
+	return searchQueryResponse[ForemanSubnet](ctx, c, reqEndpoint, SearchQuery{
+		Search: search,
+	})
 }
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"encoding/json"
+)
+
+// -----------------------------------------------------------------------------
+// Struct Definition and Helpers
+// -----------------------------------------------------------------------------
+
+// ForemanTaxonomies holds the IDs of the locations and organizations a
+// taxable object is assigned to.  API models embed it to override the
+// provider's default location and organization per object.
+//
+// A nil slice keeps the provider's default for that taxonomy.  An empty
+// slice removes all assignments of that taxonomy.
+type ForemanTaxonomies struct {
+	// IDs of the locations the object is assigned to
+	LocationIds []int `json:"-"`
+	// IDs of the organizations the object is assigned to
+	OrganizationIds []int `json:"-"`
+}
+
+// taxonomyAssignments is implemented by API models embedding
+// ForemanTaxonomies.  WrapJSONWithTaxonomy adds the assignments to the
+// wrapped object.
+type taxonomyAssignments interface {
+	taxonomies() ForemanTaxonomies
+}
+
+// taxonomyContext is implemented by API models overriding the taxonomy
+// context of the request, which Foreman uses to scope the request and to
+// assign new objects.  A positive ID replaces the provider's default, 0 keeps
+// the default and a negative ID omits the taxonomy from the request.
+type taxonomyContext interface {
+	taxonomyContext() (locationId int, organizationId int)
+}
+
+func (ft ForemanTaxonomies) taxonomies() ForemanTaxonomies {
+	return ft
+}
+
+// The taxonomy context of an object assigned to exactly one location or
+// organization is that taxonomy.  If it is assigned to several (or none), the
+// context is omitted - Foreman would add the object to the context's
+// taxonomy otherwise.
+func (ft ForemanTaxonomies) taxonomyContext() (int, int) {
+	return taxonomyContextId(ft.LocationIds), taxonomyContextId(ft.OrganizationIds)
+}
+
+func taxonomyContextId(ids []int) int {
+	switch {
+	case ids == nil:
+		return 0
+	case len(ids) == 1:
+		return ids[0]
+	default:
+		return -1
+	}
+}
+
+// foremanTaxonomiesJSON is used for JSON decode.  Foreman API returns the
+// taxonomies as lists of ForemanObjects.  We are only interested in their
+// IDs.
+type foremanTaxonomiesJSON struct {
+	Locations     []ForemanObject `json:"locations"`
+	Organizations []ForemanObject `json:"organizations"`
+}
+
+// toForemanTaxonomies converts the decoded taxonomies to their IDs.  A
+// taxonomy missing in the response - ie: in search results - is left nil.
+func (ftJSON foremanTaxonomiesJSON) toForemanTaxonomies() ForemanTaxonomies {
+	var ft ForemanTaxonomies
+	if ftJSON.Locations != nil {
+		ft.LocationIds = foremanObjectArrayToIdIntArray(ftJSON.Locations)
+	}
+	if ftJSON.Organizations != nil {
+		ft.OrganizationIds = foremanObjectArrayToIdIntArray(ftJSON.Organizations)
+	}
+	return ft
+}
+
+// unmarshalForemanTaxonomies decodes the taxonomies of a taxable object from
+// the JSON returned by the Foreman API.
+func unmarshalForemanTaxonomies(b []byte) (ForemanTaxonomies, error) {
+	var ftJSON foremanTaxonomiesJSON
+	if jsonDecErr := json.Unmarshal(b, &ftJSON); jsonDecErr != nil {
+		return ForemanTaxonomies{}, jsonDecErr
+	}
+	return ftJSON.toForemanTaxonomies(), nil
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// WrapJSONWithTaxonomy
+// ----------------------------------------------------------------------------
+
+// taxonomyKeys extracts the taxonomy context and assignments from the
+// wrapped JSON
+func taxonomyKeys(wrapped []byte, name string) map[string]interface{} {
+	var wrappedMap map[string]interface{}
+	json.Unmarshal(wrapped, &wrappedMap)
+
+	keys := map[string]interface{}{}
+	for _, key := range []string{"location_id", "organization_id"} {
+		if val, ok := wrappedMap[key]; ok {
+			keys[key] = val
+		}
+	}
+	obj, _ := wrappedMap[name].(map[string]interface{})
+	for _, key := range []string{"location_ids", "organization_ids"} {
+		if val, ok := obj[key]; ok {
+			keys[name+"."+key] = val
+		}
+	}
+	return keys
+}
+
+// Ensure the taxonomies of the items override the provider's default
+// location and organization
+func TestWrapJSONWithTaxonomy_Overrides(t *testing.T) {
+	_, server, client := NewForemanAPIAndClient(
+		ClientCredentials{},
+		ClientConfig{LocationID: 2, OrganizationID: 1},
+	)
+	defer server.Close()
+
+	testCases := []struct {
+		name     string
+		item     interface{}
+		expected string
+	}{
+		{
+			name:     "medium",
+			item:     &ForemanMedia{},
+			expected: `{"location_id":2,"organization_id":1}`,
+		},
+		{
+			name: "medium",
+			item: &ForemanMedia{
+				ForemanTaxonomies: ForemanTaxonomies{OrganizationIds: []int{12}},
+			},
+			expected: `{"location_id":2,"organization_id":12,"medium.organization_ids":[12]}`,
+		},
+		{
+			name: "medium",
+			item: &ForemanMedia{
+				ForemanTaxonomies: ForemanTaxonomies{
+					LocationIds:     []int{2, 7},
+					OrganizationIds: []int{},
+				},
+			},
+			expected: `{"medium.location_ids":[2,7],"medium.organization_ids":[]}`,
+		},
+		{
+			name:     "host",
+			item:     &ForemanHost{LocationId: 7},
+			expected: `{"location_id":7,"organization_id":1}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		wrapped, err := client.WrapJSONWithTaxonomy(testCase.name, testCase.item)
+		if err != nil {
+			t.Fatalf("WrapJSONWithTaxonomy returned an error: [%s]", err)
+		}
+
+		actual := taxonomyKeys(wrapped, testCase.name)
+		var expected map[string]interface{}
+		json.Unmarshal([]byte(testCase.expected), &expected)
+
+		if !reflect.DeepEqual(actual, expected) {
+			t.Fatalf(
+				"WrapJSONWithTaxonomy did not apply the taxonomies of [%+v]. "+
+					"Expected [%v], got [%v].",
+				testCase.item,
+				expected,
+				actual,
+			)
+		}
+	}
+}
+
+// Ensure the taxonomy context is omitted if the provider disables taxonomies
+// and the item does not override them
+func TestWrapJSONWithTaxonomy_Disabled(t *testing.T) {
+	_, server, client := NewForemanAPIAndClient(
+		ClientCredentials{},
+		ClientConfig{LocationID: -1, OrganizationID: -1},
+	)
+	defer server.Close()
+
+	wrapped, _ := client.WrapJSONWithTaxonomy("environment", &ForemanEnvironment{
+		ForemanObject:     ForemanObject{Name: "production"},
+		ForemanTaxonomies: ForemanTaxonomies{LocationIds: []int{7}},
+	})
+
+	expected := `{"environment":{"created_at":"","id":0,"location_ids":[7],` +
+		`"name":"production","updated_at":""},"location_id":7}`
+	if string(wrapped) != expected {
+		t.Fatalf(
+			"WrapJSONWithTaxonomy did not omit the disabled taxonomy. "+
+				"Expected [%s], got [%s].",
+			expected,
+			wrapped,
+		)
+	}
+}
+
+// Ensure the provider's taxonomies are only sent if both of them are set
+func TestWrapJSONWithTaxonomy_ProviderPair(t *testing.T) {
+	configs := []ClientConfig{
+		{LocationID: 2, OrganizationID: -1},
+		{LocationID: -1, OrganizationID: 1},
+	}
+	for _, conf := range configs {
+		_, server, client := NewForemanAPIAndClient(ClientCredentials{}, conf)
+		wrapped, _ := client.WrapJSONWithTaxonomy("medium", &ForemanMedia{})
+		server.Close()
+
+		if actual := taxonomyKeys(wrapped, "medium"); len(actual) != 0 {
+			t.Fatalf(
+				"WrapJSONWithTaxonomy sent a partial taxonomy context for the "+
+					"provider's location [%d] and organization [%d]. Got [%v].",
+				conf.LocationID,
+				conf.OrganizationID,
+				actual,
+			)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+// unmarshalForemanTaxonomies
+// ----------------------------------------------------------------------------
+
+// Ensure the assigned taxonomies are decoded to their IDs and taxonomies
+// missing in the response are left nil
+func TestUnmarshalJSON_ForemanTaxonomies(t *testing.T) {
+	var fs ForemanSubnet
+	err := json.Unmarshal([]byte(`{
+		"id": 5,
+		"name": "10.228.192.0 DC1",
+		"network": "10.228.192.0",
+		"locations": [{"id": 2, "name": "DC1"}, {"id": 7, "name": "DC2"}]
+	}`), &fs)
+	if err != nil {
+		t.Fatalf("ForemanSubnet UnmarshalJSON returned an error: [%s]", err)
+	}
+
+	expected := ForemanTaxonomies{LocationIds: []int{2, 7}}
+	if !reflect.DeepEqual(fs.ForemanTaxonomies, expected) {
+		t.Fatalf(
+			"ForemanSubnet UnmarshalJSON did not decode the taxonomies. "+
+				"Expected [%+v], got [%+v].",
+			expected,
+			fs.ForemanTaxonomies,
+		)
+	}
+	if fs.Id != 5 || fs.Network != "10.228.192.0" {
+		t.Fatalf("ForemanSubnet UnmarshalJSON did not decode the subnet. Got [%+v].", fs)
+	}
+}
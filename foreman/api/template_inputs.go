@@ -240,44 +240,10 @@ func (c *Client) CreateTemplateInput(ctx context.Context, tiObj *ForemanTemplate
 func (c *Client) QueryTemplateInput(ctx context.Context, tiObj *ForemanTemplateInput) (QueryResponse, error) {
 	utils.TraceFunctionCall()
 
-	qresp := QueryResponse{}
 	endpoint := fmt.Sprintf("/"+TemplateInputEndpointPrefix, tiObj.TemplateId)
-
-	req, err := c.NewRequestWithContext(
-		ctx, http.MethodGet, endpoint, nil,
-	)
-	if err != nil {
-		return qresp, err
-	}
-
-	reqQuery := req.URL.Query()
-	name := `"` + tiObj.Name + `"`
-	reqQuery.Set("search", "name="+name)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	err = c.SendAndParse(req, &qresp)
-	if err != nil {
-		return qresp, err
-	}
-
-	results := []ForemanTemplateInput{}
-	resultsBytes, err := json.Marshal(qresp.Results)
-	if err != nil {
-		return qresp, err
-	}
-
-	err = json.Unmarshal(resultsBytes, &results)
-	if err != nil {
-		return qresp, err
-	}
-
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	qresp.Results = iArr
-
-	return qresp, nil
+	return searchQueryResponse[ForemanTemplateInput](ctx, c, endpoint, SearchQuery{
+		Search: SearchEquals("name", tiObj.Name),
+	})
 }
 
 func (c *Client) ReadTemplateInput(ctx context.Context, tiObj *ForemanTemplateInput) (*ForemanTemplateInput, error) {
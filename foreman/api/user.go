@@ -12,6 +12,9 @@ import (
 
 const (
 	UserEndpointPrefix = "users"
+
+	// Foreman assigns the builtin default role to every user
+	DefaultRoleName = "Default role"
 )
 
 // -----------------------------------------------------------------------------
@@ -37,6 +40,9 @@ type ForemanUser struct {
 	// email of user
 	Mail string `json:"mail,omitempty"`
 
+	// if the user receives email notifications.  Left to Foreman if nil.
+	MailEnabled *bool `json:"mail_enabled,omitempty"`
+
 	// user description
 	Description string `json:"description,omitempty"`
 
@@ -56,10 +62,96 @@ type ForemanUser struct {
 	Locale string `json:"locale,omitempty"`
 
 	// list of all locations for user
-	LocationIds []int `json:"location_ids,omitempty"`
+	LocationIds []int `json:"-"`
 
 	// list of all organisation for user
-	OrganizationIds []int `json:"organization_ids,omitempty"`
+	OrganizationIds []int `json:"-"`
+
+	// list of the roles of the user, without the builtin default role
+	RoleIds []int `json:"-"`
+}
+
+// ForemanUser struct used for JSON decode.  Foreman API returns the
+// taxonomies and roles as lists of ForemanObjects.  We are only interested
+// in their IDs.
+type foremanUserJSON struct {
+	Locations     []ForemanObject `json:"locations"`
+	Organizations []ForemanObject `json:"organizations"`
+	Roles         []ForemanObject `json:"roles"`
+}
+
+// Implement the Marshaler interface
+func (fu ForemanUser) MarshalJSON() ([]byte, error) {
+	log.Tracef("foreman/api/user.go#MarshalJSON")
+
+	// Encode the user properties with the default encoder - the type
+	// conversion drops this method to avoid the recursion
+	type foremanUser ForemanUser
+	fuBytes, jsonEncErr := json.Marshal(foremanUser(fu))
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	var fuMap map[string]interface{}
+	jsonEncErr = json.Unmarshal(fuBytes, &fuMap)
+	if jsonEncErr != nil {
+		return nil, jsonEncErr
+	}
+
+	// Only send the assignments which are managed.  An empty array removes
+	// all assignments of that type - Foreman interprets the arrays as a
+	// REPLACE operation.
+	idArrays := map[string][]int{
+		"location_ids":     fu.LocationIds,
+		"organization_ids": fu.OrganizationIds,
+		"role_ids":         fu.RoleIds,
+	}
+	for key, ids := range idArrays {
+		if ids != nil {
+			fuMap[key] = ids
+		}
+	}
+
+	return json.Marshal(fuMap)
+}
+
+// Implement the Unmarshaler interface
+func (fu *ForemanUser) UnmarshalJSON(b []byte) error {
+	// Decode the user properties with the default decoder - the type
+	// conversion drops this method to avoid the recursion
+	type foremanUser ForemanUser
+	var obj foremanUser
+	jsonDecErr := json.Unmarshal(b, &obj)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	*fu = ForemanUser(obj)
+
+	var fuJSON foremanUserJSON
+	jsonDecErr = json.Unmarshal(b, &fuJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	// Assignments missing in the response - ie: in search results - are left
+	// nil
+	if fuJSON.Locations != nil {
+		fu.LocationIds = foremanObjectArrayToIdIntArray(fuJSON.Locations)
+	}
+	if fuJSON.Organizations != nil {
+		fu.OrganizationIds = foremanObjectArrayToIdIntArray(fuJSON.Organizations)
+	}
+	if fuJSON.Roles != nil {
+		// Foreman keeps the default role - it cannot be managed
+		roles := []ForemanObject{}
+		for _, role := range fuJSON.Roles {
+			if role.Name != DefaultRoleName {
+				roles = append(roles, role)
+			}
+		}
+		fu.RoleIds = foremanObjectArrayToIdIntArray(roles)
+	}
+
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -193,67 +285,24 @@ func (c *Client) DeleteUser(ctx context.Context, id int) error {
 func (c *Client) QueryUser(ctx context.Context, s *ForemanUser) (QueryResponse, error) {
 	log.Tracef("foreman/api/user.go#Search")
 
-	queryResponse := QueryResponse{}
-
 	reqEndpoint := fmt.Sprintf("/%s", UserEndpointPrefix)
-	req, reqErr := c.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		reqEndpoint,
-		nil,
-	)
-	if reqErr != nil {
-		return queryResponse, reqErr
-	}
 
 	// dynamically build the query based on the attributes
 	// not all api search fields supported
-	reqQuery := req.URL.Query()
+	var search string
 	if s.Description != "" {
-		description := `"` + s.Description + `"`
-		reqQuery.Set("search", "description="+description)
+		search = SearchEquals("description", s.Description)
 	} else if s.Firstname != "" {
-		firstname := `"` + s.Firstname + `"`
-		reqQuery.Set("search", "firstname="+firstname)
+		search = SearchEquals("firstname", s.Firstname)
 	} else if s.Lastname != "" {
-		lastname := `"` + s.Lastname + `"`
-		reqQuery.Set("search", "firstname="+lastname)
+		search = SearchEquals("lastname", s.Lastname)
 	} else if s.Mail != "" {
-		mail := `"` + s.Mail + `"`
-		reqQuery.Set("search", "firstname="+mail)
+		search = SearchEquals("mail", s.Mail)
 	} else if s.Login != "" {
-		login := `"` + s.Login + `"`
-		reqQuery.Set("search", "login="+login)
-	}
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanUser for
-	// the results
-	results := []ForemanUser{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanUser to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
+		search = SearchEquals("login", s.Login)
 	}
-	queryResponse.Results = iArr
 
-	return queryResponse, nil
+	return searchQueryResponse[ForemanUser](ctx, c, reqEndpoint, SearchQuery{
+		Search: search,
+	})
 }
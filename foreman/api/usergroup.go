@@ -25,6 +25,28 @@ type ForemanUsergroup struct {
 
 	// enables or disables admin access for group members, Must be one of: true, false, 1, 0.
 	Admin bool `json:"admin"`
+
+	// IDs of the users and nested usergroups which are members of the
+	// usergroup
+	UserIds      []int `json:"user_ids"`
+	UsergroupIds []int `json:"usergroup_ids"`
+	// IDs of the roles granted to the members of the usergroup
+	RoleIds []int `json:"role_ids"`
+
+	// Groups of external authentication sources, ie: LDAP, whose members
+	// become members of the usergroup.  They are managed through their own
+	// endpoint and are not sent with the usergroup.
+	ExternalUsergroups []ForemanExternalUsergroup `json:"-"`
+}
+
+// ForemanUsergroup struct used for JSON decode.  Foreman API returns the
+// members and roles as lists of ForemanObjects.  We are only interested in
+// their IDs.
+type foremanUsergroupJSON struct {
+	Users              []ForemanObject            `json:"users"`
+	Usergroups         []ForemanObject            `json:"usergroups"`
+	Roles              []ForemanObject            `json:"roles"`
+	ExternalUsergroups []ForemanExternalUsergroup `json:"external_usergroups"`
 }
 
 // Implement the Marshaler interface
@@ -39,6 +61,20 @@ func (fh ForemanUsergroup) MarshalJSON() ([]byte, error) {
 	fhMap["name"] = fh.Name
 	fhMap["admin"] = fh.Admin
 
+	// Only send the memberships which are managed.  An empty array removes
+	// all members of that type - Foreman interprets the arrays as a REPLACE
+	// operation.
+	idArrays := map[string][]int{
+		"user_ids":      fh.UserIds,
+		"usergroup_ids": fh.UsergroupIds,
+		"role_ids":      fh.RoleIds,
+	}
+	for key, ids := range idArrays {
+		if ids != nil {
+			fhMap[key] = ids
+		}
+	}
+
 	log.Debugf("fhMap: [%v]", fhMap)
 
 	return json.Marshal(fhMap)
@@ -67,6 +103,19 @@ func (fh *ForemanUsergroup) UnmarshalJSON(b []byte) error {
 		fh.Admin = false
 	}
 
+	// Unmarshal to temporary JSON struct to get the properties with
+	// differently named keys
+	var fhJSON foremanUsergroupJSON
+	jsonDecErr = json.Unmarshal(b, &fhJSON)
+	if jsonDecErr != nil {
+		return jsonDecErr
+	}
+	fh.UserIds = foremanObjectArrayToIdIntArray(fhJSON.Users)
+	fh.UsergroupIds = foremanObjectArrayToIdIntArray(fhJSON.Usergroups)
+	fh.RoleIds = foremanObjectArrayToIdIntArray(fhJSON.Roles)
+	// Left nil if missing in the response
+	fh.ExternalUsergroups = fhJSON.ExternalUsergroups
+
 	return nil
 }
 
@@ -205,52 +254,8 @@ func (c *Client) DeleteUsergroup(ctx context.Context, id int) error {
 func (c *Client) QueryUsergroup(ctx context.Context, u *ForemanUsergroup) (QueryResponse, error) {
 	log.Tracef("foreman/api/usergroup.go#Search")
 
-	queryResponse := QueryResponse{}
-
 	reqEndpoint := fmt.Sprintf("/%s", UsergroupEndpointPrefix)
-	req, reqErr := c.NewRequestWithContext(
-		ctx,
-		http.MethodGet,
-		reqEndpoint,
-		nil,
-	)
-	if reqErr != nil {
-		return queryResponse, reqErr
-	}
-
-	// dynamically build the query based on the attributes
-	reqQuery := req.URL.Query()
-	name := `"` + u.Name + `"`
-	reqQuery.Set("search", "name="+name)
-
-	req.URL.RawQuery = reqQuery.Encode()
-	sendErr := c.SendAndParse(req, &queryResponse)
-	if sendErr != nil {
-		return queryResponse, sendErr
-	}
-
-	log.Debugf("queryResponse: [%+v]", queryResponse)
-
-	// Results will be Unmarshaled into a []map[string]interface{}
-	//
-	// Encode back to JSON, then Unmarshal into []ForemanUsergroup for
-	// the results
-	results := []ForemanUsergroup{}
-	resultsBytes, jsonEncErr := json.Marshal(queryResponse.Results)
-	if jsonEncErr != nil {
-		return queryResponse, jsonEncErr
-	}
-	jsonDecErr := json.Unmarshal(resultsBytes, &results)
-	if jsonDecErr != nil {
-		return queryResponse, jsonDecErr
-	}
-	// convert the search results from []ForemanUsergroup to []interface
-	// and set the search results on the query
-	iArr := make([]interface{}, len(results))
-	for idx, val := range results {
-		iArr[idx] = val
-	}
-	queryResponse.Results = iArr
-
-	return queryResponse, nil
+	return searchQueryResponse[ForemanUsergroup](ctx, c, reqEndpoint, SearchQuery{
+		Search: SearchEquals("name", u.Name),
+	})
 }
@@ -1,6 +1,12 @@
 package foreman
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
 	"github.com/HanseMerkur/terraform-provider-utils/log"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
@@ -18,15 +24,45 @@ type Config struct {
 	//
 	// See 'pkg/crypto/tls/#Config.InsecureSkipVerify' for more information.
 	ClientTLSInsecure bool
+	// Path to and content of PEM encoded certificate authorities to verify
+	// the server's certificate with in addition to the system's pool
+	ClientTLSCAFile string
+	ClientTLSCAPEM  string
+	// Paths to the PEM encoded client certificate and key for mutual TLS
+	ClientTLSCertFile string
+	ClientTLSKeyFile  string
+	// Name to verify the server's certificate against instead of the
+	// server's hostname
+	ClientTLSServerName string
 	// Whether or not the client should try to authenticate to foreman
 	// through the HTTP negotiate mechanism.
 	NegotiateAuthEnabled bool
 	// Set of credentials needed to authenticate against Foreman
 	ClientCredentials api.ClientCredentials
+	// How to authenticate against Foreman - one of the api.AuthMethod*
+	// constants.  Defaults to HTTP basic authentication.
+	AuthMethod string
+	// Personal access token for the token authentication
+	ClientToken string
+	// Consumer key and secret for the OAuth authentication and the user to
+	// act as
+	OAuthConsumerKey    string
+	OAuthConsumerSecret string
+	OAuthUser           string
 	// Location for all API Calls
 	LocationID int
 	// Organization for all API Calls
 	OrganizationID int
+	// How long to wait for asynchronous tasks to finish
+	TaskTimeout time.Duration
+	// Bounds of the exponential backoff used when polling asynchronous tasks
+	TaskPollInterval    time.Duration
+	TaskPollMaxInterval time.Duration
+	// How often failed requests are retried and the bounds of the
+	// exponential backoff between the attempts
+	RetryMax     int
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
 }
 
 // Client creates a client reference for the Foreman REST API given the
@@ -36,14 +72,37 @@ type Config struct {
 func (c *Config) Client() (*api.Client, diag.Diagnostics) {
 	log.Tracef("config.go#Client")
 
+	authenticator, diags := c.authenticator()
+	if diags.HasError() {
+		return nil, diags
+	}
+	rootCAs, diags := c.tlsRootCAs()
+	if diags.HasError() {
+		return nil, diags
+	}
+	clientCertificates, diags := c.tlsClientCertificates()
+	if diags.HasError() {
+		return nil, diags
+	}
+
 	client := api.NewClient(
 		c.Server,
 		c.ClientCredentials,
 		api.ClientConfig{
-			TLSInsecureEnabled:   c.ClientTLSInsecure,
-			LocationID:           c.LocationID,
-			OrganizationID:       c.OrganizationID,
-			NegotiateAuthEnabled: c.NegotiateAuthEnabled,
+			TLSInsecureEnabled:    c.ClientTLSInsecure,
+			TLSRootCAs:            rootCAs,
+			TLSClientCertificates: clientCertificates,
+			TLSServerName:         c.ClientTLSServerName,
+			LocationID:            c.LocationID,
+			OrganizationID:        c.OrganizationID,
+			NegotiateAuthEnabled:  c.NegotiateAuthEnabled,
+			Authenticator:         authenticator,
+			TaskTimeout:           c.TaskTimeout,
+			TaskPollInterval:      c.TaskPollInterval,
+			TaskPollMaxInterval:   c.TaskPollMaxInterval,
+			RetryMax:              c.RetryMax,
+			RetryWaitMin:          c.RetryWaitMin,
+			RetryWaitMax:          c.RetryWaitMax,
 		},
 	)
 
@@ -51,3 +110,124 @@ func (c *Config) Client() (*api.Client, diag.Diagnostics) {
 
 	return client, diag.Diagnostics{}
 }
+
+// authenticator returns the authenticator for the configured authentication
+// method.  Returns an error diagnostic if the credentials of the method are
+// missing.
+func (c *Config) authenticator() (api.Authenticator, diag.Diagnostics) {
+	switch c.AuthMethod {
+	case "", api.AuthMethodBasic:
+		return api.BasicAuthenticator{
+			Username: c.ClientCredentials.Username,
+			Password: c.ClientCredentials.Password,
+		}, nil
+	case api.AuthMethodToken:
+		if c.ClientToken == "" {
+			return nil, diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "Missing personal access token",
+				Detail: "The client_auth_method \"token\" requires client_token " +
+					"(or the environment variable FOREMAN_CLIENT_TOKEN) to be set.",
+			}}
+		}
+		return api.TokenAuthenticator{Token: c.ClientToken}, nil
+	case api.AuthMethodOAuth:
+		if c.OAuthConsumerKey == "" || c.OAuthConsumerSecret == "" {
+			return nil, diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "Missing OAuth consumer key or secret",
+				Detail: "The client_auth_method \"oauth\" requires " +
+					"client_oauth_consumer_key and client_oauth_consumer_secret (or " +
+					"the environment variables FOREMAN_CLIENT_OAUTH_CONSUMER_KEY and " +
+					"FOREMAN_CLIENT_OAUTH_CONSUMER_SECRET) to be set.",
+			}}
+		}
+		return api.OAuthAuthenticator{
+			ConsumerKey:    c.OAuthConsumerKey,
+			ConsumerSecret: c.OAuthConsumerSecret,
+			User:           c.OAuthUser,
+		}, nil
+	}
+	return nil, diag.Errorf("Unknown client_auth_method [%s]", c.AuthMethod)
+}
+
+// tlsRootCAs returns the system's certificate pool extended by the configured
+// certificate authorities.  Returns nil if no certificate authorities are
+// configured, so the client falls back to the system's pool.  Returns an
+// error diagnostic if the bundle cannot be read or contains no certificates.
+func (c *Config) tlsRootCAs() (*x509.CertPool, diag.Diagnostics) {
+	if c.ClientTLSCAFile == "" && c.ClientTLSCAPEM == "" {
+		return nil, nil
+	}
+
+	pool, poolErr := x509.SystemCertPool()
+	if poolErr != nil {
+		log.Debugf("System certificate pool unavailable: [%s]", poolErr)
+		pool = x509.NewCertPool()
+	}
+
+	if c.ClientTLSCAFile != "" {
+		pem, readErr := os.ReadFile(c.ClientTLSCAFile)
+		if readErr != nil {
+			return nil, diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "Unable to read the CA bundle",
+				Detail: fmt.Sprintf(
+					"Failed to read client_tls_ca_file [%s]: %s",
+					c.ClientTLSCAFile,
+					readErr,
+				),
+			}}
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  "Invalid CA bundle",
+				Detail: fmt.Sprintf(
+					"client_tls_ca_file [%s] does not contain any PEM encoded certificate.",
+					c.ClientTLSCAFile,
+				),
+			}}
+		}
+	}
+	if c.ClientTLSCAPEM != "" && !pool.AppendCertsFromPEM([]byte(c.ClientTLSCAPEM)) {
+		return nil, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Invalid CA bundle",
+			Detail:   "client_tls_ca_pem does not contain any PEM encoded certificate.",
+		}}
+	}
+	return pool, nil
+}
+
+// tlsClientCertificates returns the client certificate for mutual TLS
+// authentication.  Returns an error diagnostic if only one of the certificate
+// and key is configured or they cannot be loaded.
+func (c *Config) tlsClientCertificates() ([]tls.Certificate, diag.Diagnostics) {
+	if c.ClientTLSCertFile == "" && c.ClientTLSKeyFile == "" {
+		return nil, nil
+	}
+	if c.ClientTLSCertFile == "" || c.ClientTLSKeyFile == "" {
+		return nil, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Incomplete client certificate",
+			Detail: "Mutual TLS authentication requires both client_tls_cert_file " +
+				"and client_tls_key_file to be set.",
+		}}
+	}
+
+	cert, certErr := tls.LoadX509KeyPair(c.ClientTLSCertFile, c.ClientTLSKeyFile)
+	if certErr != nil {
+		return nil, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Unable to load the client certificate",
+			Detail: fmt.Sprintf(
+				"Failed to load client_tls_cert_file [%s] and client_tls_key_file [%s]: %s",
+				c.ClientTLSCertFile,
+				c.ClientTLSKeyFile,
+				certErr,
+			),
+		}}
+	}
+	return []tls.Certificate{cert}, nil
+}
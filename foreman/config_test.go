@@ -0,0 +1,47 @@
+package foreman
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// Ensures the client is not created if the configured TLS files cannot be
+// loaded
+func TestConfigClient_TLSFilesError(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.pem")
+
+	testCases := []struct {
+		config  Config
+		summary string
+	}{
+		{
+			config:  Config{ClientTLSCAFile: missing},
+			summary: "Unable to read the CA bundle",
+		},
+		{
+			config:  Config{ClientTLSCAPEM: "not a certificate"},
+			summary: "Invalid CA bundle",
+		},
+		{
+			config:  Config{ClientTLSCertFile: missing},
+			summary: "Incomplete client certificate",
+		},
+		{
+			config:  Config{ClientTLSCertFile: missing, ClientTLSKeyFile: missing},
+			summary: "Unable to load the client certificate",
+		},
+	}
+
+	for _, testCase := range testCases {
+		client, diags := testCase.config.Client()
+		if client != nil || !diags.HasError() || diags[0].Summary != testCase.summary {
+			t.Fatalf(
+				"Config.Client did not fail for the TLS configuration [%+v]. "+
+					"Expected [%s], got [%+v].",
+				testCase.config,
+				testCase.summary,
+				diags,
+			)
+		}
+	}
+}
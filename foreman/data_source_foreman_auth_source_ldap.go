@@ -0,0 +1,88 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/helper"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanAuthSourceLDAP() *schema.Resource {
+	// copy attributes from resource definition
+	r := resourceForemanAuthSourceLDAP()
+	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds["location_ids"] = dataSourceLocationIdsSchema("authentication source")
+	ds["organization_ids"] = dataSourceOrganizationIdsSchema("authentication source")
+
+	// the password is never returned by the API and the connection test is
+	// an action of the resource
+	delete(ds, "account_password")
+	delete(ds, "test_connection")
+
+	// define searchable attributes for the data source
+	ds["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		Description: fmt.Sprintf(
+			"The name of the LDAP authentication source. "+
+				"%s \"corp-ldap\"",
+			autodoc.MetaExample,
+		),
+	}
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanAuthSourceLDAPRead,
+
+		// NOTE(ALL): See comments in the corresponding resource file
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanAuthSourceLDAPRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_auth_source_ldap.go#Read")
+
+	client := meta.(*api.Client)
+	a := api.ForemanAuthSourceLDAP{}
+	a.Name = d.Get("name").(string)
+
+	queryResponse, queryErr := client.QueryAuthSourceLDAP(ctx, &a)
+	if queryErr != nil {
+		return diag.FromErr(queryErr)
+	}
+
+	if queryResponse.Subtotal == 0 {
+		return diag.Errorf("Data source auth source LDAP returned no results")
+	} else if queryResponse.Subtotal > 1 {
+		return diag.Errorf("Data source auth source LDAP returned more than 1 result")
+	}
+
+	var queryAuthSourceLDAP api.ForemanAuthSourceLDAP
+	var ok bool
+	if queryAuthSourceLDAP, ok = queryResponse.Results[0].(api.ForemanAuthSourceLDAP); !ok {
+		return diag.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanAuthSourceLDAP], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+
+	// NOTE(ALL): The search results do not include the assigned taxonomies -
+	//   read the authentication source to get their IDs
+	readAuthSourceLDAP, readErr := client.ReadAuthSourceLDAP(ctx, queryAuthSourceLDAP.Id)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+
+	log.Debugf("ForemanAuthSourceLDAP: [%+v]", readAuthSourceLDAP)
+
+	setResourceDataFromForemanAuthSourceLDAP(d, readAuthSourceLDAP)
+
+	return nil
+}
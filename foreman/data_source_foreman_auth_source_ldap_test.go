@@ -0,0 +1,133 @@
+package foreman
+
+import (
+	"net/http"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func DataSourceForemanAuthSourceLDAPCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := RandForemanAuthSourceLDAP()
+	s := ForemanAuthSourceLDAPToInstanceState(obj)
+
+	return []TestCaseCorrectURLAndMethod{
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanAuthSourceLDAPRead",
+				crudFunc:     dataSourceForemanAuthSourceLDAPRead,
+				resourceData: MockForemanAuthSourceLDAPResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    AuthSourceLDAPsURI,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func DataSourceForemanAuthSourceLDAPRequestDataEmptyTestCases(t *testing.T) []TestCase {
+	obj := RandForemanAuthSourceLDAP()
+	s := ForemanAuthSourceLDAPToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "dataSourceForemanAuthSourceLDAPRead",
+			crudFunc:     dataSourceForemanAuthSourceLDAPRead,
+			resourceData: MockForemanAuthSourceLDAPResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func DataSourceForemanAuthSourceLDAPStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanAuthSourceLDAP()
+	s := ForemanAuthSourceLDAPToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "dataSourceForemanAuthSourceLDAPRead",
+			crudFunc:     dataSourceForemanAuthSourceLDAPRead,
+			resourceData: MockForemanAuthSourceLDAPResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func DataSourceForemanAuthSourceLDAPEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanAuthSourceLDAP()
+	s := ForemanAuthSourceLDAPToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "dataSourceForemanAuthSourceLDAPRead",
+			crudFunc:     dataSourceForemanAuthSourceLDAPRead,
+			resourceData: MockForemanAuthSourceLDAPResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func DataSourceForemanAuthSourceLDAPMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanAuthSourceLDAP()
+	s := ForemanAuthSourceLDAPToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with more than one search result for the data
+		// source read, then the operation should return an error
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanAuthSourceLDAPRead",
+				crudFunc:     dataSourceForemanAuthSourceLDAPRead,
+				resourceData: MockForemanAuthSourceLDAPResourceData(s),
+			},
+			responseFile: AuthSourceLDAPsTestDataPath + "/query_response_multi.json",
+			returnError:  true,
+		},
+		// If the server responds with zero search results for the data source
+		// read, then the operation should return an error
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanAuthSourceLDAPRead",
+				crudFunc:     dataSourceForemanAuthSourceLDAPRead,
+				resourceData: MockForemanAuthSourceLDAPResourceData(s),
+			},
+			responseFile: TestDataPath + "/query_response_zero.json",
+			returnError:  true,
+		},
+		// If the server responds with exactly one search result for the data source
+		// read, then the operation should succeed and the attributes of the
+		// ResourceData should be set properly.
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanAuthSourceLDAPRead",
+				crudFunc:     dataSourceForemanAuthSourceLDAPRead,
+				resourceData: MockForemanAuthSourceLDAPResourceData(s),
+			},
+			responseFile:     AuthSourceLDAPsTestDataPath + "/query_response_single.json",
+			readURI:          AuthSourceLDAPsURI + "/3",
+			readResponseFile: AuthSourceLDAPsTestDataPath + "/query_response_single_state.json",
+			returnError:      false,
+			expectedResourceData: MockForemanAuthSourceLDAPResourceDataFromFile(
+				t,
+				AuthSourceLDAPsTestDataPath+"/query_response_single_state.json",
+			),
+			compareFunc: ForemanAuthSourceLDAPResourceDataCompare,
+		},
+	}
+
+}
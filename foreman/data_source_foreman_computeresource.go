@@ -17,6 +17,8 @@ func dataSourceForemanComputeResource() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanComputeResource()
 	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds["location_ids"] = dataSourceLocationIdsSchema("compute resource")
+	ds["organization_ids"] = dataSourceOrganizationIdsSchema("compute resource")
 
 	// define searchable attributes for the data source
 	ds["name"] = &schema.Schema{
@@ -62,7 +64,13 @@ func dataSourceForemanComputeResourceRead(ctx context.Context, d *schema.Resourc
 			queryResponse.Results[0],
 		)
 	}
-	computeresource = &queryComputeResource
+	// NOTE(ALL): The search results do not include the assigned taxonomies -
+	//   read the compute resource to get their IDs
+	readComputeResource, readErr := client.ReadComputeResource(ctx, queryComputeResource.Id)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+	computeresource = readComputeResource
 
 	log.Debugf("ForemanComputeResource: [%+v]", computeresource)
 
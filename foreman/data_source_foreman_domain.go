@@ -17,6 +17,8 @@ func dataSourceForemanDomain() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanDomain()
 	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds["location_ids"] = dataSourceLocationIdsSchema("domain")
+	ds["organization_ids"] = dataSourceOrganizationIdsSchema("domain")
 
 	// define searchable attributes for the data source
 	ds["name"] = &schema.Schema{
@@ -66,7 +68,14 @@ func dataSourceForemanDomainRead(ctx context.Context, d *schema.ResourceData, me
 			queryResponse.Results[0],
 		)
 	}
-	domain = &queryDomain
+
+	// NOTE(ALL): The search results do not include the subnets and taxonomies
+	//   of the domain - read the domain to get their IDs
+	readDomain, readErr := client.ReadDomain(ctx, queryDomain.Id)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+	domain = readDomain
 
 	log.Debugf("ForemanDomain: [%+v]", domain)
 
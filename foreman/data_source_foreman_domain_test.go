@@ -1,8 +1,14 @@
 package foreman
 
 import (
+	"context"
 	"net/http"
+	"os"
 	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // ----------------------------------------------------------------------------
@@ -118,8 +124,10 @@ func DataSourceForemanDomainMockResponseTestCases(t *testing.T) []TestCaseMockRe
 				crudFunc:     dataSourceForemanDomainRead,
 				resourceData: MockForemanDomainResourceData(s),
 			},
-			responseFile: DomainsTestDataPath + "/query_response_single.json",
-			returnError:  false,
+			responseFile:     DomainsTestDataPath + "/query_response_single.json",
+			readURI:          DomainsURI + "/35",
+			readResponseFile: DomainsTestDataPath + "/query_response_single_state.json",
+			returnError:      false,
 			expectedResourceData: MockForemanDomainResourceDataFromFile(
 				t,
 				DomainsTestDataPath+"/query_response_single_state.json",
@@ -129,3 +137,49 @@ func DataSourceForemanDomainMockResponseTestCases(t *testing.T) []TestCaseMockRe
 	}
 
 }
+
+// -----------------------------------------------------------------------------
+// Read
+// -----------------------------------------------------------------------------
+
+// Ensures the data source reads the domain found by its search to set the
+// subnets and taxonomies, which the search results do not include
+func TestDataSourceForemanDomainRead_SubnetsAndTaxonomies(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	responseFiles := map[string]string{
+		DomainsURI:         DomainsTestDataPath + "/query_response_single.json",
+		DomainsURI + "/35": DomainsTestDataPath + "/query_response_single_state.json",
+	}
+	for uri, responseFile := range responseFiles {
+		responseFile := responseFile
+		mux.HandleFunc(uri, func(w http.ResponseWriter, r *http.Request) {
+			bytes, readErr := os.ReadFile(responseFile)
+			if readErr != nil {
+				t.Fatalf("Could not read the test data [%s]: %s", responseFile, readErr)
+			}
+			w.Write(bytes)
+		})
+	}
+
+	obj := RandForemanDomain()
+	resourceData := MockForemanDomainResourceData(ForemanDomainToInstanceState(obj))
+	if diags := dataSourceForemanDomainRead(context.TODO(), resourceData, client); diags.HasError() {
+		t.Fatalf("dataSourceForemanDomainRead returned an error: [%+v]", diags)
+	}
+
+	expected := map[string]int{"subnet_ids": 303, "location_ids": 2, "organization_ids": 1}
+	for key, id := range expected {
+		ids := resourceData.Get(key).(*schema.Set)
+		if ids.Len() != 1 || !ids.Contains(id) {
+			t.Errorf(
+				"dataSourceForemanDomainRead did not set [%s]. Expected [[%d]], "+
+					"got [%v].",
+				key,
+				id,
+				ids.List(),
+			)
+		}
+	}
+}
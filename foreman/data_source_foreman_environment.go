@@ -17,6 +17,8 @@ func dataSourceForemanEnvironment() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanEnvironment()
 	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds["location_ids"] = dataSourceLocationIdsSchema("environment")
+	ds["organization_ids"] = dataSourceOrganizationIdsSchema("environment")
 
 	// define searchable attributes for the data source
 	ds["name"] = &schema.Schema{
@@ -66,7 +68,13 @@ func dataSourceForemanEnvironmentRead(ctx context.Context, d *schema.ResourceDat
 			queryResponse.Results[0],
 		)
 	}
-	e = &queryEnvironment
+	// NOTE(ALL): The search results do not include the assigned taxonomies -
+	//   read the environment to get their IDs
+	readEnvironment, readErr := client.ReadEnvironment(ctx, queryEnvironment.Id)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+	e = readEnvironment
 
 	log.Debugf("ForemanEnvironment: [%+v]", e)
 
@@ -118,8 +118,10 @@ func DataSourceForemanEnvironmentMockResponseTestCases(t *testing.T) []TestCaseM
 				crudFunc:     dataSourceForemanEnvironmentRead,
 				resourceData: MockForemanEnvironmentResourceData(s),
 			},
-			responseFile: EnvironmentsTestDataPath + "/query_response_single.json",
-			returnError:  false,
+			responseFile:     EnvironmentsTestDataPath + "/query_response_single.json",
+			readURI:          EnvironmentsURI + "/6196",
+			readResponseFile: EnvironmentsTestDataPath + "/query_response_single_state.json",
+			returnError:      false,
 			expectedResourceData: MockForemanEnvironmentResourceDataFromFile(
 				t,
 				EnvironmentsTestDataPath+"/query_response_single_state.json",
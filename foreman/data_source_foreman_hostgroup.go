@@ -17,6 +17,8 @@ func dataSourceForemanHostgroup() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanHostgroup()
 	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds["location_ids"] = dataSourceLocationIdsSchema("hostgroup")
+	ds["organization_ids"] = dataSourceOrganizationIdsSchema("hostgroup")
 
 	// define searchable attributes for the data source
 
@@ -70,7 +72,13 @@ func dataSourceForemanHostgroupRead(ctx context.Context, d *schema.ResourceData,
 			queryResponse.Results[0],
 		)
 	}
-	h = &queryHostgroup
+	// NOTE(ALL): The search results do not include the assigned taxonomies -
+	//   read the hostgroup to get their IDs
+	readHostgroup, readErr := client.ReadHostgroup(ctx, queryHostgroup.Id)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+	h = readHostgroup
 
 	log.Debugf("ForemanHostgroup: [%+v]", h)
 
@@ -1,8 +1,12 @@
 package foreman
 
 import (
+	"context"
 	"net/http"
+	"reflect"
 	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
 )
 
 // ----------------------------------------------------------------------------
@@ -118,8 +122,10 @@ func DataSourceForemanHostgroupMockResponseTestCases(t *testing.T) []TestCaseMoc
 				crudFunc:     dataSourceForemanHostgroupRead,
 				resourceData: MockForemanHostgroupResourceData(s),
 			},
-			responseFile: HostgroupsTestDataPath + "/query_response_single.json",
-			returnError:  false,
+			responseFile:     HostgroupsTestDataPath + "/query_response_single.json",
+			readURI:          HostgroupsURI + "/166",
+			readResponseFile: HostgroupsTestDataPath + "/query_response_single_state.json",
+			returnError:      false,
 			expectedResourceData: MockForemanHostgroupResourceDataFromFile(
 				t,
 				HostgroupsTestDataPath+"/query_response_single_state.json",
@@ -129,3 +135,70 @@ func DataSourceForemanHostgroupMockResponseTestCases(t *testing.T) []TestCaseMoc
 	}
 
 }
+
+// -----------------------------------------------------------------------------
+// Read
+// -----------------------------------------------------------------------------
+
+// Ensures the search of the data source merges the results of every page, so
+// that a second match on a later page is not silently ignored
+func TestDataSourceForemanHostgroupRead_MultiplePages(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	pages := map[string]string{
+		"1": `{"id": 1, "name": "web", "title": "web"}`,
+		"2": `{"id": 2, "name": "web", "title": "web"}`,
+	}
+	requestedPages := []string{}
+	mux.HandleFunc(HostgroupsURI, func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		requestedPages = append(requestedPages, page)
+		w.Write([]byte(`{
+			"total": 2, "subtotal": 2, "page": ` + page + `, "per_page": 1,
+			"results": [` + pages[page] + `]
+		}`))
+	})
+
+	queryResponse, queryErr := client.QueryHostgroup(context.TODO(), &api.ForemanHostgroup{Title: "web"})
+	if queryErr != nil {
+		t.Fatalf("QueryHostgroup returned an error: [%s]", queryErr)
+	}
+	if !reflect.DeepEqual(requestedPages, []string{"1", "2"}) {
+		t.Fatalf(
+			"QueryHostgroup did not request every page. Expected [[1 2]], got [%v].",
+			requestedPages,
+		)
+	}
+	ids := []int{}
+	for _, result := range queryResponse.Results {
+		ids = append(ids, result.(api.ForemanHostgroup).Id)
+	}
+	if !reflect.DeepEqual(ids, []int{1, 2}) || queryResponse.Subtotal != 2 {
+		t.Fatalf(
+			"QueryHostgroup did not merge the results of both pages. Expected "+
+				"the IDs [[1 2]], got [%v] with subtotal [%d].",
+			ids,
+			queryResponse.Subtotal,
+		)
+	}
+
+	requestedPages = []string{}
+	resourceData := MockForemanHostgroupResourceData(ForemanHostgroupToInstanceState(RandForemanHostgroup()))
+	if diags := dataSourceForemanHostgroupRead(context.TODO(), resourceData, client); !diags.HasError() {
+		t.Fatalf(
+			"dataSourceForemanHostgroupRead did not return an error for the " +
+				"hostgroups matching on both pages",
+		)
+	}
+	if !reflect.DeepEqual(requestedPages, []string{"1", "2"}) {
+		t.Fatalf(
+			"dataSourceForemanHostgroupRead did not request every page. "+
+				"Expected [[1 2]], got [%v].",
+			requestedPages,
+		)
+	}
+}
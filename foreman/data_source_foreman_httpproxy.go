@@ -17,6 +17,14 @@ func dataSourceForemanHTTPProxy() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanHTTPProxy()
 	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds["location_ids"] = dataSourceLocationIdsSchema("http proxy")
+	ds["organization_ids"] = dataSourceOrganizationIdsSchema("http proxy")
+
+	// the password is not returned by the API and testing the connection is
+	// an action of the resource
+	delete(ds, "password")
+	delete(ds, "test_connection")
+	delete(ds, "test_url")
 
 	// define searchable attributes for the data source
 	ds["name"] = &schema.Schema{
@@ -42,9 +50,8 @@ func dataSourceForemanHTTPProxyRead(ctx context.Context, d *schema.ResourceData,
 	log.Tracef("data_source_foreman_smartproxy.go#Read")
 
 	client := meta.(*api.Client)
-	s := buildForemanHTTPProxy(d)
-
-	log.Debugf("ForemanHTTPProxy: [%+v]", s)
+	s := &api.ForemanHTTPProxy{}
+	s.Name = d.Get("name").(string)
 
 	queryResponse, queryErr := client.QueryHTTPProxy(ctx, s)
 	if queryErr != nil {
@@ -66,7 +73,13 @@ func dataSourceForemanHTTPProxyRead(ctx context.Context, d *schema.ResourceData,
 			queryResponse.Results[0],
 		)
 	}
-	s = &queryHTTPProxy
+	// NOTE(ALL): The search results do not include the assigned taxonomies -
+	//   read the HTTP proxy to get their IDs
+	readHTTPProxy, readErr := client.ReadHTTPProxy(ctx, queryHTTPProxy.Id)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+	s = readHTTPProxy
 
 	log.Debugf("ForemanHTTPProxy: [%+v]", s)
 
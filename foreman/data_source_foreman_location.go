@@ -0,0 +1,93 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/helper"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanLocation() *schema.Resource {
+	// copy attributes from resource definition
+	r := resourceForemanLocation()
+	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+
+	// define searchable attributes for the data source
+	ds["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		Description: fmt.Sprintf(
+			"The name of the location. "+
+				"%s \"DC1\"",
+			autodoc.MetaExample,
+		),
+	}
+	ds["title"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		Description: fmt.Sprintf(
+			"The name of the location including the names of its parents. "+
+				"Nested locations may share their name - set the title to tell "+
+				"them apart. %s \"Europe/DC1\"",
+			autodoc.MetaExample,
+		),
+	}
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanLocationRead,
+
+		// NOTE(ALL): See comments in the corresponding resource file
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanLocationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_location.go#Read")
+
+	client := meta.(*api.Client)
+	l := buildForemanLocation(d)
+
+	log.Debugf("ForemanLocation: [%+v]", l)
+
+	queryResponse, queryErr := client.QueryLocation(ctx, l)
+	if queryErr != nil {
+		return diag.FromErr(queryErr)
+	}
+
+	if queryResponse.Subtotal == 0 {
+		return diag.Errorf("Data source location returned no results")
+	} else if queryResponse.Subtotal > 1 {
+		return diag.Errorf("Data source location returned more than 1 result")
+	}
+
+	var queryLocation api.ForemanLocation
+	var ok bool
+	if queryLocation, ok = queryResponse.Results[0].(api.ForemanLocation); !ok {
+		return diag.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanLocation], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+
+	// NOTE(ALL): The search results do not include the assigned objects -
+	//   read the location to get their IDs
+	readLocation, readErr := client.ReadLocation(ctx, queryLocation.Id)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+
+	log.Debugf("ForemanLocation: [%+v]", readLocation)
+
+	setResourceDataFromForemanLocation(d, readLocation)
+
+	return nil
+}
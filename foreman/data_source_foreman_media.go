@@ -17,6 +17,8 @@ func dataSourceForemanMedia() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanMedia()
 	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds["location_ids"] = dataSourceLocationIdsSchema("media")
+	ds["organization_ids"] = dataSourceOrganizationIdsSchema("media")
 
 	// define searchable attributes for the data source
 	ds["name"] = &schema.Schema{
@@ -66,7 +68,13 @@ func dataSourceForemanMediaRead(ctx context.Context, d *schema.ResourceData, met
 			queryResponse.Results[0],
 		)
 	}
-	m = &queryMedia
+	// NOTE(ALL): The search results do not include the assigned taxonomies -
+	//   read the media to get their IDs
+	readMedia, readErr := client.ReadMedia(ctx, queryMedia.Id)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+	m = readMedia
 
 	log.Debugf("ForemanMedia: [%+v]", m)
 
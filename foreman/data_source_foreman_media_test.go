@@ -118,8 +118,10 @@ func DataSourceForemanMediaMockResponseTestCases(t *testing.T) []TestCaseMockRes
 				crudFunc:     dataSourceForemanMediaRead,
 				resourceData: MockForemanMediaResourceData(s),
 			},
-			responseFile: MediasTestDataPath + "/query_response_single.json",
-			returnError:  false,
+			responseFile:     MediasTestDataPath + "/query_response_single.json",
+			readURI:          MediasURI + "/37",
+			readResponseFile: MediasTestDataPath + "/query_response_single_state.json",
+			returnError:      false,
 			expectedResourceData: MockForemanMediaResourceDataFromFile(
 				t,
 				MediasTestDataPath+"/query_response_single_state.json",
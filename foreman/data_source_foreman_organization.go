@@ -0,0 +1,93 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/helper"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanOrganization() *schema.Resource {
+	// copy attributes from resource definition
+	r := resourceForemanOrganization()
+	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+
+	// define searchable attributes for the data source
+	ds["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		Description: fmt.Sprintf(
+			"The name of the organization. "+
+				"%s \"Tenant A\"",
+			autodoc.MetaExample,
+		),
+	}
+	ds["title"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		Description: fmt.Sprintf(
+			"The name of the organization including the names of its parents. "+
+				"Nested organizations may share their name - set the title to tell "+
+				"them apart. %s \"Parent Org/Tenant A\"",
+			autodoc.MetaExample,
+		),
+	}
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanOrganizationRead,
+
+		// NOTE(ALL): See comments in the corresponding resource file
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanOrganizationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_organization.go#Read")
+
+	client := meta.(*api.Client)
+	o := buildForemanOrganization(d)
+
+	log.Debugf("ForemanOrganization: [%+v]", o)
+
+	queryResponse, queryErr := client.QueryOrganization(ctx, o)
+	if queryErr != nil {
+		return diag.FromErr(queryErr)
+	}
+
+	if queryResponse.Subtotal == 0 {
+		return diag.Errorf("Data source organization returned no results")
+	} else if queryResponse.Subtotal > 1 {
+		return diag.Errorf("Data source organization returned more than 1 result")
+	}
+
+	var queryOrganization api.ForemanOrganization
+	var ok bool
+	if queryOrganization, ok = queryResponse.Results[0].(api.ForemanOrganization); !ok {
+		return diag.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanOrganization], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+
+	// NOTE(ALL): The search results do not include the assigned objects -
+	//   read the organization to get their IDs
+	readOrganization, readErr := client.ReadOrganization(ctx, queryOrganization.Id)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+
+	log.Debugf("ForemanOrganization: [%+v]", readOrganization)
+
+	setResourceDataFromForemanOrganization(d, readOrganization)
+
+	return nil
+}
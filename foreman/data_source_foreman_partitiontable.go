@@ -17,6 +17,8 @@ func dataSourceForemanPartitionTable() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanPartitionTable()
 	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds["location_ids"] = dataSourceLocationIdsSchema("partition table")
+	ds["organization_ids"] = dataSourceOrganizationIdsSchema("partition table")
 
 	// define searchable attributes for the data source
 	ds["name"] = &schema.Schema{
@@ -66,7 +68,13 @@ func dataSourceForemanPartitionTableRead(ctx context.Context, d *schema.Resource
 			queryResponse.Results[0],
 		)
 	}
-	t = &queryPartitionTable
+	// NOTE(ALL): The search results do not include the assigned taxonomies -
+	//   read the partition table to get their IDs
+	readPartitionTable, readErr := client.ReadPartitionTable(ctx, queryPartitionTable.Id)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+	t = readPartitionTable
 
 	log.Debugf("[DEBUG] ForemanPartitionTable: [%+v]", t)
 
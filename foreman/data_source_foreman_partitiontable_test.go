@@ -149,6 +149,8 @@ func DataSourceForemanPartitionTableMockResponseTestCases(t *testing.T) []TestCa
 				resourceData: MockForemanPartitionTableResourceData(s),
 			},
 			responseFile:         PartitionTablesTestDataPath + "/query_response_single.json",
+			readURI:              PartitionTablesURI + "/171",
+			readResponseFile:     PartitionTablesTestDataPath + "/query_response_single_state.json",
 			returnError:          false,
 			expectedResourceData: expectedData,
 			compareFunc:          ForemanPartitionTableResourceDataCompare,
@@ -0,0 +1,116 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanPermission() *schema.Resource {
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanPermissionRead,
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Resolves the name of a permission to its ID and the type of "+
+						"the resources it applies to.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Name of the permission. "+
+						"%s \"view_hosts\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"resource_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Type of the resources the permission applies to, ie: " +
+					"`Host`. Empty for permissions which do not apply to a resource.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanPermission constructs a ForemanPermission reference from a
+// resource data reference.  The struct's  members are populated from the data
+// populated in the resource data.  Missing members will be left to the zero
+// value for that member's type.
+func buildForemanPermission(d *schema.ResourceData) *api.ForemanPermission {
+	p := api.ForemanPermission{}
+	obj := buildForemanObject(d)
+	p.ForemanObject = *obj
+	p.ResourceType = d.Get("resource_type").(string)
+	return &p
+}
+
+// setResourceDataFromForemanPermission sets a ResourceData's attributes from
+// the attributes of the supplied ForemanPermission reference
+func setResourceDataFromForemanPermission(d *schema.ResourceData, fp *api.ForemanPermission) {
+	d.SetId(strconv.Itoa(fp.Id))
+	d.Set("name", fp.Name)
+	d.Set("resource_type", fp.ResourceType)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func dataSourceForemanPermissionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_permission.go#Read")
+
+	client := meta.(*api.Client)
+	p := buildForemanPermission(d)
+
+	log.Debugf("ForemanPermission: [%+v]", p)
+
+	queryResponse, queryErr := client.QueryPermission(ctx, p)
+	if queryErr != nil {
+		return diag.FromErr(queryErr)
+	}
+
+	if queryResponse.Subtotal == 0 {
+		return diag.Errorf("Data source permission returned no results")
+	} else if queryResponse.Subtotal > 1 {
+		return diag.Errorf("Data source permission returned more than 1 result")
+	}
+
+	var queryPermission api.ForemanPermission
+	var ok bool
+	if queryPermission, ok = queryResponse.Results[0].(api.ForemanPermission); !ok {
+		return diag.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanPermission], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+	p = &queryPermission
+
+	log.Debugf("ForemanPermission: [%+v]", p)
+
+	setResourceDataFromForemanPermission(d, p)
+
+	return nil
+}
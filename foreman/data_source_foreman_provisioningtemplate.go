@@ -17,6 +17,8 @@ func dataSourceForemanProvisioningTemplate() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanProvisioningTemplate()
 	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds["location_ids"] = dataSourceLocationIdsSchema("provisioning template")
+	ds["organization_ids"] = dataSourceOrganizationIdsSchema("provisioning template")
 
 	// define searchable attributes for the data source
 	ds["name"] = &schema.Schema{
@@ -66,7 +68,13 @@ func dataSourceForemanProvisioningTemplateRead(ctx context.Context, d *schema.Re
 			queryResponse.Results[0],
 		)
 	}
-	t = &queryTemplate
+	// NOTE(ALL): The search results do not include the assigned taxonomies -
+	//   read the provisioning template to get their IDs
+	readTemplate, readErr := client.ReadProvisioningTemplate(ctx, queryTemplate.Id)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+	t = readTemplate
 
 	log.Debugf("ForemanProvisioningTemplate: [%+v]", t)
 
@@ -118,8 +118,10 @@ func DataSourceForemanProvisioningTemplateMockResponseTestCases(t *testing.T) []
 				crudFunc:     dataSourceForemanProvisioningTemplateRead,
 				resourceData: MockForemanProvisioningTemplateResourceData(s),
 			},
-			responseFile: ProvisioningTemplatesTestDataPath + "/query_response_single.json",
-			returnError:  false,
+			responseFile:     ProvisioningTemplatesTestDataPath + "/query_response_single.json",
+			readURI:          ProvisioningTemplatesURI + "/138",
+			readResponseFile: ProvisioningTemplatesTestDataPath + "/query_response_single_state.json",
+			returnError:      false,
 			expectedResourceData: MockForemanProvisioningTemplateResourceDataFromFile(
 				t,
 				ProvisioningTemplatesTestDataPath+"/query_response_single_state.json",
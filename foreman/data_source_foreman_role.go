@@ -0,0 +1,84 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/helper"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceForemanRole() *schema.Resource {
+	// copy attributes from resource definition
+	r := resourceForemanRole()
+	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds["location_ids"] = dataSourceLocationIdsSchema("role")
+	ds["organization_ids"] = dataSourceOrganizationIdsSchema("role")
+
+	// define searchable attributes for the data source
+	ds["name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+		Description: fmt.Sprintf(
+			"The name of the role, ie: of a builtin role to clone or to assign. "+
+				"%s \"Viewer\"",
+			autodoc.MetaExample,
+		),
+	}
+
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanRoleRead,
+
+		// NOTE(ALL): See comments in the corresponding resource file
+		Schema: ds,
+	}
+}
+
+func dataSourceForemanRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_role.go#Read")
+
+	client := meta.(*api.Client)
+	r := buildForemanRole(d)
+
+	log.Debugf("ForemanRole: [%+v]", r)
+
+	queryResponse, queryErr := client.QueryRole(ctx, r)
+	if queryErr != nil {
+		return diag.FromErr(queryErr)
+	}
+
+	if queryResponse.Subtotal == 0 {
+		return diag.Errorf("Data source role returned no results")
+	} else if queryResponse.Subtotal > 1 {
+		return diag.Errorf("Data source role returned more than 1 result")
+	}
+
+	var queryRole api.ForemanRole
+	var ok bool
+	if queryRole, ok = queryResponse.Results[0].(api.ForemanRole); !ok {
+		return diag.Errorf(
+			"Data source results contain unexpected type. Expected "+
+				"[api.ForemanRole], got [%T]",
+			queryResponse.Results[0],
+		)
+	}
+	// NOTE(ALL): The search results do not include the assigned taxonomies -
+	//   read the role to get their IDs
+	readRole, readErr := client.ReadRole(ctx, queryRole.Id)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+	r = readRole
+
+	log.Debugf("ForemanRole: [%+v]", r)
+
+	setResourceDataFromForemanRole(d, r)
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package foreman
+
+import (
+	"net/http"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func DataSourceForemanRoleCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := RandForemanRole()
+	s := ForemanRoleToInstanceState(obj)
+
+	return []TestCaseCorrectURLAndMethod{
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanRoleRead",
+				crudFunc:     dataSourceForemanRoleRead,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    RolesURI,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func DataSourceForemanRoleRequestDataEmptyTestCases(t *testing.T) []TestCase {
+	obj := RandForemanRole()
+	s := ForemanRoleToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "dataSourceForemanRoleRead",
+			crudFunc:     dataSourceForemanRoleRead,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func DataSourceForemanRoleStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanRole()
+	s := ForemanRoleToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "dataSourceForemanRoleRead",
+			crudFunc:     dataSourceForemanRoleRead,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func DataSourceForemanRoleEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanRole()
+	s := ForemanRoleToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "dataSourceForemanRoleRead",
+			crudFunc:     dataSourceForemanRoleRead,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func DataSourceForemanRoleMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanRole()
+	s := ForemanRoleToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with more than one search result for the data
+		// source read, then the operation should return an error
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanRoleRead",
+				crudFunc:     dataSourceForemanRoleRead,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			responseFile: RolesTestDataPath + "/query_response_multi.json",
+			returnError:  true,
+		},
+		// If the server responds with zero search results for the data source
+		// read, then the operation should return an error
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanRoleRead",
+				crudFunc:     dataSourceForemanRoleRead,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			responseFile: TestDataPath + "/query_response_zero.json",
+			returnError:  true,
+		},
+		// If the server responds with exactly one search result for the data source
+		// read, then the operation should succeed and the attributes of the
+		// ResourceData should be set properly.
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanRoleRead",
+				crudFunc:     dataSourceForemanRoleRead,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			responseFile:     RolesTestDataPath + "/query_response_single.json",
+			readURI:          RolesURI + "/3",
+			readResponseFile: RolesTestDataPath + "/query_response_single_state.json",
+			returnError:      false,
+			expectedResourceData: MockForemanRoleResourceDataFromFile(
+				t,
+				RolesTestDataPath+"/query_response_single_state.json",
+			),
+			compareFunc: ForemanRoleResourceDataCompare,
+		},
+	}
+
+}
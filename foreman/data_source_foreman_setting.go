@@ -3,7 +3,6 @@ package foreman
 import (
 	"context"
 	"fmt"
-	"strconv"
 
 	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
 	"github.com/HanseMerkur/terraform-provider-utils/log"
@@ -14,8 +13,8 @@ import (
 )
 
 func dataSourceForemanSetting() *schema.Resource {
-	// Build schema from scratch, because Setting is a data source only.
-	// There is no resource schema we could re-use.
+	// Build schema from scratch - the resource schema requires a value and
+	// has no other inputs than the name.
 
 	dataSourceSchema := map[string]*schema.Schema{
 
@@ -38,8 +37,8 @@ func dataSourceForemanSetting() *schema.Resource {
 			),
 		},
 
-		// Value can be either string, int or bool.
-		// The API client converts int and bool to string..
+		// Value can be either string, int, bool, array or hash.
+		// Non-string values are converted to string, arrays and hashes to JSON.
 		"value": {
 			Type:     schema.TypeString,
 			Computed: true,
@@ -123,37 +122,16 @@ func dataSourceForemanSettingRead(ctx context.Context, d *schema.ResourceData, m
 	}
 	setting = &querySetting
 
-	// Convert boolean or integer values to strings to match the Terraform resource schema.
-	// Foreman uses "boolean", "integer", "array" and "hash", besides "string"/"text", as types in "settings_type".
-	// See https://github.com/theforeman/foreman/blob/0025f26123a22b84052292ed3ef749c91a563274/app/models/setting.rb#L111
-	switch setting.Value.(type) {
-	case bool:
-		setting.Value = strconv.FormatBool(setting.Value.(bool))
-	case int:
-		setting.Value = strconv.FormatInt(setting.Value.(int64), 10)
-	case string:
-	default:
-		// noop
-	}
-
-	// Same for the default value
-	switch setting.Default.(type) {
-	case bool:
-		setting.Default = strconv.FormatBool(setting.Default.(bool))
-	case int:
-		setting.Default = strconv.FormatInt(setting.Default.(int64), 10)
-	case string:
-	default:
-		// noop
-	}
-
 	log.Debugf("ForemanSetting: [%+v]", setting)
 
 	d.SetId(setting.Id)
 	d.Set("name", setting.Name)
-	d.Set("value", setting.Value)
+	// Convert the typed values to strings to match the Terraform schema.
+	// Foreman uses "boolean", "integer", "array" and "hash", besides "string"/"text", as types in "settings_type".
+	// See https://github.com/theforeman/foreman/blob/0025f26123a22b84052292ed3ef749c91a563274/app/models/setting.rb#L111
+	d.Set("value", settingValueToString(setting.Value))
 	d.Set("description", setting.Description)
-	d.Set("default", setting.Default)
+	d.Set("default", settingValueToString(setting.Default))
 	d.Set("category_name", setting.CategoryName)
 	d.Set("readonly", setting.ReadOnly)
 	d.Set("settings_type", setting.SettingsType)
@@ -30,7 +30,7 @@ func ForemanSettingToInstanceState(obj api.ForemanSetting) *terraform.InstanceSt
 	attr["name"] = obj.Name
 
 	if obj.Default != nil {
-		attr["default"] = obj.Default.(string)
+		attr["default"] = settingValueToString(obj.Default)
 	}
 
 	attr["description"] = obj.Description
@@ -40,11 +40,13 @@ func ForemanSettingToInstanceState(obj api.ForemanSetting) *terraform.InstanceSt
 	attr["full_name"] = obj.Fullname
 
 	if obj.Value != nil {
-		attr["value"] = obj.Value.(string)
+		attr["value"] = settingValueToString(obj.Value)
 	}
 
 	attr["category"] = obj.Category
 	attr["category_name"] = obj.CategoryName
+	attr["readonly"] = fmt.Sprintf("%t", obj.ReadOnly)
+	attr["encrypted"] = fmt.Sprintf("%t", obj.Encrypted)
 
 	state.Attributes = attr
 	return &state
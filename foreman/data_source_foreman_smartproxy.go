@@ -17,6 +17,11 @@ func dataSourceForemanSmartProxy() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanSmartProxy()
 	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds["location_ids"] = dataSourceLocationIdsSchema("smart proxy")
+	ds["organization_ids"] = dataSourceOrganizationIdsSchema("smart proxy")
+
+	// refreshing the features is an action of the resource
+	delete(ds, "refresh_on_change")
 
 	// define searchable attributes for the data source
 	ds["name"] = &schema.Schema{
@@ -66,7 +71,13 @@ func dataSourceForemanSmartProxyRead(ctx context.Context, d *schema.ResourceData
 			queryResponse.Results[0],
 		)
 	}
-	s = &querySmartProxy
+	// NOTE(ALL): The search results do not include the assigned taxonomies -
+	//   read the smart proxy to get their IDs
+	readSmartProxy, readErr := client.ReadSmartProxy(ctx, querySmartProxy.Id)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+	s = readSmartProxy
 
 	log.Debugf("ForemanSmartProxy: [%+v]", s)
 
@@ -0,0 +1,123 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Status Foreman reports for reachable smart proxies
+const smartProxyStatusOK = "ok"
+
+func dataSourceForemanSmartProxyStatus() *schema.Resource {
+	return &schema.Resource{
+
+		ReadContext: dataSourceForemanSmartProxyStatusRead,
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s The status of a smart proxy as reported by Foreman's status "+
+						"page. Use it in preconditions to make sure a proxy and its "+
+						"features work before depending on them.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"The name of the smart proxy. "+
+						"%s \"dns.dc1.company.com\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the smart proxy, `\"ok\"` if Foreman can reach it.",
+			},
+
+			"healthy": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the smart proxy is reachable and none of its features fail.",
+			},
+
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Version of the smart proxy.",
+			},
+
+			"features": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Versions of the working features by the name of the " +
+					"proxy module, ie: `{ dhcp = \"3.5.0\" }`.",
+			},
+
+			"failed_features": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Error messages of the failing features by the name of " +
+					"the proxy module.",
+			},
+		},
+	}
+}
+
+// setResourceDataFromForemanSmartProxyStatus sets a ResourceData's
+// attributes from the attributes of the supplied ForemanSmartProxyStatus
+// struct
+func setResourceDataFromForemanSmartProxyStatus(d *schema.ResourceData, fs *api.ForemanSmartProxyStatus) {
+	log.Tracef("data_source_foreman_smartproxy_status.go#setResourceDataFromForemanSmartProxyStatus")
+
+	d.SetId(fs.Name)
+	d.Set("name", fs.Name)
+	d.Set("status", fs.Status)
+	d.Set("healthy", fs.Status == smartProxyStatusOK && len(fs.FailedFeatures) == 0)
+	d.Set("version", fs.Version)
+	d.Set("features", fs.Features)
+	d.Set("failed_features", fs.FailedFeatures)
+}
+
+func dataSourceForemanSmartProxyStatusRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("data_source_foreman_smartproxy_status.go#Read")
+
+	client := meta.(*api.Client)
+	name := d.Get("name").(string)
+
+	statuses, readErr := client.ReadSmartProxyStatuses(ctx)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+
+	for _, status := range statuses {
+		if status.Name == name {
+			log.Debugf("ForemanSmartProxyStatus: [%+v]", status)
+
+			setResourceDataFromForemanSmartProxyStatus(d, &status)
+			return nil
+		}
+	}
+
+	return diag.Errorf("Data source smart proxy status returned no status for [%s]", name)
+}
@@ -0,0 +1,230 @@
+package foreman
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const StatusesURI = api.FOREMAN_API_URL_PREFIX + "/statuses"
+const StatusesTestDataPath = "testdata/1.11/statuses"
+
+// Given a ForemanSmartProxyStatus, create a mock instance state reference
+func ForemanSmartProxyStatusToInstanceState(obj api.ForemanSmartProxyStatus, healthy bool) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = obj.Name
+	// Build the attribute map from ForemanSmartProxyStatus
+	attr := map[string]string{}
+	attr["name"] = obj.Name
+	attr["status"] = obj.Status
+	attr["version"] = obj.Version
+	attr["healthy"] = strconv.FormatBool(healthy)
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanSmartProxyStatus data source,
+// create a mock ResourceData reference.
+func MockForemanSmartProxyStatusResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := dataSourceForemanSmartProxyStatus()
+	return r.Data(s)
+}
+
+// Compares two ResourceData references for a ForemanSmartProxyStatus data
+// source.  If the two references differ in their attributes, the test will
+// raise a fatal.
+func ForemanSmartProxyStatusResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := dataSourceForemanSmartProxyStatus()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+}
+
+// -----------------------------------------------------------------------------
+// setResourceDataFromForemanSmartProxyStatus
+// -----------------------------------------------------------------------------
+
+// Ensures a smart proxy is only healthy if it is reachable and none of its
+// features fail
+func TestSetResourceDataFromForemanSmartProxyStatus_Healthy(t *testing.T) {
+
+	testCases := []struct {
+		status          string
+		failedFeatures  map[string]string
+		expectedHealthy bool
+	}{
+		{status: "ok", failedFeatures: map[string]string{}, expectedHealthy: true},
+		{status: "ok", failedFeatures: map[string]string{"dns": "Unable to connect"}, expectedHealthy: false},
+		{status: "FAIL", failedFeatures: map[string]string{}, expectedHealthy: false},
+	}
+
+	for _, testCase := range testCases {
+		obj := api.ForemanSmartProxyStatus{
+			Name:           "smartproxy01.dev.dc1.company.com",
+			Status:         testCase.status,
+			FailedFeatures: testCase.failedFeatures,
+		}
+		resourceData := MockForemanSmartProxyStatusResourceData(&terraform.InstanceState{})
+
+		setResourceDataFromForemanSmartProxyStatus(resourceData, &obj)
+
+		if resourceData.Get("healthy").(bool) != testCase.expectedHealthy {
+			t.Errorf(
+				"setResourceDataFromForemanSmartProxyStatus with status [%s] and "+
+					"failed features [%v] set healthy [%t]. Expected [%t].",
+				testCase.status,
+				testCase.failedFeatures,
+				resourceData.Get("healthy"),
+				testCase.expectedHealthy,
+			)
+		}
+	}
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func DataSourceForemanSmartProxyStatusCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := api.ForemanSmartProxyStatus{Name: "smartproxy01.dev.dc1.company.com"}
+	s := ForemanSmartProxyStatusToInstanceState(obj, false)
+
+	return []TestCaseCorrectURLAndMethod{
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanSmartProxyStatusRead",
+				crudFunc:     dataSourceForemanSmartProxyStatusRead,
+				resourceData: MockForemanSmartProxyStatusResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    StatusesURI,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func DataSourceForemanSmartProxyStatusRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanSmartProxyStatus{Name: "smartproxy01.dev.dc1.company.com"}
+	s := ForemanSmartProxyStatusToInstanceState(obj, false)
+
+	return []TestCase{
+		{
+			funcName:     "dataSourceForemanSmartProxyStatusRead",
+			crudFunc:     dataSourceForemanSmartProxyStatusRead,
+			resourceData: MockForemanSmartProxyStatusResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func DataSourceForemanSmartProxyStatusStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanSmartProxyStatus{Name: "smartproxy01.dev.dc1.company.com"}
+	s := ForemanSmartProxyStatusToInstanceState(obj, false)
+
+	return []TestCase{
+		{
+			funcName:     "dataSourceForemanSmartProxyStatusRead",
+			crudFunc:     dataSourceForemanSmartProxyStatusRead,
+			resourceData: MockForemanSmartProxyStatusResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func DataSourceForemanSmartProxyStatusEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanSmartProxyStatus{Name: "smartproxy01.dev.dc1.company.com"}
+	s := ForemanSmartProxyStatusToInstanceState(obj, false)
+
+	return []TestCase{
+		{
+			funcName:     "dataSourceForemanSmartProxyStatusRead",
+			crudFunc:     dataSourceForemanSmartProxyStatusRead,
+			resourceData: MockForemanSmartProxyStatusResourceData(s),
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func DataSourceForemanSmartProxyStatusMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := api.ForemanSmartProxyStatus{Name: "smartproxy02.dev.dc1.company.com"}
+	s := ForemanSmartProxyStatusToInstanceState(obj, false)
+
+	expectedObj := api.ForemanSmartProxyStatus{
+		Name:    "smartproxy02.dev.dc1.company.com",
+		Status:  "ok",
+		Version: "3.9.1",
+	}
+
+	missingObj := api.ForemanSmartProxyStatus{Name: "smartproxy03.dev.dc1.company.com"}
+
+	return []TestCaseMockResponse{
+		// If the status page does not contain the smart proxy, then the
+		// operation should return an error
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanSmartProxyStatusRead",
+				crudFunc:     dataSourceForemanSmartProxyStatusRead,
+				resourceData: MockForemanSmartProxyStatusResourceData(ForemanSmartProxyStatusToInstanceState(missingObj, false)),
+			},
+			responseFile: StatusesTestDataPath + "/read_response.json",
+			returnError:  true,
+		},
+		// If the status page contains the smart proxy, then the operation
+		// should succeed and the attributes of the ResourceData should be set
+		// properly.  The proxy is not healthy, one of its features fails.
+		{
+			TestCase: TestCase{
+				funcName:     "dataSourceForemanSmartProxyStatusRead",
+				crudFunc:     dataSourceForemanSmartProxyStatusRead,
+				resourceData: MockForemanSmartProxyStatusResourceData(s),
+			},
+			responseFile: StatusesTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanSmartProxyStatusResourceData(
+				ForemanSmartProxyStatusToInstanceState(expectedObj, false),
+			),
+			compareFunc: ForemanSmartProxyStatusResourceDataCompare,
+		},
+	}
+
+}
@@ -118,8 +118,10 @@ func DataSourceForemanSmartProxyMockResponseTestCases(t *testing.T) []TestCaseMo
 				crudFunc:     dataSourceForemanSmartProxyRead,
 				resourceData: MockForemanSmartProxyResourceData(s),
 			},
-			responseFile: SmartProxiesTestDataPath + "/query_response_single.json",
-			returnError:  false,
+			responseFile:     SmartProxiesTestDataPath + "/query_response_single.json",
+			readURI:          SmartProxiesURI + "/38",
+			readResponseFile: SmartProxiesTestDataPath + "/query_response_single_state.json",
+			returnError:      false,
 			expectedResourceData: MockForemanSmartProxyResourceDataFromFile(
 				t,
 				SmartProxiesTestDataPath+"/query_response_single_state.json",
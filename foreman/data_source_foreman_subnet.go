@@ -17,6 +17,8 @@ func dataSourceForemanSubnet() *schema.Resource {
 	// copy attributes from resource definition
 	r := resourceForemanSubnet()
 	ds := helper.DataSourceSchemaFromResourceSchema(r.Schema)
+	ds["location_ids"] = dataSourceLocationIdsSchema("subnet")
+	ds["organization_ids"] = dataSourceOrganizationIdsSchema("subnet")
 
 	// define searchable attributes for the data source
 	ds["network"] = &schema.Schema{
@@ -76,7 +78,15 @@ func dataSourceForemanSubnetRead(ctx context.Context, d *schema.ResourceData, me
 			queryResponse.Results[0],
 		)
 	}
-	s = &querySubnet
+
+	// NOTE(ALL): The search results do not include the parameters, remote
+	//   execution proxies and taxonomies of the subnet - read the subnet to
+	//   get them
+	readSubnet, readErr := client.ReadSubnet(ctx, querySubnet.Id)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+	s = readSubnet
 
 	log.Debugf("ForemanSubnet: [%+v]", s)
 
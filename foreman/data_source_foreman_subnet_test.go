@@ -1,8 +1,14 @@
 package foreman
 
 import (
+	"context"
 	"net/http"
+	"os"
 	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // ----------------------------------------------------------------------------
@@ -118,8 +124,10 @@ func DataSourceForemanSubnetMockResponseTestCases(t *testing.T) []TestCaseMockRe
 				crudFunc:     dataSourceForemanSubnetRead,
 				resourceData: MockForemanSubnetResourceData(s),
 			},
-			responseFile: SubnetsTestDataPath + "/query_response_single.json",
-			returnError:  false,
+			responseFile:     SubnetsTestDataPath + "/query_response_single.json",
+			readURI:          SubnetsURI + "/303",
+			readResponseFile: SubnetsTestDataPath + "/query_response_single_state.json",
+			returnError:      false,
 			expectedResourceData: MockForemanSubnetResourceDataFromFile(
 				t,
 				SubnetsTestDataPath+"/query_response_single_state.json",
@@ -129,3 +137,59 @@ func DataSourceForemanSubnetMockResponseTestCases(t *testing.T) []TestCaseMockRe
 	}
 
 }
+
+// -----------------------------------------------------------------------------
+// Read
+// -----------------------------------------------------------------------------
+
+// Ensures the data source reads the subnet found by its search to set the
+// parameters, remote execution proxies and taxonomies, which the search
+// results do not include
+func TestDataSourceForemanSubnetRead_ReadAttributes(t *testing.T) {
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	responseFiles := map[string]string{
+		SubnetsURI:          SubnetsTestDataPath + "/query_response_single.json",
+		SubnetsURI + "/303": SubnetsTestDataPath + "/query_response_single_state.json",
+	}
+	for uri, responseFile := range responseFiles {
+		responseFile := responseFile
+		mux.HandleFunc(uri, func(w http.ResponseWriter, r *http.Request) {
+			bytes, readErr := os.ReadFile(responseFile)
+			if readErr != nil {
+				t.Fatalf("Could not read the test data [%s]: %s", responseFile, readErr)
+			}
+			w.Write(bytes)
+		})
+	}
+
+	obj := RandForemanSubnet()
+	resourceData := MockForemanSubnetResourceData(ForemanSubnetToInstanceState(obj))
+	if diags := dataSourceForemanSubnetRead(context.TODO(), resourceData, client); diags.HasError() {
+		t.Fatalf("dataSourceForemanSubnetRead returned an error: [%+v]", diags)
+	}
+
+	expected := map[string]int{"remote_execution_proxy_ids": 38, "location_ids": 2, "organization_ids": 1}
+	for key, id := range expected {
+		ids := resourceData.Get(key).(*schema.Set)
+		if ids.Len() != 1 || !ids.Contains(id) {
+			t.Errorf(
+				"dataSourceForemanSubnetRead did not set [%s]. Expected [[%d]], "+
+					"got [%v].",
+				key,
+				id,
+				ids.List(),
+			)
+		}
+	}
+
+	parameters := resourceData.Get("parameters").(map[string]interface{})
+	if len(parameters) != 1 || parameters["ntp_server"] != "10.225.18.204" {
+		t.Errorf(
+			"dataSourceForemanSubnetRead did not set [parameters]. Expected "+
+				"[map[ntp_server:10.225.18.204]], got [%v].",
+			parameters,
+		)
+	}
+}
@@ -220,6 +220,10 @@ func TestCRUDFunction_CorrectURLAndMethod(t *testing.T) {
 
 	testCases = append(testCases, ResourceForemanMediaCorrectURLAndMethodTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanMediaCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, ResourceForemanOrganizationCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, ResourceForemanLocationCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanOrganizationCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanLocationCorrectURLAndMethodTestCases(t)...)
 
 	testCases = append(testCases, ResourceForemanModelCorrectURLAndMethodTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanModelCorrectURLAndMethodTestCases(t)...)
@@ -239,8 +243,18 @@ func TestCRUDFunction_CorrectURLAndMethod(t *testing.T) {
 
 	testCases = append(testCases, DataSourceForemanSmartClassParameterCorrectURLAndMethodTestCases(t)...)
 
+	testCases = append(testCases, ResourceForemanSettingCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, ResourceForemanRoleCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanRoleCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, ResourceForemanFilterCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanPermissionCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, ResourceForemanAuthSourceLDAPCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanAuthSourceLDAPCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, ResourceForemanPersonalAccessTokenCorrectURLAndMethodTestCases(t)...)
+
 	testCases = append(testCases, ResourceForemanSmartProxyCorrectURLAndMethodTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanSmartProxyCorrectURLAndMethodTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanSmartProxyStatusCorrectURLAndMethodTestCases(t)...)
 
 	testCases = append(testCases, ResourceForemanSubnetCorrectURLAndMethodTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanSubnetCorrectURLAndMethodTestCases(t)...)
@@ -267,6 +281,7 @@ func TestCRUDFunction_CorrectURLAndMethod(t *testing.T) {
 
 		// expected handler to be called
 		for _, uri := range testCase.expectedURIs {
+			uri := uri
 			mux.HandleFunc(uri.expectedURI, func(w http.ResponseWriter, r *http.Request) {
 				// assert expected HTTP method
 				if !strings.EqualFold(uri.expectedMethod, r.Method) {
@@ -322,6 +337,10 @@ func TestCRUDFunction_RequestDataEmpty(t *testing.T) {
 
 	testCases = append(testCases, ResourceForemanMediaRequestDataEmptyTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanMediaRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, ResourceForemanOrganizationRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, ResourceForemanLocationRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanOrganizationRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanLocationRequestDataEmptyTestCases(t)...)
 
 	testCases = append(testCases, ResourceForemanModelRequestDataEmptyTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanModelRequestDataEmptyTestCases(t)...)
@@ -341,8 +360,18 @@ func TestCRUDFunction_RequestDataEmpty(t *testing.T) {
 
 	testCases = append(testCases, DataSourceForemanSmartClassParameterRequestDataEmptyTestCases(t)...)
 
+	testCases = append(testCases, ResourceForemanSettingRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, ResourceForemanRoleRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanRoleRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, ResourceForemanFilterRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanPermissionRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, ResourceForemanAuthSourceLDAPRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanAuthSourceLDAPRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, ResourceForemanPersonalAccessTokenRequestDataEmptyTestCases(t)...)
+
 	testCases = append(testCases, ResourceForemanSmartProxyRequestDataEmptyTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanSmartProxyRequestDataEmptyTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanSmartProxyStatusRequestDataEmptyTestCases(t)...)
 
 	testCases = append(testCases, ResourceForemanSubnetRequestDataEmptyTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanSubnetRequestDataEmptyTestCases(t)...)
@@ -399,6 +428,8 @@ func TestCRUDFunction_RequestData(t *testing.T) {
 	testCases = append(testCases, ResourceForemanArchitectureRequestDataTestCases(t)...)
 	testCases = append(testCases, ResourceForemanHostgroupRequestDataTestCases(t)...)
 	testCases = append(testCases, ResourceForemanMediaRequestDataTestCases(t)...)
+	testCases = append(testCases, ResourceForemanOrganizationRequestDataTestCases(t)...)
+	testCases = append(testCases, ResourceForemanLocationRequestDataTestCases(t)...)
 	testCases = append(testCases, ResourceForemanModelRequestDataTestCases(t)...)
 	testCases = append(testCases, ResourceForemanOverrideValueRequestDataTestCases(t)...)
 	testCases = append(testCases, ResourceForemanPartitionTableRequestDataTestCases(t)...)
@@ -467,6 +498,10 @@ func TestCRUDFunction_StatusCodeError(t *testing.T) {
 
 	testCases = append(testCases, ResourceForemanMediaStatusCodeTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanMediaStatusCodeTestCases(t)...)
+	testCases = append(testCases, ResourceForemanOrganizationStatusCodeTestCases(t)...)
+	testCases = append(testCases, ResourceForemanLocationStatusCodeTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanOrganizationStatusCodeTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanLocationStatusCodeTestCases(t)...)
 
 	testCases = append(testCases, ResourceForemanModelStatusCodeTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanModelStatusCodeTestCases(t)...)
@@ -486,8 +521,18 @@ func TestCRUDFunction_StatusCodeError(t *testing.T) {
 
 	testCases = append(testCases, DataSourceForemanSmartClassParameterStatusCodeTestCases(t)...)
 
+	testCases = append(testCases, ResourceForemanSettingStatusCodeTestCases(t)...)
+	testCases = append(testCases, ResourceForemanRoleStatusCodeTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanRoleStatusCodeTestCases(t)...)
+	testCases = append(testCases, ResourceForemanFilterStatusCodeTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanPermissionStatusCodeTestCases(t)...)
+	testCases = append(testCases, ResourceForemanAuthSourceLDAPStatusCodeTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanAuthSourceLDAPStatusCodeTestCases(t)...)
+	testCases = append(testCases, ResourceForemanPersonalAccessTokenStatusCodeTestCases(t)...)
+
 	testCases = append(testCases, ResourceForemanSmartProxyStatusCodeTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanSmartProxyStatusCodeTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanSmartProxyStatusStatusCodeTestCases(t)...)
 
 	testCases = append(testCases, ResourceForemanSubnetStatusCodeTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanSubnetStatusCodeTestCases(t)...)
@@ -551,6 +596,10 @@ func TestCRUDFunction_EmptyResponseError(t *testing.T) {
 
 	testCases = append(testCases, ResourceForemanMediaEmptyResponseTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanMediaEmptyResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanOrganizationEmptyResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanLocationEmptyResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanOrganizationEmptyResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanLocationEmptyResponseTestCases(t)...)
 
 	testCases = append(testCases, ResourceForemanModelEmptyResponseTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanModelEmptyResponseTestCases(t)...)
@@ -570,8 +619,18 @@ func TestCRUDFunction_EmptyResponseError(t *testing.T) {
 
 	testCases = append(testCases, DataSourceForemanSmartClassParameterEmptyResponseTestCases(t)...)
 
+	testCases = append(testCases, ResourceForemanSettingEmptyResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanRoleEmptyResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanRoleEmptyResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanFilterEmptyResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanPermissionEmptyResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanAuthSourceLDAPEmptyResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanAuthSourceLDAPEmptyResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanPersonalAccessTokenEmptyResponseTestCases(t)...)
+
 	testCases = append(testCases, ResourceForemanSmartProxyEmptyResponseTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanSmartProxyEmptyResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanSmartProxyStatusEmptyResponseTestCases(t)...)
 
 	testCases = append(testCases, ResourceForemanSubnetEmptyResponseTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanSubnetEmptyResponseTestCases(t)...)
@@ -626,6 +685,11 @@ type TestCaseMockResponse struct {
 	// Path to the testdata file.  The server will return the contents of this
 	// file as its response.
 	responseFile string
+	// Path of the single object read by the CRUD function - ie: the read of
+	// a data source following its search - and the testdata file returned
+	// for it.  Optional, all other requests are answered with responseFile.
+	readURI          string
+	readResponseFile string
 	// Whether or not the CRUD function is expected to return an error. If the
 	// CRUD function is expected to return an error, then the test framework
 	// will not attempt to validate the end state of ResourceData reference.
@@ -673,6 +737,10 @@ func TestCRUDFunction_MockResponse(t *testing.T) {
 
 	testCases = append(testCases, ResourceForemanMediaMockResponseTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanMediaMockResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanOrganizationMockResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanLocationMockResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanOrganizationMockResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanLocationMockResponseTestCases(t)...)
 
 	testCases = append(testCases, ResourceForemanModelMockResponseTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanModelMockResponseTestCases(t)...)
@@ -694,8 +762,18 @@ func TestCRUDFunction_MockResponse(t *testing.T) {
 
 	testCases = append(testCases, DataSourceForemanSmartClassParameterMockResponseTestCases(t)...)
 
+	testCases = append(testCases, ResourceForemanSettingMockResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanRoleMockResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanRoleMockResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanFilterMockResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanPermissionMockResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanAuthSourceLDAPMockResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanAuthSourceLDAPMockResponseTestCases(t)...)
+	testCases = append(testCases, ResourceForemanPersonalAccessTokenMockResponseTestCases(t)...)
+
 	testCases = append(testCases, ResourceForemanSmartProxyMockResponseTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanSmartProxyMockResponseTestCases(t)...)
+	testCases = append(testCases, DataSourceForemanSmartProxyStatusMockResponseTestCases(t)...)
 
 	testCases = append(testCases, ResourceForemanSubnetMockResponseTestCases(t)...)
 	testCases = append(testCases, DataSourceForemanSubnetMockResponseTestCases(t)...)
@@ -718,11 +796,15 @@ func TestCRUDFunction_MockResponse(t *testing.T) {
 		defer server.Close()
 
 		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-			bytes, readErr := os.ReadFile(testCase.responseFile)
+			responseFile := testCase.responseFile
+			if testCase.readURI != "" && r.URL.Path == testCase.readURI {
+				responseFile = testCase.readResponseFile
+			}
+			bytes, readErr := os.ReadFile(responseFile)
 			if readErr != nil {
 				t.Fatalf(
 					"Error reading file [%s] to send as server response. Failing Test. Error: [%s]",
-					testCase.responseFile,
+					responseFile,
 					readErr.Error(),
 				)
 			}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"net/url"
 	"os"
+	"time"
 
 	logger "github.com/HanseMerkur/terraform-provider-utils/log"
 	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
@@ -24,6 +25,25 @@ const (
 	ClientUsernameEnv string = "FOREMAN_CLIENT_USERNAME"
 	// Environment variable to configure the client_password attribute
 	ClientPasswordEnv string = "FOREMAN_CLIENT_PASSWORD"
+	// Environment variable to configure the client_auth_method attribute
+	ClientAuthMethodEnv string = "FOREMAN_CLIENT_AUTH_METHOD"
+	// Environment variable to configure the client_token attribute
+	ClientTokenEnv string = "FOREMAN_CLIENT_TOKEN"
+	// Environment variable to configure the client_oauth_consumer_key attribute
+	ClientOAuthConsumerKeyEnv string = "FOREMAN_CLIENT_OAUTH_CONSUMER_KEY"
+	// Environment variable to configure the client_oauth_consumer_secret
+	// attribute
+	ClientOAuthConsumerSecretEnv string = "FOREMAN_CLIENT_OAUTH_CONSUMER_SECRET"
+	// Environment variable to configure the client_oauth_user attribute
+	ClientOAuthUserEnv string = "FOREMAN_CLIENT_OAUTH_USER"
+	// Environment variable to configure the client_tls_ca_file attribute
+	ClientTLSCAFileEnv string = "FOREMAN_CLIENT_TLS_CA_FILE"
+	// Environment variable to configure the client_tls_ca_pem attribute
+	ClientTLSCAPEMEnv string = "FOREMAN_CLIENT_TLS_CA_PEM"
+	// Environment variable to configure the client_tls_cert_file attribute
+	ClientTLSCertFileEnv string = "FOREMAN_CLIENT_TLS_CERT_FILE"
+	// Environment variable to configure the client_tls_key_file attribute
+	ClientTLSKeyFileEnv string = "FOREMAN_CLIENT_TLS_KEY_FILE"
 )
 
 // Provider configuration default values
@@ -123,6 +143,67 @@ func Provider() *schema.Provider {
 					"Defaults to `false`.",
 			},
 
+			"client_tls_ca_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientTLSCAFileEnv,
+					"",
+				),
+				Description: "Path to a PEM encoded bundle of certificate authorities " +
+					"to verify the server's certificate with, in addition to the " +
+					"system's certificate authorities. This can also be set through the " +
+					"environment variable `FOREMAN_CLIENT_TLS_CA_FILE`. Defaults to `\"\"`.",
+			},
+
+			"client_tls_ca_pem": {
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientTLSCAPEMEnv,
+					"",
+				),
+				Description: "PEM encoded bundle of certificate authorities to verify " +
+					"the server's certificate with, in addition to the system's " +
+					"certificate authorities and `client_tls_ca_file`. This can also be " +
+					"set through the environment variable `FOREMAN_CLIENT_TLS_CA_PEM`. " +
+					"Defaults to `\"\"`.",
+			},
+
+			"client_tls_cert_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientTLSCertFileEnv,
+					"",
+				),
+				Description: "Path to a PEM encoded client certificate presented to " +
+					"the server for mutual TLS authentication. Requires " +
+					"`client_tls_key_file`. This can also be set through the environment " +
+					"variable `FOREMAN_CLIENT_TLS_CERT_FILE`. Defaults to `\"\"`.",
+			},
+
+			"client_tls_key_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientTLSKeyFileEnv,
+					"",
+				),
+				Description: "Path to the PEM encoded private key of " +
+					"`client_tls_cert_file`. This can also be set through the environment " +
+					"variable `FOREMAN_CLIENT_TLS_KEY_FILE`. Defaults to `\"\"`.",
+			},
+
+			"client_tls_server_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+				Description: "The name to verify the server's certificate against " +
+					"instead of `server_hostname`, ie: when connecting through an IP " +
+					"address or a load balancer. Defaults to `\"\"`.",
+			},
+
 			"client_auth_negotiate": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -131,6 +212,68 @@ func Provider() *schema.Provider {
 					"through the HTTP negotiate mechanism. Defaults to `false`.",
 			},
 
+			"client_task_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "How many seconds to wait for asynchronous Foreman tasks, " +
+					"ie: Katello content view publishes, to finish. `0` waits as long " +
+					"as the timeout of the resource operation allows. Defaults to `0`.",
+			},
+
+			"client_task_poll_interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "How many seconds to wait before polling the state of an " +
+					"asynchronous Foreman task for the first time. The interval doubles " +
+					"after every poll up to `client_task_poll_max_interval`. Defaults to `1`.",
+			},
+
+			"client_task_poll_max_interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "The maximum number of seconds between two polls of an " +
+					"asynchronous Foreman task. Defaults to `30`.",
+			},
+
+			"client_retry_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description: "How many times a request is sent again when Foreman cannot " +
+					"be reached or answers with `429 Too Many Requests` or a `5xx` status " +
+					"code. Requests which change data (e.g. `POST`, `PUT` and `DELETE`) " +
+					"are only sent again if they never reached Foreman: when the " +
+					"connection could not be established or Foreman answered with `429` " +
+					"or `503 Service Unavailable`. `0` disables retries. Defaults to `3`.",
+			},
+
+			"client_retry_wait_min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "How many seconds to wait before the first retry of a failed " +
+					"request. The wait doubles after every attempt up to " +
+					"`client_retry_wait_max`. A `Retry-After` header sent by the server " +
+					"takes precedence. Defaults to `1`.",
+			},
+
+			"client_retry_wait_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "The maximum number of seconds to wait between two attempts " +
+					"of a request. Defaults to `30`.",
+			},
+
 			// -- client credentials --
 
 			"client_username": {
@@ -155,6 +298,76 @@ func Provider() *schema.Provider {
 					"also be set through the environment variable `FOREMAN_CLIENT_PASSWORD`. " +
 					"Defaults to `\"\"`.",
 			},
+			"client_auth_method": {
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientAuthMethodEnv,
+					api.AuthMethodBasic,
+				),
+				ValidateFunc: validation.StringInSlice([]string{
+					api.AuthMethodBasic,
+					api.AuthMethodToken,
+					api.AuthMethodOAuth,
+				}, false),
+				Description: "How to authenticate against Foreman. `\"basic\"` uses " +
+					"`client_username` and `client_password`, `\"token\"` sends " +
+					"`client_token` as bearer token and `\"oauth\"` signs the requests " +
+					"with `client_oauth_consumer_key` and `client_oauth_consumer_secret`. " +
+					"This can also be set through the environment variable " +
+					"`FOREMAN_CLIENT_AUTH_METHOD`. Defaults to `\"basic\"`.",
+			},
+			"client_token": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientTokenEnv,
+					"",
+				),
+				Description: "The personal access token to authenticate against " +
+					"Foreman if `client_auth_method` is `\"token\"`. This can also be " +
+					"set through the environment variable `FOREMAN_CLIENT_TOKEN`. " +
+					"Defaults to `\"\"`.",
+			},
+			"client_oauth_consumer_key": {
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientOAuthConsumerKeyEnv,
+					"",
+				),
+				Description: "The OAuth consumer key configured in Foreman's settings, " +
+					"used if `client_auth_method` is `\"oauth\"`. This can also be set " +
+					"through the environment variable `FOREMAN_CLIENT_OAUTH_CONSUMER_KEY`. " +
+					"Defaults to `\"\"`.",
+			},
+			"client_oauth_consumer_secret": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientOAuthConsumerSecretEnv,
+					"",
+				),
+				Description: "The OAuth consumer secret configured in Foreman's settings, " +
+					"used if `client_auth_method` is `\"oauth\"`. This can also be set " +
+					"through the environment variable `FOREMAN_CLIENT_OAUTH_CONSUMER_SECRET`. " +
+					"Defaults to `\"\"`.",
+			},
+			"client_oauth_user": {
+				Type:     schema.TypeString,
+				Optional: true,
+				DefaultFunc: schema.EnvDefaultFunc(
+					ClientOAuthUserEnv,
+					"",
+				),
+				Description: "The login of the Foreman user to act as when " +
+					"`client_auth_method` is `\"oauth\"`. Requires the Foreman setting " +
+					"`oauth_map_users`. If empty, requests are executed as the API admin. " +
+					"This can also be set through the environment variable " +
+					"`FOREMAN_CLIENT_OAUTH_USER`. Defaults to `\"\"`.",
+			},
 
 			// -- provider organization and location --
 			"organization_id": {
@@ -163,7 +376,9 @@ func Provider() *schema.Provider {
 				Default:  0,
 				Description: "The organization for all resource requested and created by the Provider " +
 					"Defaults to \"0\". Set organization_id and location_id to a value < 0 if you need " +
-					"to disable Locations and Organizations on Foreman older than 1.21",
+					"to disable Locations and Organizations on Foreman older than 1.21. " +
+					"Resources of taxable objects can override it with their `organization_ids` " +
+					"(`organization_id` for hosts) argument.",
 			},
 			"location_id": {
 				Type:     schema.TypeInt,
@@ -171,7 +386,9 @@ func Provider() *schema.Provider {
 				Default:  0,
 				Description: "The location for all resources requested and created by the provider" +
 					"Defaults to \"0\". Set organization_id and location_id to a value < 0 if you need " +
-					"to disable Locations and Organizations on Foreman older than 1.21",
+					"to disable Locations and Organizations on Foreman older than 1.21. " +
+					"Resources of taxable objects can override it with their `location_ids` " +
+					"(`location_id` for hosts) argument.",
 			},
 		},
 
@@ -179,8 +396,10 @@ func Provider() *schema.Provider {
 			"foreman_architecture":                  resourceForemanArchitecture(),
 			"foreman_host":                          resourceForemanHost(),
 			"foreman_hostgroup":                     resourceForemanHostgroup(),
+			"foreman_location":                      resourceForemanLocation(),
 			"foreman_media":                         resourceForemanMedia(),
 			"foreman_model":                         resourceForemanModel(),
+			"foreman_organization":                  resourceForemanOrganization(),
 			"foreman_operatingsystem":               resourceForemanOperatingSystem(),
 			"foreman_partitiontable":                resourceForemanPartitionTable(),
 			"foreman_provisioningtemplate":          resourceForemanProvisioningTemplate(),
@@ -201,11 +420,16 @@ func Provider() *schema.Provider {
 			"foreman_katello_content_view":          resourceForemanKatelloContentView(),
 			"foreman_katello_sync_plan":             resourceForemanKatelloSyncPlan(),
 			"foreman_user":                          resourceForemanUser(),
+			"foreman_personal_access_token":         resourceForemanPersonalAccessToken(),
 			"foreman_usergroup":                     resourceForemanUsergroup(),
 			"foreman_override_value":                resourceForemanOverrideValue(),
 			"foreman_computeprofile":                resourceForemanComputeProfile(),
 			"foreman_jobtemplate":                   resourceForemanJobTemplate(),
 			"foreman_templateinput":                 resourceForemanTemplateInput(),
+			"foreman_setting":                       resourceForemanSetting(),
+			"foreman_role":                          resourceForemanRole(),
+			"foreman_filter":                        resourceForemanFilter(),
+			"foreman_auth_source_ldap":              resourceForemanAuthSourceLDAP(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -213,14 +437,17 @@ func Provider() *schema.Provider {
 			"foreman_domain":                        dataSourceForemanDomain(),
 			"foreman_environment":                   dataSourceForemanEnvironment(),
 			"foreman_hostgroup":                     dataSourceForemanHostgroup(),
+			"foreman_location":                      dataSourceForemanLocation(),
 			"foreman_media":                         dataSourceForemanMedia(),
 			"foreman_model":                         dataSourceForemanModel(),
 			"foreman_operatingsystem":               dataSourceForemanOperatingSystem(),
+			"foreman_organization":                  dataSourceForemanOrganization(),
 			"foreman_partitiontable":                dataSourceForemanPartitionTable(),
 			"foreman_provisioningtemplate":          dataSourceForemanProvisioningTemplate(),
 			"foreman_puppetclass":                   dataSourceForemanPuppetClass(),
 			"foreman_smartclassparameter":           dataSourceForemanSmartClassParameter(),
 			"foreman_smartproxy":                    dataSourceForemanSmartProxy(),
+			"foreman_smartproxy_status":             dataSourceForemanSmartProxyStatus(),
 			"foreman_subnet":                        dataSourceForemanSubnet(),
 			"foreman_templatekind":                  dataSourceForemanTemplateKind(),
 			"foreman_computeprofile":                dataSourceForemanComputeProfile(),
@@ -239,6 +466,9 @@ func Provider() *schema.Provider {
 			"foreman_user":                          dataSourceForemanUser(),
 			"foreman_usergroup":                     dataSourceForemanUsergroup(),
 			"foreman_setting":                       dataSourceForemanSetting(),
+			"foreman_role":                          dataSourceForemanRole(),
+			"foreman_permission":                    dataSourceForemanPermission(),
+			"foreman_auth_source_ldap":              dataSourceForemanAuthSourceLDAP(),
 			"foreman_jobtemplate":                   dataSourceForemanJobTemplate(),
 			"foreman_templateinput":                 dataSourceForemanTemplateInput(),
 		},
@@ -312,13 +542,31 @@ func providerConfigure(context context.Context, d *schema.ResourceData) (interfa
 		},
 		// -- client configuration --
 		ClientTLSInsecure:    d.Get("client_tls_insecure").(bool),
+		ClientTLSCAFile:      d.Get("client_tls_ca_file").(string),
+		ClientTLSCAPEM:       d.Get("client_tls_ca_pem").(string),
+		ClientTLSCertFile:    d.Get("client_tls_cert_file").(string),
+		ClientTLSKeyFile:     d.Get("client_tls_key_file").(string),
+		ClientTLSServerName:  d.Get("client_tls_server_name").(string),
 		NegotiateAuthEnabled: d.Get("client_auth_negotiate").(bool),
 		ClientCredentials: api.ClientCredentials{
 			Username: d.Get("client_username").(string),
 			Password: d.Get("client_password").(string),
 		},
-		LocationID:     d.Get("location_id").(int),
-		OrganizationID: d.Get("organization_id").(int),
+		AuthMethod:          d.Get("client_auth_method").(string),
+		ClientToken:         d.Get("client_token").(string),
+		OAuthConsumerKey:    d.Get("client_oauth_consumer_key").(string),
+		OAuthConsumerSecret: d.Get("client_oauth_consumer_secret").(string),
+		OAuthUser:           d.Get("client_oauth_user").(string),
+		LocationID:          d.Get("location_id").(int),
+		OrganizationID:      d.Get("organization_id").(int),
+		// -- asynchronous task polling --
+		TaskTimeout:         time.Duration(d.Get("client_task_timeout").(int)) * time.Second,
+		TaskPollInterval:    time.Duration(d.Get("client_task_poll_interval").(int)) * time.Second,
+		TaskPollMaxInterval: time.Duration(d.Get("client_task_poll_max_interval").(int)) * time.Second,
+		// -- request retries --
+		RetryMax:     d.Get("client_retry_max").(int),
+		RetryWaitMin: time.Duration(d.Get("client_retry_wait_min").(int)) * time.Second,
+		RetryWaitMax: time.Duration(d.Get("client_retry_wait_max").(int)) * time.Second,
 	}
 
 	return config.Client()
@@ -129,7 +129,7 @@ func resourceForemanArchitectureCreate(ctx context.Context, d *schema.ResourceDa
 
 	createdArch, createErr := client.CreateArchitecture(ctx, a)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanArchitecture: [%+v]", createdArch)
@@ -169,7 +169,7 @@ func resourceForemanArchitectureUpdate(ctx context.Context, d *schema.ResourceDa
 
 	updatedArch, updateErr := client.UpdateArchitecture(ctx, a)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	log.Debugf("Updated ForemanArchitecture: [%+v]", updatedArch)
@@ -0,0 +1,367 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanAuthSourceLDAP() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanAuthSourceLDAPCreate,
+		ReadContext:   resourceForemanAuthSourceLDAPRead,
+		UpdateContext: resourceForemanAuthSourceLDAPUpdate,
+		DeleteContext: resourceForemanAuthSourceLDAPDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s An LDAP server users authenticate against. Users reference "+
+						"it by its ID in `auth_source_id`.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Name of the authentication source."+
+						"%s \"corp-ldap\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			// -- Connection --
+
+			"host": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"Hostname or IP address of the LDAP server."+
+						"%s \"ldap.example.com\"",
+					autodoc.MetaExample,
+				),
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IsPortNumber,
+				Description:  "Port of the LDAP server. Defaults to `389`, or `636` with TLS.",
+			},
+			"tls": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to connect with LDAPS. Defaults to `false`.",
+			},
+			"server_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "posix",
+				ValidateFunc: validation.StringInSlice([]string{
+					"posix",
+					"free_ipa",
+					"active_directory",
+				}, false),
+				Description: "Type of the LDAP server. One of `posix`, `free_ipa` or " +
+					"`active_directory`. Defaults to `posix`.",
+			},
+			"base_dn": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Base DN the users are searched in."+
+						"%s \"ou=people,dc=example,dc=com\"",
+					autodoc.MetaExample,
+				),
+			},
+			"groups_base": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Base DN the groups are searched in, ie: for external usergroups.",
+			},
+			"ldap_filter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Additional LDAP filter applied when searching for users, ie: `(memberOf=cn=foreman,ou=groups,dc=example,dc=com)`.",
+			},
+			"use_netgroups": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to use netgroups instead of groups. Only supported by POSIX servers. Defaults to `false`.",
+			},
+
+			// -- Bind Account --
+
+			"account": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Account used to bind to the LDAP server. Use `$login` to " +
+					"bind with the credentials of the authenticating user. Binds " +
+					"anonymously if unset.",
+			},
+			"account_password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				Description: "Password of the bind account. The password is not read " +
+					"back from Foreman - changes made outside of Terraform are not detected.",
+			},
+
+			// -- Attribute Mappings --
+
+			"attr_login": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"LDAP attribute of the login name. Required to register users on "+
+						"the fly."+
+						"%s \"uid\"",
+					autodoc.MetaExample,
+				),
+			},
+			"attr_firstname": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "LDAP attribute of the first name, ie: `givenName`. Required to register users on the fly.",
+			},
+			"attr_lastname": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "LDAP attribute of the last name, ie: `sn`. Required to register users on the fly.",
+			},
+			"attr_mail": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "LDAP attribute of the email address, ie: `mail`. Required to register users on the fly.",
+			},
+			"attr_photo": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "LDAP attribute of the photo, ie: `jpegPhoto`.",
+			},
+
+			// -- Users and Groups --
+
+			"onthefly_register": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether users are created on their first login. Requires " +
+					"the attribute mappings of the login name, first name, last name " +
+					"and email address. Defaults to `false`.",
+			},
+			"usergroup_sync": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				Description: "Whether the members of external usergroups are " +
+					"synchronized when users log in. Defaults to `true`.",
+			},
+
+			"test_connection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether to test the connection and bind to the LDAP " +
+					"server after creating or updating the authentication source. The " +
+					"apply fails if Foreman cannot connect. Defaults to `false`.",
+			},
+
+			// -- Taxonomies --
+
+			"location_ids":     locationIdsSchema("authentication source"),
+			"organization_ids": organizationIdsSchema("authentication source"),
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanAuthSourceLDAP constructs a ForemanAuthSourceLDAP reference
+// from a resource data reference.  The struct's  members are populated from
+// the data populated in the resource data.  Missing members will be left to
+// the zero value for that member's type.
+func buildForemanAuthSourceLDAP(d *schema.ResourceData) *api.ForemanAuthSourceLDAP {
+	log.Tracef("resource_foreman_auth_source_ldap.go#buildForemanAuthSourceLDAP")
+
+	authSource := api.ForemanAuthSourceLDAP{}
+
+	obj := buildForemanObject(d)
+	authSource.ForemanObject = *obj
+
+	authSource.Name = d.Get("name").(string)
+	authSource.Host = d.Get("host").(string)
+	authSource.Port = d.Get("port").(int)
+	authSource.TLS = d.Get("tls").(bool)
+	authSource.ServerType = d.Get("server_type").(string)
+	authSource.BaseDN = d.Get("base_dn").(string)
+	authSource.GroupsBase = d.Get("groups_base").(string)
+	authSource.LDAPFilter = d.Get("ldap_filter").(string)
+	authSource.UseNetgroups = d.Get("use_netgroups").(bool)
+
+	authSource.Account = d.Get("account").(string)
+	authSource.AccountPassword = d.Get("account_password").(string)
+
+	authSource.AttrLogin = d.Get("attr_login").(string)
+	authSource.AttrFirstname = d.Get("attr_firstname").(string)
+	authSource.AttrLastname = d.Get("attr_lastname").(string)
+	authSource.AttrMail = d.Get("attr_mail").(string)
+	authSource.AttrPhoto = d.Get("attr_photo").(string)
+
+	authSource.OntheflyRegister = d.Get("onthefly_register").(bool)
+	authSource.UsergroupSync = d.Get("usergroup_sync").(bool)
+
+	authSource.ForemanTaxonomies = buildForemanTaxonomies(d)
+
+	return &authSource
+}
+
+// setResourceDataFromForemanAuthSourceLDAP sets a ResourceData's attributes
+// from the attributes of the supplied ForemanAuthSourceLDAP reference.  The
+// account password is never returned by the API and left untouched.
+func setResourceDataFromForemanAuthSourceLDAP(d *schema.ResourceData, fa *api.ForemanAuthSourceLDAP) {
+	log.Tracef("resource_foreman_auth_source_ldap.go#setResourceDataFromForemanAuthSourceLDAP")
+
+	d.SetId(strconv.Itoa(fa.Id))
+	d.Set("name", fa.Name)
+	d.Set("host", fa.Host)
+	d.Set("port", fa.Port)
+	d.Set("tls", fa.TLS)
+	d.Set("server_type", fa.ServerType)
+	d.Set("base_dn", fa.BaseDN)
+	d.Set("groups_base", fa.GroupsBase)
+	d.Set("ldap_filter", fa.LDAPFilter)
+	d.Set("use_netgroups", fa.UseNetgroups)
+	d.Set("account", fa.Account)
+	d.Set("attr_login", fa.AttrLogin)
+	d.Set("attr_firstname", fa.AttrFirstname)
+	d.Set("attr_lastname", fa.AttrLastname)
+	d.Set("attr_mail", fa.AttrMail)
+	d.Set("attr_photo", fa.AttrPhoto)
+	d.Set("onthefly_register", fa.OntheflyRegister)
+	d.Set("usergroup_sync", fa.UsergroupSync)
+	setResourceDataFromForemanTaxonomies(d, fa.ForemanTaxonomies)
+}
+
+// testForemanAuthSourceLDAPConnection tests the connection to the LDAP server
+// if the resource enables the connection test
+func testForemanAuthSourceLDAPConnection(ctx context.Context, d *schema.ResourceData, client *api.Client) diag.Diagnostics {
+	if !d.Get("test_connection").(bool) {
+		return nil
+	}
+
+	id, _ := strconv.Atoi(d.Id())
+	testResult, testErr := client.TestAuthSourceLDAP(ctx, id)
+	if testErr != nil {
+		return api.DiagnosticsFromError(d, testErr)
+	}
+
+	log.Debugf("Tested ForemanAuthSourceLDAP: [%+v]", testResult)
+
+	if !testResult.Success {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  "LDAP connection test failed",
+				Detail: fmt.Sprintf(
+					"Foreman could not connect to the LDAP server [%s]: %s",
+					d.Get("host"),
+					testResult.Message,
+				),
+			},
+		}
+	}
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanAuthSourceLDAPCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_auth_source_ldap.go#Create")
+
+	client := meta.(*api.Client)
+	a := buildForemanAuthSourceLDAP(d)
+
+	createdAuthSourceLDAP, createErr := client.CreateAuthSourceLDAP(ctx, a)
+	if createErr != nil {
+		return api.DiagnosticsFromError(d, createErr)
+	}
+
+	log.Debugf("Created ForemanAuthSourceLDAP: [%+v]", createdAuthSourceLDAP)
+
+	setResourceDataFromForemanAuthSourceLDAP(d, createdAuthSourceLDAP)
+
+	return testForemanAuthSourceLDAPConnection(ctx, d, client)
+}
+
+func resourceForemanAuthSourceLDAPRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_auth_source_ldap.go#Read")
+
+	client := meta.(*api.Client)
+	a := buildForemanAuthSourceLDAP(d)
+
+	readAuthSourceLDAP, readErr := client.ReadAuthSourceLDAP(ctx, a.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanAuthSourceLDAP: [%+v]", readAuthSourceLDAP)
+
+	setResourceDataFromForemanAuthSourceLDAP(d, readAuthSourceLDAP)
+
+	return nil
+}
+
+func resourceForemanAuthSourceLDAPUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_auth_source_ldap.go#Update")
+
+	client := meta.(*api.Client)
+	a := buildForemanAuthSourceLDAP(d)
+
+	updatedAuthSourceLDAP, updateErr := client.UpdateAuthSourceLDAP(ctx, a)
+	if updateErr != nil {
+		return api.DiagnosticsFromError(d, updateErr)
+	}
+
+	log.Debugf("Updated ForemanAuthSourceLDAP: [%+v]", updatedAuthSourceLDAP)
+
+	setResourceDataFromForemanAuthSourceLDAP(d, updatedAuthSourceLDAP)
+
+	return testForemanAuthSourceLDAPConnection(ctx, d, client)
+}
+
+func resourceForemanAuthSourceLDAPDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_auth_source_ldap.go#Delete")
+
+	client := meta.(*api.Client)
+	a := buildForemanAuthSourceLDAP(d)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return diag.FromErr(api.CheckDeleted(d, client.DeleteAuthSourceLDAP(ctx, a.Id)))
+}
@@ -0,0 +1,426 @@
+package foreman
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const AuthSourceLDAPsURI = api.FOREMAN_API_URL_PREFIX + "/auth_source_ldaps"
+const AuthSourceLDAPsTestDataPath = "testdata/1.11/auth_source_ldaps"
+
+// Given a ForemanAuthSourceLDAP, create a mock instance state reference
+func ForemanAuthSourceLDAPToInstanceState(obj api.ForemanAuthSourceLDAP) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = strconv.Itoa(obj.Id)
+	// Build the attribute map from ForemanAuthSourceLDAP
+	attr := map[string]string{}
+	attr["name"] = obj.Name
+	attr["host"] = obj.Host
+	attr["port"] = strconv.Itoa(obj.Port)
+	attr["tls"] = strconv.FormatBool(obj.TLS)
+	attr["server_type"] = obj.ServerType
+	attr["base_dn"] = obj.BaseDN
+	attr["groups_base"] = obj.GroupsBase
+	attr["ldap_filter"] = obj.LDAPFilter
+	attr["use_netgroups"] = strconv.FormatBool(obj.UseNetgroups)
+	attr["account"] = obj.Account
+	attr["account_password"] = obj.AccountPassword
+	attr["attr_login"] = obj.AttrLogin
+	attr["attr_firstname"] = obj.AttrFirstname
+	attr["attr_lastname"] = obj.AttrLastname
+	attr["attr_mail"] = obj.AttrMail
+	attr["attr_photo"] = obj.AttrPhoto
+	attr["onthefly_register"] = strconv.FormatBool(obj.OntheflyRegister)
+	attr["usergroup_sync"] = strconv.FormatBool(obj.UsergroupSync)
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanAuthSourceLDAP resource, create a
+// mock ResourceData reference.
+func MockForemanAuthSourceLDAPResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanAuthSourceLDAP()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates an LDAP authentication
+// source ResourceData reference
+func MockForemanAuthSourceLDAPResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanAuthSourceLDAP
+	ParseJSONFile(t, path, &obj)
+	s := ForemanAuthSourceLDAPToInstanceState(obj)
+	return MockForemanAuthSourceLDAPResourceData(s)
+}
+
+// Creates a random ForemanAuthSourceLDAP struct
+func RandForemanAuthSourceLDAP() api.ForemanAuthSourceLDAP {
+	obj := api.ForemanAuthSourceLDAP{}
+
+	fo := RandForemanObject()
+	obj.ForemanObject = fo
+
+	obj.Host = fo.Name + ".example.com"
+	obj.Port = 389
+	obj.ServerType = "posix"
+	obj.BaseDN = "ou=people,dc=example,dc=com"
+	obj.Account = "uid=foreman,ou=services,dc=example,dc=com"
+	obj.AttrLogin = "uid"
+	obj.UsergroupSync = true
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanAuthSourceLDAP resource.
+// If the two references differ in their attributes, the test will raise a
+// fatal.
+func ForemanAuthSourceLDAPResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := resourceForemanAuthSourceLDAP()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+}
+
+// -----------------------------------------------------------------------------
+// MarshalJSON / UnmarshalJSON
+// -----------------------------------------------------------------------------
+
+// Ensures the JSON unmarshal decodes the attributes and the taxonomies of the
+// API response
+func TestAuthSourceLDAPUnmarshalJSON(t *testing.T) {
+
+	var obj api.ForemanAuthSourceLDAP
+	ParseJSONFile(t, AuthSourceLDAPsTestDataPath+"/read_response.json", &obj)
+
+	if obj.Id != 3 || obj.Host != "ldap.example.com" || obj.Port != 636 ||
+		!obj.TLS || !obj.OntheflyRegister || obj.AttrMail != "mail" ||
+		!reflect.DeepEqual(obj.LocationIds, []int{2}) ||
+		!reflect.DeepEqual(obj.OrganizationIds, []int{1}) {
+		t.Errorf(
+			"ForemanAuthSourceLDAP UnmarshalJSON did not properly decode the "+
+				"response. Got [%+v]",
+			obj,
+		)
+	}
+
+}
+
+// Ensures the account password is only sent if it is set
+func TestAuthSourceLDAPMarshalJSON_AccountPassword(t *testing.T) {
+
+	obj := RandForemanAuthSourceLDAP()
+
+	for _, password := range []string{"", "s3cr3t"} {
+		obj.AccountPassword = password
+
+		objBytes, _ := json.Marshal(obj)
+		var objMap map[string]interface{}
+		json.Unmarshal(objBytes, &objMap)
+
+		if _, ok := objMap["account_password"]; ok != (password != "") {
+			t.Errorf(
+				"ForemanAuthSourceLDAP MarshalJSON did not handle the account "+
+					"password [%s]. Got [%s]",
+				password,
+				objBytes,
+			)
+		}
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// setResourceDataFromForemanAuthSourceLDAP
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being set
+func TestSetResourceDataFromForemanAuthSourceLDAP_Value(t *testing.T) {
+
+	expectedObj := RandForemanAuthSourceLDAP()
+	expectedState := ForemanAuthSourceLDAPToInstanceState(expectedObj)
+	expectedResourceData := MockForemanAuthSourceLDAPResourceData(expectedState)
+
+	actualObj := api.ForemanAuthSourceLDAP{}
+	actualState := ForemanAuthSourceLDAPToInstanceState(actualObj)
+	actualResourceData := MockForemanAuthSourceLDAPResourceData(actualState)
+
+	setResourceDataFromForemanAuthSourceLDAP(actualResourceData, &expectedObj)
+
+	ForemanAuthSourceLDAPResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// Ensures the account password, which is never returned by the API, is kept
+func TestSetResourceDataFromForemanAuthSourceLDAP_AccountPassword(t *testing.T) {
+
+	obj := RandForemanAuthSourceLDAP()
+	obj.AccountPassword = "s3cr3t"
+	resourceData := MockForemanAuthSourceLDAPResourceData(ForemanAuthSourceLDAPToInstanceState(obj))
+
+	obj.AccountPassword = ""
+	setResourceDataFromForemanAuthSourceLDAP(resourceData, &obj)
+
+	if resourceData.Get("account_password").(string) != "s3cr3t" {
+		t.Fatalf(
+			"setResourceDataFromForemanAuthSourceLDAP replaced the account "+
+				"password. Expected [s3cr3t], got [%s].",
+			resourceData.Get("account_password"),
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// testForemanAuthSourceLDAPConnection
+// -----------------------------------------------------------------------------
+
+// Ensures the connection is only tested if enabled and a failed test fails
+// the operation
+func TestForemanAuthSourceLDAPConnection(t *testing.T) {
+
+	obj := RandForemanAuthSourceLDAP()
+	obj.Id = rand.Intn(100) + 1
+	testURI := AuthSourceLDAPsURI + "/" + strconv.Itoa(obj.Id) + "/test"
+
+	testCases := []struct {
+		testConnection bool
+		response       string
+		expectedTests  int
+		expectError    bool
+	}{
+		{testConnection: false, response: `{"success": false}`, expectedTests: 0},
+		{testConnection: true, response: `{"success": true, "message": "ok"}`, expectedTests: 1},
+		{testConnection: true, response: `{"success": false, "message": "Connection refused"}`, expectedTests: 1, expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+
+		tests := 0
+		mux.HandleFunc(testURI, func(w http.ResponseWriter, r *http.Request) {
+			tests++
+			if r.Method != http.MethodPut {
+				t.Errorf("Connection test used [%s]. Expected [PUT]", r.Method)
+			}
+			w.Write([]byte(testCase.response))
+		})
+
+		s := ForemanAuthSourceLDAPToInstanceState(obj)
+		s.Attributes["test_connection"] = strconv.FormatBool(testCase.testConnection)
+		diags := testForemanAuthSourceLDAPConnection(context.TODO(), MockForemanAuthSourceLDAPResourceData(s), client)
+		server.Close()
+
+		if tests != testCase.expectedTests || diags.HasError() != testCase.expectError {
+			t.Errorf(
+				"testForemanAuthSourceLDAPConnection with test_connection [%t] and "+
+					"response [%s] tested [%d] times with diagnostics [%+v]",
+				testCase.testConnection,
+				testCase.response,
+				tests,
+				diags,
+			)
+		}
+	}
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanAuthSourceLDAPCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := RandForemanAuthSourceLDAP()
+	obj.Id = rand.Intn(100)
+	s := ForemanAuthSourceLDAPToInstanceState(obj)
+	authSourceLDAPsURIById := AuthSourceLDAPsURI + "/" + strconv.Itoa(obj.Id)
+
+	return []TestCaseCorrectURLAndMethod{
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanAuthSourceLDAPCreate",
+				crudFunc:     resourceForemanAuthSourceLDAPCreate,
+				resourceData: MockForemanAuthSourceLDAPResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    AuthSourceLDAPsURI,
+					expectedMethod: http.MethodPost,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanAuthSourceLDAPRead",
+				crudFunc:     resourceForemanAuthSourceLDAPRead,
+				resourceData: MockForemanAuthSourceLDAPResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    authSourceLDAPsURIById,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanAuthSourceLDAPUpdate",
+				crudFunc:     resourceForemanAuthSourceLDAPUpdate,
+				resourceData: MockForemanAuthSourceLDAPResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    authSourceLDAPsURIById,
+					expectedMethod: http.MethodPut,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanAuthSourceLDAPDelete",
+				crudFunc:     resourceForemanAuthSourceLDAPDelete,
+				resourceData: MockForemanAuthSourceLDAPResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    authSourceLDAPsURIById,
+					expectedMethod: http.MethodDelete,
+				},
+			},
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func ResourceForemanAuthSourceLDAPRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanAuthSourceLDAP()
+	s := ForemanAuthSourceLDAPToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanAuthSourceLDAPRead",
+			crudFunc:     resourceForemanAuthSourceLDAPRead,
+			resourceData: MockForemanAuthSourceLDAPResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanAuthSourceLDAPDelete",
+			crudFunc:     resourceForemanAuthSourceLDAPDelete,
+			resourceData: MockForemanAuthSourceLDAPResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func ResourceForemanAuthSourceLDAPStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanAuthSourceLDAP()
+	s := ForemanAuthSourceLDAPToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanAuthSourceLDAPCreate",
+			crudFunc:     resourceForemanAuthSourceLDAPCreate,
+			resourceData: MockForemanAuthSourceLDAPResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanAuthSourceLDAPRead",
+			crudFunc:     resourceForemanAuthSourceLDAPRead,
+			resourceData: MockForemanAuthSourceLDAPResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanAuthSourceLDAPUpdate",
+			crudFunc:     resourceForemanAuthSourceLDAPUpdate,
+			resourceData: MockForemanAuthSourceLDAPResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanAuthSourceLDAPDelete",
+			crudFunc:     resourceForemanAuthSourceLDAPDelete,
+			resourceData: MockForemanAuthSourceLDAPResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func ResourceForemanAuthSourceLDAPEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanAuthSourceLDAP()
+	s := ForemanAuthSourceLDAPToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanAuthSourceLDAPCreate",
+			crudFunc:     resourceForemanAuthSourceLDAPCreate,
+			resourceData: MockForemanAuthSourceLDAPResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanAuthSourceLDAPRead",
+			crudFunc:     resourceForemanAuthSourceLDAPRead,
+			resourceData: MockForemanAuthSourceLDAPResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanAuthSourceLDAPUpdate",
+			crudFunc:     resourceForemanAuthSourceLDAPUpdate,
+			resourceData: MockForemanAuthSourceLDAPResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func ResourceForemanAuthSourceLDAPMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanAuthSourceLDAP()
+	s := ForemanAuthSourceLDAPToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper read response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanAuthSourceLDAPRead",
+				crudFunc:     resourceForemanAuthSourceLDAPRead,
+				resourceData: MockForemanAuthSourceLDAPResourceData(s),
+			},
+			responseFile: AuthSourceLDAPsTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanAuthSourceLDAPResourceDataFromFile(
+				t,
+				AuthSourceLDAPsTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanAuthSourceLDAPResourceDataCompare,
+		},
+	}
+
+}
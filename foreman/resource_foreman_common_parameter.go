@@ -21,6 +21,8 @@ func resourceForemanCommonParameter() *schema.Resource {
 		UpdateContext: resourceForemanCommonParameterUpdate,
 		DeleteContext: resourceForemanCommonParameterDelete,
 
+		CustomizeDiff: resourceParameterCustomizeDiffValue,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -42,9 +44,11 @@ func resourceForemanCommonParameter() *schema.Resource {
 				Required: true,
 			},
 			"value": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: parameterValueDiffSuppressFunc,
 			},
+			"parameter_type": parameterTypeSchema(),
 		},
 	}
 }
@@ -74,6 +78,9 @@ func buildForemanCommonParameter(d *schema.ResourceData) *api.ForemanCommonParam
 	if attr, ok = d.GetOk("value"); ok {
 		commonParameter.Value = attr.(string)
 	}
+	if attr, ok = d.GetOk("parameter_type"); ok {
+		commonParameter.ParameterType = attr.(string)
+	}
 	return &commonParameter
 }
 
@@ -85,6 +92,7 @@ func setResourceDataFromForemanCommonParameter(d *schema.ResourceData, fd *api.F
 	d.SetId(strconv.Itoa(fd.Id))
 	d.Set("name", fd.Name)
 	d.Set("value", fd.Value)
+	d.Set("parameter_type", parameterTypeOrDefault(fd.ParameterType))
 }
 
 // -----------------------------------------------------------------------------
@@ -101,7 +109,7 @@ func resourceForemanCommonParameterCreate(ctx context.Context, d *schema.Resourc
 
 	createdParam, createErr := client.CreateCommonParameter(ctx, p)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanCommonParameter: [%+v]", createdParam)
@@ -141,7 +149,7 @@ func resourceForemanCommonParameterUpdate(ctx context.Context, d *schema.Resourc
 
 	updatedParam, updateErr := client.UpdateCommonParameter(ctx, p, p.Id)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	log.Debugf("Updated ForemanCommonParameter: [%+v]", updatedParam)
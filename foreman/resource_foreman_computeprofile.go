@@ -163,7 +163,7 @@ func resourceForemanComputeprofileCreate(ctx context.Context, d *schema.Resource
 
 	createdComputeprofile, createErr := client.CreateComputeprofile(ctx, p)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanComputeprofile [%+v]", createdComputeprofile)
@@ -199,7 +199,7 @@ func resourceForemanComputeprofileUpdate(ctx context.Context, d *schema.Resource
 
 	cp, err := client.UpdateComputeProfile(ctx, p)
 	if err != nil {
-		return diag.FromErr(err)
+		return api.DiagnosticsFromError(d, err)
 	}
 
 	log.Debugf("Update compute_profile: %+v", cp)
@@ -95,6 +95,11 @@ func resourceForemanComputeResource() *schema.Resource {
 				Optional:    true,
 				Description: "Description of the compute resource",
 			},
+
+			// -- Taxonomies --
+
+			"location_ids":     locationIdsSchema("compute resource"),
+			"organization_ids": organizationIdsSchema("compute resource"),
 		},
 	}
 }
@@ -152,6 +157,8 @@ func buildForemanComputeResource(d *schema.ResourceData) *api.ForemanComputeReso
 		computeresource.Description = attr.(string)
 	}
 
+	computeresource.ForemanTaxonomies = buildForemanTaxonomies(d)
+
 	return &computeresource
 }
 
@@ -172,6 +179,7 @@ func setResourceDataFromForemanComputeResource(d *schema.ResourceData, fd *api.F
 	d.Set("setconsolepassword", fd.SetConsolePassword)
 	d.Set("cachingenabled", fd.CachingEnabled)
 	d.Set("description", fd.Description)
+	setResourceDataFromForemanTaxonomies(d, fd.ForemanTaxonomies)
 }
 
 // -----------------------------------------------------------------------------
@@ -117,7 +117,7 @@ func resourceForemanDefaultTemplateCreate(ctx context.Context, d *schema.Resourc
 
 	createdParam, createErr := client.CreateDefaultTemplate(ctx, p)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanDefaultTemplate: [%+v]", createdParam)
@@ -157,7 +157,7 @@ func resourceForemanDefaultTemplateUpdate(ctx context.Context, d *schema.Resourc
 
 	updatedParam, updateErr := client.UpdateDefaultTemplate(ctx, p, p.Id)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	log.Debugf("Updated ForemanDefaultTemplate: [%+v]", updatedParam)
@@ -11,6 +11,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceForemanDomain() *schema.Resource {
@@ -54,6 +55,29 @@ func resourceForemanDomain() *schema.Resource {
 				Description: "Description of the domain",
 			},
 
+			"dns_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "ID of the smart proxy with the DNS feature which " +
+					"manages the DNS records of the domain.",
+			},
+
+			"subnet_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the subnets the domain is assigned to. Defaults to " +
+					"the current subnets.",
+			},
+
+			"location_ids": locationIdsSchema("domain"),
+
+			"organization_ids": organizationIdsSchema("domain"),
+
 			"parameters": {
 				Type:     schema.TypeMap,
 				ForceNew: false,
@@ -91,8 +115,12 @@ func buildForemanDomain(d *schema.ResourceData) *api.ForemanDomain {
 		domain.Fullname = attr.(string)
 	}
 
+	domain.DnsId = d.Get("dns_id").(int)
+	domain.SubnetIds = buildForemanIds(d, "subnet_ids")
+	domain.ForemanTaxonomies = buildForemanTaxonomies(d)
+
 	if attr, ok = d.GetOk("parameters"); ok {
-		domain.DomainParameters = api.ToKV(attr.(map[string]interface{}))
+		domain.DomainParameters = api.ToKV(attr.(map[string]interface{}), nil)
 	}
 
 	return &domain
@@ -106,6 +134,12 @@ func setResourceDataFromForemanDomain(d *schema.ResourceData, fd *api.ForemanDom
 	d.SetId(strconv.Itoa(fd.Id))
 	d.Set("name", fd.Name)
 	d.Set("fullname", fd.Fullname)
+	d.Set("dns_id", fd.DnsId)
+	// Subnets missing in the API response are left untouched
+	if fd.SubnetIds != nil {
+		d.Set("subnet_ids", fd.SubnetIds)
+	}
+	setResourceDataFromForemanTaxonomies(d, fd.ForemanTaxonomies)
 	d.Set("parameters", api.FromKV(fd.DomainParameters))
 }
 
@@ -123,7 +157,7 @@ func resourceForemanDomainCreate(ctx context.Context, d *schema.ResourceData, me
 
 	createdDomain, createErr := client.CreateDomain(ctx, p)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanDomain: [%+v]", createdDomain)
@@ -162,7 +196,7 @@ func resourceForemanDomainUpdate(ctx context.Context, d *schema.ResourceData, me
 
 	updatedDomain, updateErr := client.UpdateDomain(ctx, do, do.Id)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	log.Debugf("Updated ForemanDomain: [%+v]", updatedDomain)
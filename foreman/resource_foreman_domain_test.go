@@ -2,6 +2,7 @@ package foreman
 
 import (
 	"encoding/json"
+	"fmt"
 	"math/rand"
 	"net/http"
 	"reflect"
@@ -30,6 +31,18 @@ func ForemanDomainToInstanceState(obj api.ForemanDomain) *terraform.InstanceStat
 	attr := map[string]string{}
 	attr["name"] = obj.Name
 	attr["fullname"] = obj.Fullname
+	attr["dns_id"] = strconv.Itoa(obj.DnsId)
+	idSets := map[string][]int{
+		"subnet_ids":       obj.SubnetIds,
+		"location_ids":     obj.LocationIds,
+		"organization_ids": obj.OrganizationIds,
+	}
+	for key, ids := range idSets {
+		attr[key+".#"] = strconv.Itoa(len(ids))
+		for idx, val := range ids {
+			attr[fmt.Sprintf("%s.%d", key, idx)] = strconv.Itoa(val)
+		}
+	}
 	state.Attributes = attr
 	return &state
 }
@@ -58,6 +71,10 @@ func RandForemanDomain() api.ForemanDomain {
 	obj.ForemanObject = fo
 
 	obj.Fullname = tfrand.String(20, tfrand.Lower+".")
+	obj.DnsId = rand.Intn(100) + 1
+	obj.SubnetIds = []int{rand.Intn(100) + 1}
+	obj.LocationIds = []int{rand.Intn(100) + 1}
+	obj.OrganizationIds = []int{rand.Intn(100) + 1}
 
 	return obj
 }
@@ -120,6 +137,81 @@ func TestDomainUnmarshalJSON_ForemanObject(t *testing.T) {
 
 }
 
+// Ensures the JSON unmarshal reconciles the nested subnets and taxonomies of
+// the API response
+func TestDomainUnmarshalJSON_NestedObjects(t *testing.T) {
+
+	var obj api.ForemanDomain
+	ParseJSONFile(t, DomainsTestDataPath+"/read_response.json", &obj)
+
+	if obj.DnsId != 39 ||
+		!reflect.DeepEqual(obj.SubnetIds, []int{303, 304, 305, 306, 307, 308}) ||
+		!reflect.DeepEqual(obj.LocationIds, []int{2}) ||
+		!reflect.DeepEqual(obj.OrganizationIds, []int{1}) {
+		t.Errorf(
+			"ForemanDomain UnmarshalJSON did not properly decode the nested "+
+				"objects. Got [%+v]",
+			obj,
+		)
+	}
+
+	// Search results do not contain the subnets - they are left nil
+	var searchObj api.ForemanDomain
+	json.Unmarshal([]byte(`{"id": 39, "name": "dev.company.com"}`), &searchObj)
+	if searchObj.SubnetIds != nil {
+		t.Errorf(
+			"ForemanDomain UnmarshalJSON set the subnets of a response without "+
+				"subnets. Got [%+v]",
+			searchObj.SubnetIds,
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// MarshalJSON
+// -----------------------------------------------------------------------------
+
+// Ensures an unset DNS proxy is sent as null and the subnets are only sent if
+// they are managed
+func TestDomainMarshalJSON(t *testing.T) {
+
+	testCases := []struct {
+		dnsId             int
+		subnetIds         []int
+		expectedDnsId     interface{}
+		expectedSubnetIds interface{}
+	}{
+		{dnsId: 0, subnetIds: nil, expectedDnsId: nil, expectedSubnetIds: nil},
+		{dnsId: 3, subnetIds: []int{}, expectedDnsId: "3", expectedSubnetIds: []interface{}{}},
+		{dnsId: 3, subnetIds: []int{5}, expectedDnsId: "3", expectedSubnetIds: []interface{}{float64(5)}},
+	}
+
+	for _, testCase := range testCases {
+		obj := RandForemanDomain()
+		obj.DnsId = testCase.dnsId
+		obj.SubnetIds = testCase.subnetIds
+
+		objBytes, _ := json.Marshal(obj)
+		var objMap map[string]interface{}
+		json.Unmarshal(objBytes, &objMap)
+
+		dnsId, dnsOk := objMap["dns_id"]
+		subnetIds := objMap["subnet_ids"]
+		if !dnsOk || dnsId != testCase.expectedDnsId ||
+			!reflect.DeepEqual(subnetIds, testCase.expectedSubnetIds) {
+			t.Errorf(
+				"ForemanDomain MarshalJSON did not encode dns_id [%d] and "+
+					"subnet_ids [%v] correctly. Got [%s]",
+				testCase.dnsId,
+				testCase.subnetIds,
+				objBytes,
+			)
+		}
+	}
+
+}
+
 // -----------------------------------------------------------------------------
 // setResourceDataFromForemanDomain
 // -----------------------------------------------------------------------------
@@ -46,6 +46,11 @@ func resourceForemanEnvironment() *schema.Resource {
 					autodoc.MetaExample,
 				),
 			},
+
+			// -- Taxonomies --
+
+			"location_ids":     locationIdsSchema("environment"),
+			"organization_ids": organizationIdsSchema("environment"),
 		},
 	}
 }
@@ -73,6 +78,8 @@ func buildForemanEnvironment(d *schema.ResourceData) *api.ForemanEnvironment {
 		environment.Name = attr.(string)
 	}
 
+	environment.ForemanTaxonomies = buildForemanTaxonomies(d)
+
 	return &environment
 }
 
@@ -83,6 +90,7 @@ func setResourceDataFromForemanEnvironment(d *schema.ResourceData, fe *api.Forem
 
 	d.SetId(strconv.Itoa(fe.Id))
 	d.Set("name", fe.Name)
+	setResourceDataFromForemanTaxonomies(d, fe.ForemanTaxonomies)
 }
 
 // -----------------------------------------------------------------------------
@@ -99,7 +107,7 @@ func resourceForemanEnvironmentCreate(ctx context.Context, d *schema.ResourceDat
 
 	createdEnv, createErr := client.CreateEnvironment(ctx, e)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanEnvironment: [%+v]", createdEnv)
@@ -139,7 +147,7 @@ func resourceForemanEnvironmentUpdate(ctx context.Context, d *schema.ResourceDat
 
 	updatedEnv, updateErr := client.UpdateEnvironment(ctx, e)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	log.Debugf("Updated ForemanEnvironment: [%+v]", updatedEnv)
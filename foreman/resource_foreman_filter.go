@@ -0,0 +1,355 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanFilter() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanFilterCreate,
+		ReadContext:   resourceForemanFilterRead,
+		UpdateContext: resourceForemanFilterUpdate,
+		DeleteContext: resourceForemanFilterDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CustomizeDiff: resourceForemanFilterCustomizeDiffTaxonomies,
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s A filter grants permissions for the resources of one type to "+
+						"the members of its role, optionally limited by a search.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"role_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the role the filter belongs to",
+			},
+
+			"permissions": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				Description: fmt.Sprintf(
+					"Names of the permissions granted by the filter. All permissions "+
+						"must apply to the same resource type."+
+						"%s [\"view_hosts\", \"edit_hosts\"]",
+					autodoc.MetaExample,
+				),
+			},
+
+			"resource_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"Type of the resources the permissions apply to. Foreman derives "+
+						"it from the permissions - if set, the permissions are checked "+
+						"to apply to this type. Defaults to the type of the permissions."+
+						"%s \"Host\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"permission_ids": {
+				Type:     schema.TypeSet,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the permissions granted by the filter",
+			},
+
+			"search": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: fmt.Sprintf(
+					"Search limiting the resources the permissions apply to. The "+
+						"permissions apply to all resources of the type if unset."+
+						"%s \"hostgroup = web\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"unlimited": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the permissions apply to all resources of the type",
+			},
+
+			// -- Taxonomies --
+
+			"override": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether the filter overrides the locations and " +
+					"organizations of its role with its own `location_ids` and " +
+					"`organization_ids`. Defaults to `false`.",
+			},
+			"location_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the locations the filter is limited to. Can only " +
+					"be set if `override` is `true`. Defaults to the locations of the role.",
+			},
+			"organization_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the organizations the filter is limited to. Can " +
+					"only be set if `override` is `true`. Defaults to the organizations " +
+					"of the role.",
+			},
+		},
+	}
+}
+
+// resourceForemanFilterCustomizeDiffTaxonomies rejects taxonomies of filters
+// which do not override the taxonomies of their role - Foreman replaces them
+// with the taxonomies of the role.
+func resourceForemanFilterCustomizeDiffTaxonomies(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("override").(bool) {
+		return nil
+	}
+
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+	for _, key := range []string{"location_ids", "organization_ids"} {
+		if !config.GetAttr(key).IsNull() {
+			return fmt.Errorf(
+				"%s can only be set if override is true. Filters which do not "+
+					"override the taxonomies use the taxonomies of their role",
+				key,
+			)
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanFilter constructs a ForemanFilter reference from a resource data
+// reference.  The struct's  members are populated from the data populated in
+// the resource data.  Missing members will be left to the zero value for that
+// member's type.  The permission names are not resolved to their IDs.
+func buildForemanFilter(d *schema.ResourceData) *api.ForemanFilter {
+	log.Tracef("resource_foreman_filter.go#buildForemanFilter")
+
+	filter := api.ForemanFilter{}
+
+	obj := buildForemanObject(d)
+	filter.ForemanObject = *obj
+
+	filter.RoleId = d.Get("role_id").(int)
+	filter.Search = d.Get("search").(string)
+	filter.ResourceType = d.Get("resource_type").(string)
+	filter.Override = d.Get("override").(bool)
+
+	filter.PermissionNames = []string{}
+	for _, name := range d.Get("permissions").(*schema.Set).List() {
+		filter.PermissionNames = append(filter.PermissionNames, name.(string))
+	}
+
+	filter.ForemanTaxonomies = buildForemanTaxonomies(d)
+
+	return &filter
+}
+
+// setResourceDataFromForemanFilter sets a ResourceData's attributes from the
+// attributes of the supplied ForemanFilter reference
+func setResourceDataFromForemanFilter(d *schema.ResourceData, ff *api.ForemanFilter) {
+	log.Tracef("resource_foreman_filter.go#setResourceDataFromForemanFilter")
+
+	d.SetId(strconv.Itoa(ff.Id))
+	d.Set("role_id", ff.RoleId)
+	d.Set("permissions", ff.PermissionNames)
+	d.Set("permission_ids", ff.PermissionIds)
+	d.Set("resource_type", ff.ResourceType)
+	d.Set("search", ff.Search)
+	d.Set("unlimited", ff.Unlimited)
+	d.Set("override", ff.Override)
+	setResourceDataFromForemanTaxonomies(d, ff.ForemanTaxonomies)
+}
+
+// resolveForemanFilterPermissions sets the IDs of the permissions of the
+// supplied ForemanFilter from their names.  Fails if a permission does not
+// exist or if the permissions apply to different resource types.
+func resolveForemanFilterPermissions(ctx context.Context, client *api.Client, ff *api.ForemanFilter) error {
+	log.Tracef("resource_foreman_filter.go#resolveForemanFilterPermissions")
+
+	permissions, searchErr := client.SearchPermissionsByName(ctx, ff.PermissionNames)
+	if searchErr != nil {
+		return searchErr
+	}
+
+	permissionsByName := map[string]api.ForemanPermission{}
+	for _, permission := range permissions {
+		permissionsByName[permission.Name] = permission
+	}
+
+	ff.PermissionIds = []int{}
+	unknown := []string{}
+	resourceTypes := map[string]bool{}
+	for _, name := range ff.PermissionNames {
+		permission, ok := permissionsByName[name]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		ff.PermissionIds = append(ff.PermissionIds, permission.Id)
+		resourceTypes[permission.ResourceType] = true
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown permissions [%s]", strings.Join(unknown, ", "))
+	}
+
+	if len(resourceTypes) > 1 {
+		types := []string{}
+		for resourceType := range resourceTypes {
+			types = append(types, resourceType)
+		}
+		sort.Strings(types)
+		return fmt.Errorf(
+			"the permissions of a filter must apply to the same resource type, "+
+				"got [%s]",
+			strings.Join(types, ", "),
+		)
+	}
+
+	if ff.ResourceType != "" && !resourceTypes[ff.ResourceType] {
+		return fmt.Errorf(
+			"the permissions do not apply to the resource type [%s]",
+			ff.ResourceType,
+		)
+	}
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanFilterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_filter.go#Create")
+
+	client := meta.(*api.Client)
+	f := buildForemanFilter(d)
+
+	resolveErr := resolveForemanFilterPermissions(ctx, client, f)
+	if resolveErr != nil {
+		return diag.FromErr(resolveErr)
+	}
+
+	log.Debugf("ForemanFilter: [%+v]", f)
+
+	createdFilter, createErr := client.CreateFilter(ctx, f)
+	if createErr != nil {
+		return api.DiagnosticsFromError(d, createErr)
+	}
+
+	log.Debugf("Created ForemanFilter: [%+v]", createdFilter)
+
+	setResourceDataFromForemanFilter(d, createdFilter)
+
+	return nil
+}
+
+func resourceForemanFilterRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_filter.go#Read")
+
+	client := meta.(*api.Client)
+	f := buildForemanFilter(d)
+
+	log.Debugf("ForemanFilter: [%+v]", f)
+
+	readFilter, readErr := client.ReadFilter(ctx, f.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanFilter: [%+v]", readFilter)
+
+	setResourceDataFromForemanFilter(d, readFilter)
+
+	return nil
+}
+
+func resourceForemanFilterUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_filter.go#Update")
+
+	client := meta.(*api.Client)
+	f := buildForemanFilter(d)
+
+	resolveErr := resolveForemanFilterPermissions(ctx, client, f)
+	if resolveErr != nil {
+		return diag.FromErr(resolveErr)
+	}
+
+	log.Debugf("ForemanFilter: [%+v]", f)
+
+	updatedFilter, updateErr := client.UpdateFilter(ctx, f)
+	if updateErr != nil {
+		return api.DiagnosticsFromError(d, updateErr)
+	}
+
+	log.Debugf("Updated ForemanFilter: [%+v]", updatedFilter)
+
+	setResourceDataFromForemanFilter(d, updatedFilter)
+
+	return nil
+}
+
+func resourceForemanFilterDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_filter.go#Delete")
+
+	client := meta.(*api.Client)
+	f := buildForemanFilter(d)
+
+	log.Debugf("ForemanFilter: [%+v]", f)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return diag.FromErr(api.CheckDeleted(d, client.DeleteFilter(ctx, f.Id)))
+}
@@ -0,0 +1,407 @@
+package foreman
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const FiltersURI = api.FOREMAN_API_URL_PREFIX + "/filters"
+const FiltersTestDataPath = "testdata/1.11/filters"
+
+// Given a ForemanFilter, create a mock instance state reference
+func ForemanFilterToInstanceState(obj api.ForemanFilter) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = strconv.Itoa(obj.Id)
+	// Build the attribute map from ForemanFilter
+	attr := map[string]string{}
+	attr["role_id"] = strconv.Itoa(obj.RoleId)
+	attr["search"] = obj.Search
+	attr["resource_type"] = obj.ResourceType
+	attr["unlimited"] = strconv.FormatBool(obj.Unlimited)
+	attr["override"] = strconv.FormatBool(obj.Override)
+	attr["permissions.#"] = strconv.Itoa(len(obj.PermissionNames))
+	for idx, name := range obj.PermissionNames {
+		attr["permissions."+strconv.Itoa(idx)] = name
+	}
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanFilter resource, create a mock
+// ResourceData reference.
+func MockForemanFilterResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanFilter()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates a filter ResourceData
+// reference
+func MockForemanFilterResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanFilter
+	ParseJSONFile(t, path, &obj)
+	s := ForemanFilterToInstanceState(obj)
+	return MockForemanFilterResourceData(s)
+}
+
+// Creates a random ForemanFilter struct
+func RandForemanFilter() api.ForemanFilter {
+	obj := api.ForemanFilter{}
+
+	fo := RandForemanObject()
+	fo.Name = ""
+	obj.ForemanObject = fo
+
+	obj.RoleId = rand.Intn(100) + 1
+	obj.Search = "hostgroup = " + fo.CreatedAt
+	obj.ResourceType = "Host"
+	obj.PermissionNames = []string{"view_hosts"}
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanFilter resource.  If the
+// two references differ in their attributes, the test will raise a fatal.
+func ForemanFilterResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := resourceForemanFilter()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+	if !r1.Get("permissions").(*schema.Set).Equal(r2.Get("permissions")) {
+		t.Fatalf(
+			"ResourceData references differ in attribute [permissions]. [%v], [%v]",
+			r1.Get("permissions"),
+			r2.Get("permissions"),
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// MarshalJSON / UnmarshalJSON
+// -----------------------------------------------------------------------------
+
+// Ensures the JSON unmarshal decodes the role, permissions and taxonomies from
+// the nested objects of the API response
+func TestFilterUnmarshalJSON_Permissions(t *testing.T) {
+
+	var obj api.ForemanFilter
+	ParseJSONFile(t, FiltersTestDataPath+"/read_response.json", &obj)
+
+	if obj.RoleId != 12 || obj.ResourceType != "Host" ||
+		!reflect.DeepEqual(obj.PermissionIds, []int{74, 76}) ||
+		!reflect.DeepEqual(obj.PermissionNames, []string{"view_hosts", "edit_hosts"}) ||
+		!reflect.DeepEqual(obj.LocationIds, []int{2}) {
+		t.Errorf(
+			"ForemanFilter UnmarshalJSON did not properly decode the nested "+
+				"objects. Got [%+v]",
+			obj,
+		)
+	}
+
+}
+
+// Ensures the taxonomies are only sent for filters overriding the taxonomies
+// of their role
+func TestFilterMarshalJSON_Override(t *testing.T) {
+
+	obj := RandForemanFilter()
+	obj.PermissionIds = []int{74}
+	obj.LocationIds = []int{2}
+
+	for _, override := range []bool{false, true} {
+		obj.Override = override
+
+		objBytes, _ := json.Marshal(obj)
+		var objMap map[string]interface{}
+		json.Unmarshal(objBytes, &objMap)
+
+		if _, ok := objMap["location_ids"]; ok != override {
+			t.Errorf(
+				"ForemanFilter MarshalJSON sent location_ids [%t] for override [%t]. "+
+					"Got [%s]",
+				ok,
+				override,
+				objBytes,
+			)
+		}
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// resolveForemanFilterPermissions
+// -----------------------------------------------------------------------------
+
+// Ensures the permission names are resolved to their IDs and permissions
+// which do not exist or apply to different resource types are rejected
+func TestResolveForemanFilterPermissions(t *testing.T) {
+
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(PermissionsURI, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"total": 3, "subtotal": 3, "page": 1, "per_page": 20, "results": [
+			{"id": 74, "name": "view_hosts", "resource_type": "Host"},
+			{"id": 76, "name": "edit_hosts", "resource_type": "Host"},
+			{"id": 81, "name": "view_hostgroups", "resource_type": "Hostgroup"}
+		]}`))
+	})
+
+	testCases := []struct {
+		resourceType string
+		names        []string
+		expectedIds  []int
+		expectedErr  string
+	}{
+		{names: []string{"edit_hosts", "view_hosts"}, expectedIds: []int{76, 74}},
+		{resourceType: "Host", names: []string{"view_hosts"}, expectedIds: []int{74}},
+		{resourceType: "Hostgroup", names: []string{"view_hosts"}, expectedErr: "resource type [Hostgroup]"},
+		{names: []string{"view_hosts", "view_hostgroups"}, expectedErr: "[Host, Hostgroup]"},
+		{names: []string{"view_hosts", "destroy_everything"}, expectedErr: "unknown permissions [destroy_everything]"},
+	}
+
+	for _, testCase := range testCases {
+		obj := api.ForemanFilter{
+			ResourceType:    testCase.resourceType,
+			PermissionNames: testCase.names,
+		}
+		err := resolveForemanFilterPermissions(context.TODO(), client, &obj)
+
+		if testCase.expectedErr != "" {
+			if err == nil || !strings.Contains(err.Error(), testCase.expectedErr) {
+				t.Errorf(
+					"resolveForemanFilterPermissions did not reject the permissions "+
+						"[%v]. Expected [%s], got [%v]",
+					testCase.names,
+					testCase.expectedErr,
+					err,
+				)
+			}
+			continue
+		}
+
+		if err != nil || !reflect.DeepEqual(obj.PermissionIds, testCase.expectedIds) {
+			t.Errorf(
+				"resolveForemanFilterPermissions did not resolve the permissions "+
+					"[%v]. Expected [%v], got [%v] with error [%v]",
+				testCase.names,
+				testCase.expectedIds,
+				obj.PermissionIds,
+				err,
+			)
+		}
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// setResourceDataFromForemanFilter
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being set
+func TestSetResourceDataFromForemanFilter_Value(t *testing.T) {
+
+	expectedObj := RandForemanFilter()
+	expectedState := ForemanFilterToInstanceState(expectedObj)
+	expectedResourceData := MockForemanFilterResourceData(expectedState)
+
+	actualObj := api.ForemanFilter{}
+	actualState := ForemanFilterToInstanceState(actualObj)
+	actualResourceData := MockForemanFilterResourceData(actualState)
+
+	setResourceDataFromForemanFilter(actualResourceData, &expectedObj)
+
+	ForemanFilterResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanFilterCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := RandForemanFilter()
+	obj.Id = rand.Intn(100)
+	s := ForemanFilterToInstanceState(obj)
+	filtersURIById := FiltersURI + "/" + strconv.Itoa(obj.Id)
+
+	return []TestCaseCorrectURLAndMethod{
+		// The permission names are resolved first
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanFilterCreate",
+				crudFunc:     resourceForemanFilterCreate,
+				resourceData: MockForemanFilterResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    PermissionsURI,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanFilterRead",
+				crudFunc:     resourceForemanFilterRead,
+				resourceData: MockForemanFilterResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    filtersURIById,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanFilterDelete",
+				crudFunc:     resourceForemanFilterDelete,
+				resourceData: MockForemanFilterResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    filtersURIById,
+					expectedMethod: http.MethodDelete,
+				},
+			},
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func ResourceForemanFilterRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanFilter()
+	s := ForemanFilterToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanFilterRead",
+			crudFunc:     resourceForemanFilterRead,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanFilterDelete",
+			crudFunc:     resourceForemanFilterDelete,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func ResourceForemanFilterStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanFilter()
+	s := ForemanFilterToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanFilterCreate",
+			crudFunc:     resourceForemanFilterCreate,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanFilterRead",
+			crudFunc:     resourceForemanFilterRead,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanFilterUpdate",
+			crudFunc:     resourceForemanFilterUpdate,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanFilterDelete",
+			crudFunc:     resourceForemanFilterDelete,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func ResourceForemanFilterEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanFilter()
+	s := ForemanFilterToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanFilterCreate",
+			crudFunc:     resourceForemanFilterCreate,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanFilterRead",
+			crudFunc:     resourceForemanFilterRead,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanFilterUpdate",
+			crudFunc:     resourceForemanFilterUpdate,
+			resourceData: MockForemanFilterResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func ResourceForemanFilterMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanFilter()
+	s := ForemanFilterToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper read response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanFilterRead",
+				crudFunc:     resourceForemanFilterRead,
+				resourceData: MockForemanFilterResourceData(s),
+			},
+			responseFile: FiltersTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanFilterResourceDataFromFile(
+				t,
+				FiltersTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanFilterResourceDataCompare,
+		},
+	}
+
+}
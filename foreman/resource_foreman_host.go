@@ -304,12 +304,22 @@ func resourceForemanHost() *schema.Resource {
 
 		CustomizeDiff: customdiff.All(
 			resourceForemanHostCustomizeDiffComputeAttributes,
+			resourceForemanHostCustomizeDiffInterfaces,
+			resourceForemanHostCustomizeDiffInterfacesComputed,
+			resourceParametersCustomizeDiffValues,
 		),
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		// Waiting for the build of the host is bound to the create and update
+		// timeouts
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+		},
+
 		SchemaVersion: 1,
 		StateUpgraders: []schema.StateUpgrader{
 			{
@@ -422,10 +432,13 @@ func resourceForemanHost() *schema.Resource {
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
+				DiffSuppressFunc: parametersDiffSuppressFunc,
 				Description: "A map of parameters that will be saved as host parameters " +
 					"in the machine config.",
 			},
 
+			"parameter_types": parameterTypesSchema("host"),
+
 			"enable_bmc": {
 				Type:     schema.TypeBool,
 				Optional: true,
@@ -457,11 +470,48 @@ func resourceForemanHost() *schema.Resource {
 				Description: "Manage power operations, e.g. power on, if host's build flag will be enabled.",
 			},
 
+			"power_state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{api.PowerOn, api.PowerOff}, false),
+				Description: "Desired power state of the host, either `on` or `off`. The power " +
+					"state is read back through the compute resource or the BMC interface " +
+					"of the host, changes made outside of Terraform show up in the plan. " +
+					"If the build flag is set on a powered on host, the host is rebooted " +
+					"to start the build. Leave unset to not manage the power state.",
+			},
+
+			"wait_for_build": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Wait until Foreman reports the host as built after creating " +
+					"or rebuilding it. The apply fails if the build fails, the build token " +
+					"expires or the build does not finish within the `create` or `update` " +
+					"timeout of the resource (`timeouts` block, 60 minutes by default). " +
+					"Defaults to `false`.",
+			},
+
+			"rebuild_triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Arbitrary map of values that rebuilds the host when changed, " +
+					"ie: the version of a kickstart template. The host keeps its ID, facts " +
+					"and reports: the build flag is set and the host is booted into the " +
+					"build, through PXE if `enable_bmc` is set. Adding the map to an " +
+					"existing host does not rebuild it, neither does removing entries " +
+					"from the map - only adding or changing entries of a non-empty map " +
+					"does. A changed `power_state` is applied after the rebuild.",
+			},
+
 			"retry_count": {
 				Type:         schema.TypeInt,
 				Optional:     true,
 				Default:      2,
-				Description:  "Number of times to retry on a failed attempt to register or delete a host in foreman.",
+				Description:  "Number of times to check whether a host was deleted in foreman, waiting 2 seconds between the checks. Failed API requests are retried as configured by the provider's `client_retry_*` arguments.",
 				ValidateFunc: validation.IntAtLeast(1),
 			},
 
@@ -571,6 +621,23 @@ func resourceForemanHost() *schema.Resource {
 				ValidateFunc: validation.IntAtLeast(0),
 				Description:  "ID of the hostgroup to assign to the host.",
 			},
+			"location_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "ID of the location of the host. Overrides the " +
+					"provider's `location_id`. Defaults to the location of the provider.",
+			},
+			"organization_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "ID of the organization of the host. Overrides the " +
+					"provider's `organization_id`. Defaults to the organization of the " +
+					"provider.",
+			},
 			"image_id": {
 				Type:         schema.TypeInt,
 				Optional:     true,
@@ -698,9 +765,12 @@ func resourceForemanInterfacesAttributes() *schema.Resource {
 				Description:  "ID of the subnet to associate with this interface.",
 			},
 			"identifier": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Identifier of this interface local to the host.",
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Identifier of this interface local to the host. Interfaces " +
+					"are matched with the interfaces of the host by their identifier or " +
+					"configured MAC address, set either to remove or reorder interfaces " +
+					"safely.",
 			},
 			"managed": {
 				Type:        schema.TypeBool,
@@ -728,7 +798,7 @@ func resourceForemanInterfacesAttributes() *schema.Resource {
 			"attached_devices": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Identifiers of attached interfaces, e.g. 'eth1', 'eth2' as comma-separated list",
+				Description: "Identifiers of the interfaces attached to a `\"bond\"` or `\"bridge\"` interface, e.g. 'eth1', 'eth2' as comma-separated list",
 			},
 			"username": {
 				Type:        schema.TypeString,
@@ -752,7 +822,45 @@ func resourceForemanInterfacesAttributes() *schema.Resource {
 					// NOTE(ALL): false - do not ignore case when comparing values
 				}, false),
 				Description: "The type of interface. Values include: `\"interface\"`, " +
-					"`\"bmc\"`, `\"bond\"`, `\"bridge\"`.",
+					"`\"bmc\"`, `\"bond\"`, `\"bridge\"`. A VLAN is a virtual " +
+					"`\"interface\"` with a `tag`, attached to its parent interface.",
+			},
+			"tag": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 4094),
+				Description: "VLAN ID of the interface. Only applies to virtual " +
+					"interfaces, which are attached to their parent interface with " +
+					"`attached_to`.",
+			},
+			"mtu": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(68, 65536),
+				Description:  "MTU of the interface. Overrides the MTU of the subnet.",
+			},
+			"mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"balance-rr",
+					"active-backup",
+					"balance-xor",
+					"broadcast",
+					"802.3ad",
+					"balance-tlb",
+					"balance-alb",
+				}, false),
+				Description: "Bonding mode of a `\"bond\"` interface, ie: `\"802.3ad\"` " +
+					"for LACP. Foreman defaults to `\"balance-rr\"`.",
+			},
+			"bond_options": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Space separated options of a `\"bond\"` interface, ie: " +
+					"`\"miimon=100 lacp_rate=fast\"`.",
 			},
 			// Provider used for BMC/IPMI calls. (Default: IPMI)
 			"bmc_provider": {
@@ -817,6 +925,7 @@ func buildForemanHost(d *schema.ResourceData) *api.ForemanHost {
 	host.ProvisionMethod = d.Get("provision_method").(string)
 	host.Managed = d.Get("managed").(bool)
 	host.Build = d.Get("set_build_flag").(bool)
+	host.EnableBMC = d.Get("enable_bmc").(bool)
 	host.Token = d.Get("token").(string)
 
 	ownerId := d.Get("owner_id").(int)
@@ -835,6 +944,13 @@ func buildForemanHost(d *schema.ResourceData) *api.ForemanHost {
 	if hostgroupId != 0 {
 		host.HostgroupId = &hostgroupId
 	}
+	// Only configured taxonomies override the provider's
+	if isConfigured(d, "location_id") {
+		host.LocationId = d.Get("location_id").(int)
+	}
+	if isConfigured(d, "organization_id") {
+		host.OrganizationId = d.Get("organization_id").(int)
+	}
 	architectureId := d.Get("architecture_id").(int)
 	if architectureId != 0 {
 		host.ArchitectureId = &architectureId
@@ -893,7 +1009,10 @@ func buildForemanHost(d *schema.ResourceData) *api.ForemanHost {
 	}
 
 	if attr, ok = d.GetOk("parameters"); ok {
-		host.HostParameters = api.ToKV(attr.(map[string]interface{}))
+		host.HostParameters = api.ToKV(
+			attr.(map[string]interface{}),
+			d.Get("parameter_types").(map[string]interface{}),
+		)
 	}
 
 	if attr, ok = d.GetOk("root_password"); ok {
@@ -920,20 +1039,18 @@ func buildForemanInterfacesAttributes(d *schema.ResourceData) []api.ForemanInter
 		return tempIntAttr
 	}
 
-	// type assert the underlying *schema.Set and convert to a list
-	attrList := attr.([]interface{})
-	attrListLen := len(attrList)
-
-	tempIntAttr = make([]api.ForemanInterfacesAttribute, attrListLen)
+	return listToForemanInterfacesAttributes(attr.([]interface{}))
+}
 
-	// iterate over each of the map structure entires in the set and convert that
-	// to a concrete struct implementation to append to the interfaces
-	// attributes list.
+// listToForemanInterfacesAttributes converts each of the map structure
+// entries of the "interfaces_attributes" list to a concrete
+// ForemanInterfacesAttribute struct
+func listToForemanInterfacesAttributes(attrList []interface{}) []api.ForemanInterfacesAttribute {
+	tempIntAttr := make([]api.ForemanInterfacesAttribute, len(attrList))
 	for idx, attrMap := range attrList {
 		tempIntAttrMap := attrMap.(map[string]interface{})
 		tempIntAttr[idx] = mapToForemanInterfacesAttribute(tempIntAttrMap)
 	}
-
 	return tempIntAttr
 }
 
@@ -959,6 +1076,10 @@ func buildForemanInterfacesAttributes(d *schema.ResourceData) []api.ForemanInter
 //   password (string)
 //   type (string)
 //   bmc_provider (string)
+//   tag (int)
+//   mtu (int)
+//   mode (string)
+//   bond_options (string)
 //   _destroy (bool)
 
 func mapToForemanInterfacesAttribute(m map[string]interface{}) api.ForemanInterfacesAttribute {
@@ -1031,6 +1152,22 @@ func mapToForemanInterfacesAttribute(m map[string]interface{}) api.ForemanInterf
 		tempIntAttr.AttachedDevices = ""
 	}
 
+	if tag, ok := m["tag"].(int); ok && tag > 0 {
+		tempIntAttr.Tag = strconv.Itoa(tag)
+	}
+
+	if tempIntAttr.MTU, ok = m["mtu"].(int); !ok {
+		tempIntAttr.MTU = 0
+	}
+
+	if tempIntAttr.Mode, ok = m["mode"].(string); !ok {
+		tempIntAttr.Mode = ""
+	}
+
+	if tempIntAttr.BondOptions, ok = m["bond_options"].(string); !ok {
+		tempIntAttr.BondOptions = ""
+	}
+
 	if tempIntAttr.ComputeAttributes, ok = m["compute_attributes"].(map[string]interface{}); !ok {
 		tempIntAttr.ComputeAttributes = nil
 	}
@@ -1043,6 +1180,176 @@ func mapToForemanInterfacesAttribute(m map[string]interface{}) api.ForemanInterf
 	return tempIntAttr
 }
 
+// findForemanInterfacesAttribute returns the index of the interface in ifaces
+// which matches the supplied interface or -1.  Interfaces are matched by their
+// ID, their identifier or their MAC address - in that order.  Interfaces
+// whose index is claimed are skipped.
+func findForemanInterfacesAttribute(ifaces []api.ForemanInterfacesAttribute, iface api.ForemanInterfacesAttribute, claimed map[int]bool) int {
+	matchers := []func(api.ForemanInterfacesAttribute) bool{
+		func(other api.ForemanInterfacesAttribute) bool {
+			return iface.Id != 0 && other.Id == iface.Id
+		},
+		func(other api.ForemanInterfacesAttribute) bool {
+			return iface.Identifier != "" && other.Identifier == iface.Identifier
+		},
+		func(other api.ForemanInterfacesAttribute) bool {
+			return iface.MAC != "" && strings.EqualFold(other.MAC, iface.MAC)
+		},
+	}
+	for _, match := range matchers {
+		for idx, other := range ifaces {
+			if !claimed[idx] && match(other) {
+				return idx
+			}
+		}
+	}
+	return -1
+}
+
+// configuredForemanInterfacesAttribute returns which of the attributes
+// computed by Foreman are set in the configuration of the interface at the
+// index of "interfaces_attributes".  Without a known configuration, all
+// attributes are treated as configured.
+func configuredForemanInterfacesAttribute(config cty.Value, idx int) map[string]bool {
+	var iface cty.Value
+	known := false
+	if !config.IsNull() && config.IsKnown() {
+		ifaces := config.GetAttr("interfaces_attributes")
+		if !ifaces.IsNull() && ifaces.IsKnown() && idx < ifaces.LengthInt() {
+			iface = ifaces.Index(cty.NumberIntVal(int64(idx)))
+			known = !iface.IsNull() && iface.IsKnown()
+		}
+	}
+
+	configured := map[string]bool{}
+	for _, key := range []string{"ip", "mac", "name", "subnet_id", "mtu", "mode"} {
+		configured[key] = !known || !iface.GetAttr(key).IsNull()
+	}
+	return configured
+}
+
+// matchForemanInterfacesAttributes returns the index of the existing
+// interface each of the configured interfaces is matched with or -1.  The
+// configuration of an interface does not carry its ID, so interfaces are
+// matched by their identifier or configured MAC address.  The position of an
+// interface in the list is only used if neither matches an existing
+// interface.
+func matchForemanInterfacesAttributes(oldIfaces []api.ForemanInterfacesAttribute, newIfaces []api.ForemanInterfacesAttribute, configured []map[string]bool) []int {
+	claimed := map[int]bool{}
+	matches := make([]int, len(newIfaces))
+
+	// match by identifier or configured MAC address
+	for idx := range newIfaces {
+		key := api.ForemanInterfacesAttribute{Identifier: newIfaces[idx].Identifier}
+		if configured[idx]["mac"] {
+			key.MAC = newIfaces[idx].MAC
+		}
+		matches[idx] = findForemanInterfacesAttribute(oldIfaces, key, claimed)
+		if matches[idx] >= 0 {
+			claimed[matches[idx]] = true
+		}
+	}
+
+	// fall back to the interface at the same position
+	for idx := range newIfaces {
+		if matches[idx] >= 0 || idx >= len(oldIfaces) || claimed[idx] {
+			continue
+		}
+		matches[idx] = idx
+		claimed[idx] = true
+	}
+
+	return matches
+}
+
+// reconcileForemanInterfacesAttributes matches the configured interfaces
+// with the interfaces of the host by the ID planned by
+// resourceForemanHostCustomizeDiffInterfacesComputed, falling back to their
+// identifier or configured MAC address.  Attributes computed by Foreman are
+// taken from the matched interface unless configured.  Existing interfaces
+// without a match are tagged for removal.
+func reconcileForemanInterfacesAttributes(d *schema.ResourceData) []api.ForemanInterfacesAttribute {
+	log.Tracef("resource_foreman_host.go#reconcileForemanInterfacesAttributes")
+
+	oldVal, _ := d.GetChange("interfaces_attributes")
+	oldIfaces := listToForemanInterfacesAttributes(oldVal.([]interface{}))
+	newIfaces := buildForemanInterfacesAttributes(d)
+	config := d.GetRawConfig()
+
+	claimed := map[int]bool{}
+	for idx := range newIfaces {
+		iface := &newIfaces[idx]
+		configured := configuredForemanInterfacesAttribute(config, idx)
+
+		key := api.ForemanInterfacesAttribute{Id: iface.Id, Identifier: iface.Identifier}
+		if configured["mac"] {
+			key.MAC = iface.MAC
+		}
+		// new interfaces are compared against the zero value
+		var oldIface api.ForemanInterfacesAttribute
+		if match := findForemanInterfacesAttribute(oldIfaces, key, claimed); match >= 0 {
+			claimed[match] = true
+			oldIface = oldIfaces[match]
+		}
+
+		iface.Id = oldIface.Id
+		if !configured["ip"] {
+			iface.IP = oldIface.IP
+		}
+		if !configured["mac"] {
+			iface.MAC = oldIface.MAC
+		}
+		if !configured["name"] {
+			iface.Name = oldIface.Name
+		}
+		if !configured["subnet_id"] {
+			iface.SubnetId = oldIface.SubnetId
+		}
+		if !configured["mtu"] {
+			iface.MTU = oldIface.MTU
+		}
+		if !configured["mode"] {
+			iface.Mode = oldIface.Mode
+		}
+	}
+
+	// NOTE(ALL): Handling the removal of a Interfaces.  See the note
+	//   in ForemanInterfacesAttribute's Destroy property
+	for idx, oldIface := range oldIfaces {
+		if !claimed[idx] && oldIface.Id != 0 {
+			newIfaces = append(newIfaces, api.ForemanInterfacesAttribute{
+				Id:      oldIface.Id,
+				Destroy: true,
+			})
+		}
+	}
+
+	log.Debugf("Reconciled ForemanInterfacesAttributes: [%+v]", newIfaces)
+
+	return newIfaces
+}
+
+// sortForemanInterfacesAttributes orders the interfaces like the supplied
+// prior interfaces, so that the order in which Foreman returns the interfaces
+// does not show up in the plan.  Interfaces without a prior interface are
+// appended in the order of the API.
+func sortForemanInterfacesAttributes(prior []api.ForemanInterfacesAttribute, ifaces []api.ForemanInterfacesAttribute) []api.ForemanInterfacesAttribute {
+	sorted := make([]api.ForemanInterfacesAttribute, 0, len(ifaces))
+	claimed := map[int]bool{}
+	for _, priorIface := range prior {
+		if idx := findForemanInterfacesAttribute(ifaces, priorIface, claimed); idx >= 0 {
+			claimed[idx] = true
+			sorted = append(sorted, ifaces[idx])
+		}
+	}
+	for idx, iface := range ifaces {
+		if !claimed[idx] {
+			sorted = append(sorted, iface)
+		}
+	}
+	return sorted
+}
+
 // setResourceDataFromForemanHost sets a ResourceData's attributes from the
 // attributes of the supplied ForemanHost struct
 func setResourceDataFromForemanHost(d *schema.ResourceData, fh *api.ForemanHost) error {
@@ -1071,6 +1378,7 @@ func setResourceDataFromForemanHost(d *schema.ResourceData, fh *api.ForemanHost)
 
 	d.Set("comment", fh.Comment)
 	d.Set("parameters", api.FromKV(fh.HostParameters))
+	d.Set("parameter_types", api.FromKVTypes(fh.HostParameters))
 
 	if err := d.Set("compute_attributes", flattenComputeAttributes(fh.ComputeAttributes)); err != nil {
 		log.Printf("[WARN] error setting compute attributes: %s", err)
@@ -1086,6 +1394,8 @@ func setResourceDataFromForemanHost(d *schema.ResourceData, fh *api.ForemanHost)
 	d.Set("owner_id", fh.OwnerId)
 	d.Set("owner_type", fh.OwnerType)
 	d.Set("hostgroup_id", fh.HostgroupId)
+	d.Set("location_id", fh.LocationId)
+	d.Set("organization_id", fh.OrganizationId)
 	d.Set("architecture_id", fh.ArchitectureId)
 	d.Set("ptable_id", fh.PtableId)
 	d.Set("subnet_id", fh.SubnetId)
@@ -1115,7 +1425,10 @@ func setResourceDataFromForemanInterfacesAttributes(d *schema.ResourceData, fh *
 	// underneath, a *schema.Set stores an array of map[string]interface{} entries.
 	// convert each ForemanInterfaces struct in the supplied array to a
 	// mapstructure and then add it to the set
-	fhia := fh.InterfacesAttributes
+	fhia := sortForemanInterfacesAttributes(
+		buildForemanInterfacesAttributes(d),
+		fh.InterfacesAttributes,
+	)
 	interfaces_compute_attributes := make(map[string]interface{})
 
 	if fh.ComputeAttributes != nil {
@@ -1175,7 +1488,13 @@ func setResourceDataFromForemanInterfacesAttributes(d *schema.ResourceData, fh *
 
 			"attached_devices": val.AttachedDevices,
 			"attached_to":      val.AttachedTo,
+
+			"mtu":          val.MTU,
+			"mode":         val.Mode,
+			"bond_options": val.BondOptions,
 		}
+		// NOTE(ALL): Foreman returns the VLAN ID as string
+		ifaceMap["tag"], _ = strconv.Atoi(val.Tag)
 
 		// NOTE(ALL): These settings only apply to virtual machines
 		var ok bool
@@ -1192,6 +1511,88 @@ func setResourceDataFromForemanInterfacesAttributes(d *schema.ResourceData, fh *
 	return nil
 }
 
+// sendForemanHostPowerCommands sends each of the power and boot commands to
+// the host.  In the event of any failure, it stops and returns the error.
+func sendForemanHostPowerCommands(ctx context.Context, client *api.Client, h *api.ForemanHost, powerCmds []interface{}) error {
+	for _, cmd := range powerCmds {
+		sendErr := client.SendPowerCommand(ctx, h, cmd)
+		if sendErr != nil {
+			return sendErr
+		}
+		// Sleep for 3 seconds between chained BMC calls
+		duration := time.Duration(3) * time.Second
+		time.Sleep(duration)
+	}
+	return nil
+}
+
+// bootForemanHostIntoBuild boots a host with the build flag set into its
+// build.  Hosts with a BMC interface boot from PXE once.  Running hosts are
+// power cycled, powered off hosts are powered on.
+func bootForemanHostIntoBuild(ctx context.Context, client *api.Client, h *api.ForemanHost) error {
+	powerState, readErr := client.ReadHostPowerState(ctx, h)
+	if readErr != nil {
+		return readErr
+	}
+
+	var powerCmds []interface{}
+	if h.EnableBMC {
+		powerCmds = append(powerCmds, api.BMCBoot{Device: api.BootPxe})
+	}
+	if powerState == api.PowerOff {
+		powerCmds = append(powerCmds, api.Power{PowerAction: api.PowerOn})
+	} else {
+		powerCmds = append(powerCmds, api.Power{PowerAction: api.PowerCycle})
+	}
+
+	return sendForemanHostPowerCommands(ctx, client, h, powerCmds)
+}
+
+// setForemanHostPowerState powers the host on or off if it is not in the
+// configured power state.  Hosts without a configured power state are left
+// untouched.
+func setForemanHostPowerState(ctx context.Context, d *schema.ResourceData, client *api.Client, h *api.ForemanHost) diag.Diagnostics {
+	powerState := d.Get("power_state").(string)
+	if powerState == "" {
+		return nil
+	}
+
+	currentState, readErr := client.ReadHostPowerState(ctx, h)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+
+	log.Debugf("ForemanHost power state: [%s], configured: [%s]", currentState, powerState)
+
+	if currentState != powerState {
+		sendErr := client.SendPowerCommand(ctx, h, api.Power{PowerAction: powerState})
+		if sendErr != nil {
+			return diag.FromErr(sendErr)
+		}
+	}
+
+	return nil
+}
+
+// waitForForemanHostBuild waits until the host is built if the resource
+// enables waiting for the build and sets the ResourceData's attributes from
+// the built host
+func waitForForemanHostBuild(ctx context.Context, d *schema.ResourceData, client *api.Client) diag.Diagnostics {
+	if !d.Get("wait_for_build").(bool) {
+		return nil
+	}
+
+	id, _ := strconv.Atoi(d.Id())
+	builtHost, waitErr := client.WaitForHostBuild(ctx, id)
+	if waitErr != nil {
+		return diag.FromErr(waitErr)
+	}
+
+	log.Debugf("Built ForemanHost: [%+v]", builtHost)
+
+	return diag.FromErr(setResourceDataFromForemanHost(d, builtHost))
+}
+
 // -----------------------------------------------------------------------------
 // Resource CRUD Operations
 // -----------------------------------------------------------------------------
@@ -1214,16 +1615,15 @@ func resourceForemanHostCreate(ctx context.Context, d *schema.ResourceData, meta
 	// a FQDN, resulting in inconsistent plans. Maybe this issue will arise again, then handle it here.
 
 	log.Debugf("ForemanHost: [%+v]", h)
-	hostRetryCount := d.Get("retry_count").(int)
 
 	// See commit ad2b5890f09645513b520f12291546f26b812c96 for an experimental implementation
 	// for checks of the "computeAttributes" field, when using ProvisionMethod=image.
 	// The feature was removed because it was VMware-specific and the test on the backend provider
 	// could not yet be implemented (via client.ReadComputeResource -> computeResource.Provider)
 
-	createdHost, createErr := client.CreateHost(ctx, h, hostRetryCount)
+	createdHost, createErr := client.CreateHost(ctx, h)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanHost: [%+v]", createdHost)
@@ -1265,23 +1665,21 @@ func resourceForemanHostCreate(ctx context.Context, d *schema.ResourceData, meta
 			}
 		}
 
-		// Loop through each of the above BMC Operations and execute.
-		// In the event fo any failure, exit with error
-		for _, cmd := range powerCmds {
-			sendErr := client.SendPowerCommand(ctx, createdHost, cmd, hostRetryCount)
-			if sendErr != nil {
-				return diag.FromErr(sendErr)
-			}
-			// Sleep for 3 seconds between chained BMC calls
-			duration := time.Duration(3) * time.Second
-			time.Sleep(duration)
+		sendErr := sendForemanHostPowerCommands(ctx, client, createdHost, powerCmds)
+		if sendErr != nil {
+			return diag.FromErr(sendErr)
 		}
 	}
 
 	// Disable partial mode
 	d.Partial(false)
 
-	return diags
+	diags = append(diags, setForemanHostPowerState(ctx, d, client, createdHost)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	return append(diags, waitForForemanHostBuild(ctx, d, client)...)
 }
 
 func resourceForemanHostRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -1308,6 +1706,22 @@ func resourceForemanHostRead(ctx context.Context, d *schema.ResourceData, meta i
 		d.Set("retry_count", DEFAULT_RETRY_COUNT)
 	}
 
+	// NOTE(ALL): The power state is only read if it is managed, hosts
+	//   without a compute resource or BMC interface have no power state.
+	if d.Get("power_state").(string) != "" {
+		powerState, powerErr := client.ReadHostPowerState(ctx, readHost)
+		if powerErr != nil {
+			return diag.Diagnostics{
+				{
+					Severity: diag.Warning,
+					Summary:  "Unable to read the power state of the host",
+					Detail:   powerErr.Error(),
+				},
+			}
+		}
+		d.Set("power_state", powerState)
+	}
+
 	return nil
 }
 
@@ -1319,6 +1733,17 @@ func resourceForemanHostUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 	log.Debugf("ForemanHost: [%+v]", h)
 
+	// NOTE(ALL): Changed rebuild triggers set the build flag of the host.
+	//   Adding the triggers to an existing host does not rebuild it, neither
+	//   does removing triggers.
+	oldTriggers, newTriggers := d.GetChange("rebuild_triggers")
+	rebuild := d.HasChange("rebuild_triggers") &&
+		len(oldTriggers.(map[string]interface{})) > 0 &&
+		triggersAddedOrChanged(oldTriggers.(map[string]interface{}), newTriggers.(map[string]interface{}))
+	if rebuild {
+		h.Build = true
+	}
+
 	// Enable partial mode in the event of failure of one of API calls required for host update
 	d.Partial(true)
 
@@ -1327,28 +1752,11 @@ func resourceForemanHostUpdate(ctx context.Context, d *schema.ResourceData, meta
 		h.ComputeAttributes = nil
 	}
 
-	// NOTE(ALL): Handling the removal of a Interfaces.  See the note
-	//   in ForemanInterfacesAttribute's Destroy property
+	// NOTE(ALL): Interfaces are matched by identifier or MAC address rather
+	//   than by their position, removed interfaces are tagged for removal
 	if d.HasChange("interfaces_attributes") {
-		oldVal, newVal := d.GetChange("interfaces_attributes")
-		oldValList, newValList := oldVal.([]interface{}), newVal.([]interface{})
-
-		// iterate over the removed items, add them back to the interface's
-		// array, but tag them for removal.
-		for idx, rmVal := range oldValList {
-			if idx+1 > len(newValList) {
-				// construct, tag for deletion from list of interfaces
-				rmValMap := rmVal.(map[string]interface{})
-				rmInterface := mapToForemanInterfacesAttribute(rmValMap)
-				rmInterface.Destroy = true
-				// append back to interface's list
-				h.InterfacesAttributes = append(h.InterfacesAttributes, rmInterface)
-			}
-		}
-
-	} // end HasChange("interfaces_attributes")
-
-	hostRetryCount := d.Get("retry_count").(int)
+		h.InterfacesAttributes = reconcileForemanInterfacesAttributes(d)
+	}
 
 	// We need to test whether a call to update the host is necessary based on what has changed.
 	// Otherwise, a detected update caused by an unsuccessful BMC operation will cause a 422 on update.
@@ -1356,26 +1764,31 @@ func resourceForemanHostUpdate(ctx context.Context, d *schema.ResourceData, meta
 		d.HasChange("shortname") ||
 		d.HasChange("comment") ||
 		d.HasChange("parameters") ||
+		d.HasChange("parameter_types") ||
 		d.HasChange("compute_attributes") ||
 		d.HasChange("domain_id") ||
 		d.HasChange("environment_id") ||
 		d.HasChange("owner_id") ||
 		d.HasChange("owner_type") ||
 		d.HasChange("hostgroup_id") ||
+		d.HasChange("location_id") ||
+		d.HasChange("organization_id") ||
 		d.HasChange("compute_resource_id") ||
 		d.HasChange("compute_profile_id") ||
 		d.HasChange("operatingsystem_id") ||
 		d.HasChange("interfaces_attributes") ||
 		d.HasChange("build") ||
+		d.HasChange("set_build_flag") ||
+		rebuild ||
 		d.HasChange("puppet_class_ids") ||
 		d.HasChange("config_group_ids") ||
 		d.Get("managed") == false {
 
 		log.Debugf("host: [%+v]", h)
 
-		updatedHost, updateErr := client.UpdateHost(ctx, h, hostRetryCount)
+		updatedHost, updateErr := client.UpdateHost(ctx, h)
 		if updateErr != nil {
-			return diag.FromErr(updateErr)
+			return api.DiagnosticsFromError(d, updateErr)
 		}
 
 		log.Debugf("Updated FormanHost: [%+v]", updatedHost)
@@ -1386,12 +1799,55 @@ func resourceForemanHostUpdate(ctx context.Context, d *schema.ResourceData, meta
 		}
 	} // end HasChange("name")
 
+	// Boot a rebuilt host into its build or reboot a running host to start its
+	// build
+	if rebuild {
+		if d.Get("manage_power_operations").(bool) {
+			log.Debugf("Booting ForemanHost into the rebuild")
+			bootErr := bootForemanHostIntoBuild(ctx, client, h)
+			if bootErr != nil {
+				return diag.FromErr(bootErr)
+			}
+		}
+	} else if d.HasChange("set_build_flag") && h.Build &&
+		!d.HasChange("power_state") && d.Get("power_state").(string) == api.PowerOn {
+		log.Debugf("Rebooting ForemanHost to start the build")
+		sendErr := client.SendPowerCommand(ctx, h, api.Power{PowerAction: api.PowerSoft})
+		if sendErr != nil {
+			return diag.FromErr(sendErr)
+		}
+	}
+
 	// Use partial state mode in the event of failure of one of API calls required for host creation
 	d.Partial(false)
 
+	if rebuild {
+		if diags := waitForForemanHostBuild(ctx, d, client); diags.HasError() {
+			return diags
+		}
+	}
+
+	// Bring the host into the configured power state - after the rebuild, as
+	// booting into the build powers the host on
+	if d.HasChange("power_state") {
+		return setForemanHostPowerState(ctx, d, client, h)
+	}
+
 	return nil
 }
 
+// triggersAddedOrChanged reports whether the new rebuild triggers add an
+// entry or change the value of an entry of the old rebuild triggers.
+// Removed entries are ignored.
+func triggersAddedOrChanged(oldTriggers map[string]interface{}, newTriggers map[string]interface{}) bool {
+	for key, value := range newTriggers {
+		if oldValue, ok := oldTriggers[key]; !ok || oldValue != value {
+			return true
+		}
+	}
+	return false
+}
+
 func resourceForemanHostDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Tracef("resource_foreman_host.go#Delete")
 
@@ -1465,6 +1921,109 @@ func resourceForemanHostCustomizeDiffComputeAttributes(ctx context.Context, d *s
 	return nil
 }
 
+// validateForemanInterfacesAttribute validates that only the attributes which
+// apply to the type of the interface are set.  VLANs are virtual interfaces
+// with a tag, attached to their parent interface.
+func validateForemanInterfacesAttribute(iface api.ForemanInterfacesAttribute) error {
+	ifaceType := iface.Type
+	if ifaceType == "" {
+		ifaceType = "interface"
+	}
+
+	if (iface.Mode != "" || iface.BondOptions != "") && ifaceType != "bond" {
+		return fmt.Errorf("mode and bond_options can only be set for bond interfaces, not for %s interfaces", ifaceType)
+	}
+	if iface.AttachedDevices != "" && ifaceType != "bond" && ifaceType != "bridge" {
+		return fmt.Errorf("attached_devices can only be set for bond and bridge interfaces, not for %s interfaces", ifaceType)
+	}
+	if (iface.Username != "" || iface.Password != "" || iface.Provider != "") && ifaceType != "bmc" {
+		return fmt.Errorf("username, password and bmc_provider can only be set for bmc interfaces, not for %s interfaces", ifaceType)
+	}
+	if iface.Tag != "" {
+		if ifaceType == "bmc" {
+			return fmt.Errorf("tag can not be set for bmc interfaces")
+		}
+		if !iface.Virtual || iface.AttachedTo == "" {
+			return fmt.Errorf("tag can only be set for virtual interfaces attached to their parent interface with attached_to")
+		}
+	}
+	return nil
+}
+
+// resourceForemanHostCustomizeDiffInterfaces validates the attributes of each
+// of the host's interfaces against the type of the interface
+func resourceForemanHostCustomizeDiffInterfaces(ctx context.Context, d *schema.ResourceDiff, i interface{}) error {
+	for idx, attr := range d.Get("interfaces_attributes").([]interface{}) {
+		attrMap, ok := attr.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if err := validateForemanInterfacesAttribute(mapToForemanInterfacesAttribute(attrMap)); err != nil {
+			return fmt.Errorf("interfaces_attributes.%d: %s", idx, err)
+		}
+	}
+	return nil
+}
+
+// resourceForemanHostCustomizeDiffInterfacesComputed plans the attributes of
+// the host's interfaces computed by Foreman, including their ID, from the
+// existing interface each configured interface is matched with - see
+// matchForemanInterfacesAttributes.  Without a match, they are planned as
+// unknown.  So the plan matches the apply when interfaces are removed from or
+// moved within the list, instead of carrying the values of the interface
+// which was at the same position before.
+func resourceForemanHostCustomizeDiffInterfacesComputed(ctx context.Context, d *schema.ResourceDiff, i interface{}) error {
+	if d.Id() == "" || !d.HasChange("interfaces_attributes") {
+		return nil
+	}
+
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || config.GetAttr("interfaces_attributes").IsNull() {
+		return nil
+	}
+	if !config.GetAttr("interfaces_attributes").IsWhollyKnown() {
+		// The interfaces can only be matched once their configuration is known
+		return d.SetNewComputed("interfaces_attributes")
+	}
+
+	oldVal, newVal := d.GetChange("interfaces_attributes")
+	oldList := oldVal.([]interface{})
+	newList := newVal.([]interface{})
+
+	configured := make([]map[string]bool, len(newList))
+	for idx := range newList {
+		configured[idx] = configuredForemanInterfacesAttribute(config, idx)
+	}
+	matches := matchForemanInterfacesAttributes(
+		listToForemanInterfacesAttributes(oldList),
+		listToForemanInterfacesAttributes(newList),
+		configured,
+	)
+
+	planned := make([]interface{}, len(newList))
+	for idx, attr := range newList {
+		iface := map[string]interface{}{}
+		for key, value := range attr.(map[string]interface{}) {
+			iface[key] = value
+		}
+		// NOTE(ALL): Missing values of computed attributes are planned as
+		//   unknown
+		var oldIface map[string]interface{}
+		if matches[idx] >= 0 {
+			oldIface = oldList[matches[idx]].(map[string]interface{})
+		}
+		iface["id"] = oldIface["id"]
+		for key, isConfigured := range configured[idx] {
+			if !isConfigured {
+				iface[key] = oldIface[key]
+			}
+		}
+		planned[idx] = iface
+	}
+
+	return d.SetNew("interfaces_attributes", planned)
+}
+
 func resourceForemanHostNameDiffSuppressFunc(k, oldValue, newValue string, d *schema.ResourceData) bool {
 	domainName := d.Get("domain_name").(string)
 	if domainName == "" {
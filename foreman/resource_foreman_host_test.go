@@ -8,11 +8,14 @@ import (
 	"net/http"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
 	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -371,6 +374,10 @@ func ResourceForemanHostCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCor
 				resourceData: MockForemanHostResourceData(s),
 			},
 			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    hostsURIById,
+					expectedMethod: http.MethodPut,
+				},
 				{
 					expectedURI:    hostsURIById + "/vm_compute_attributes",
 					expectedMethod: http.MethodGet,
@@ -570,6 +577,538 @@ func ResourceForemanHostMockResponseTestCases(t *testing.T) []TestCaseMockRespon
 
 }
 
+// -----------------------------------------------------------------------------
+// waitForForemanHostBuild
+// -----------------------------------------------------------------------------
+
+// Ensures the host is polled until it is built and failed builds fail the
+// operation with the build status label
+func TestWaitForForemanHostBuild(t *testing.T) {
+
+	obj := RandForemanHost()
+	obj.Id = rand.Intn(100) + 1
+	hostURI := HostsURI + "/" + strconv.Itoa(obj.Id)
+
+	testCases := []struct {
+		waitForBuild   bool
+		buildStates    []int
+		expectedPolls  int
+		expectedErrMsg string
+	}{
+		{waitForBuild: false, buildStates: []int{1}, expectedPolls: 0},
+		{waitForBuild: true, buildStates: []int{1, 1, 0}, expectedPolls: 3},
+		{waitForBuild: true, buildStates: []int{1, 3}, expectedPolls: 2, expectedErrMsg: "build status [Installation error]"},
+		{waitForBuild: true, buildStates: []int{2}, expectedPolls: 1, expectedErrMsg: "build status [Token expired]"},
+	}
+	labels := map[int]string{
+		api.HostBuildStatusBuilt:        "Installed",
+		api.HostBuildStatusPending:      "Pending installation",
+		api.HostBuildStatusTokenExpired: "Token expired",
+		api.HostBuildStatusBuildFailed:  "Installation error",
+	}
+
+	for _, testCase := range testCases {
+		mux, server, client := NewForemanAPIAndClient(
+			api.ClientCredentials{},
+			api.ClientConfig{TaskPollInterval: time.Millisecond},
+		)
+
+		polls := 0
+		mux.HandleFunc(hostURI, func(w http.ResponseWriter, r *http.Request) {
+			buildStatus := testCase.buildStates[polls]
+			polls++
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":                 obj.Id,
+				"name":               obj.Name,
+				"build_status":       buildStatus,
+				"build_status_label": labels[buildStatus],
+			})
+		})
+
+		s := ForemanHostToInstanceState(obj)
+		s.Attributes["wait_for_build"] = strconv.FormatBool(testCase.waitForBuild)
+		diags := waitForForemanHostBuild(context.TODO(), MockForemanHostResourceData(s), client)
+		server.Close()
+
+		errMsg := ""
+		if diags.HasError() {
+			errMsg = diags[0].Summary
+		}
+		if polls != testCase.expectedPolls ||
+			diags.HasError() != (testCase.expectedErrMsg != "") ||
+			!strings.Contains(errMsg, testCase.expectedErrMsg) {
+			t.Errorf(
+				"waitForForemanHostBuild with wait_for_build [%t] and build states "+
+					"%v polled [%d] times with diagnostics [%+v]",
+				testCase.waitForBuild,
+				testCase.buildStates,
+				polls,
+				diags,
+			)
+		}
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// Power State
+// -----------------------------------------------------------------------------
+
+// Ensures the host is only powered on or off if it is not in the configured
+// power state.  Virtual machines report their state in the terms of the
+// compute resource.
+func TestSetForemanHostPowerState(t *testing.T) {
+
+	obj := RandForemanHost()
+	obj.Id = rand.Intn(100) + 1
+	hostURI := HostsURI + "/" + strconv.Itoa(obj.Id)
+
+	testCases := []struct {
+		powerState       string
+		currentState     string
+		expectedCommands []string
+	}{
+		{powerState: "", currentState: "on", expectedCommands: []string{}},
+		{powerState: "on", currentState: "poweredOn", expectedCommands: []string{"status"}},
+		{powerState: "on", currentState: "off", expectedCommands: []string{"status", "on"}},
+		{powerState: "off", currentState: "running", expectedCommands: []string{"status", "off"}},
+	}
+
+	for _, testCase := range testCases {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+
+		commands := []string{}
+		mux.HandleFunc(hostURI+"/power_status", func(w http.ResponseWriter, r *http.Request) {
+			commands = append(commands, "status")
+			fmt.Fprintf(w, `{"id": %d, "state": "%s"}`, obj.Id, testCase.currentState)
+		})
+		mux.HandleFunc(hostURI+"/power", func(w http.ResponseWriter, r *http.Request) {
+			var power api.Power
+			json.NewDecoder(r.Body).Decode(&power)
+			commands = append(commands, power.PowerAction)
+			w.Write([]byte(`{"power": true}`))
+		})
+
+		s := ForemanHostToInstanceState(obj)
+		s.Attributes["power_state"] = testCase.powerState
+		diags := setForemanHostPowerState(context.TODO(), MockForemanHostResourceData(s), client, &obj)
+		server.Close()
+
+		if diags.HasError() || !reflect.DeepEqual(commands, testCase.expectedCommands) {
+			t.Errorf(
+				"setForemanHostPowerState with power_state [%s] and current state [%s] "+
+					"sent %v with diagnostics [%+v]. Expected %v.",
+				testCase.powerState,
+				testCase.currentState,
+				commands,
+				diags,
+				testCase.expectedCommands,
+			)
+		}
+	}
+
+}
+
+// Ensures a managed power state is read back, so that changes made outside
+// of Terraform show up in the plan
+func TestResourceForemanHostRead_PowerState(t *testing.T) {
+
+	obj := RandForemanHost()
+	obj.Id = rand.Intn(100) + 1
+	hostURI := HostsURI + "/" + strconv.Itoa(obj.Id)
+
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(hostURI, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id": %d, "name": "%s"}`, obj.Id, obj.Name)
+	})
+	mux.HandleFunc(hostURI+"/power_status", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("resourceForemanHostRead read the power state with [%s]. Expected [GET]", r.Method)
+		}
+		fmt.Fprintf(w, `{"id": %d, "state": "off"}`, obj.Id)
+	})
+	mux.HandleFunc(hostURI+"/power", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("resourceForemanHostRead sent a power command while refreshing")
+	})
+
+	s := ForemanHostToInstanceState(obj)
+	s.Attributes["power_state"] = "on"
+	resourceData := MockForemanHostResourceData(s)
+	diags := resourceForemanHostRead(context.TODO(), resourceData, client)
+
+	if diags.HasError() || resourceData.Get("power_state").(string) != "off" {
+		t.Fatalf(
+			"resourceForemanHostRead did not read the power state. Expected [off], "+
+				"got [%s] with diagnostics [%+v].",
+			resourceData.Get("power_state"),
+			diags,
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// Rebuild Triggers
+// -----------------------------------------------------------------------------
+
+// Ensures changed rebuild triggers set the build flag and boot the host into
+// its build, while adding the triggers to an existing host or removing
+// triggers does not.  A changed power state is applied after the rebuild.
+func TestResourceForemanHostUpdate_RebuildTriggers(t *testing.T) {
+
+	obj := RandForemanHost()
+	obj.Id = rand.Intn(100) + 1
+	obj.InterfacesAttributes = nil
+	hostURI := HostsURI + "/" + strconv.Itoa(obj.Id)
+
+	testCases := []struct {
+		oldTriggers      map[string]string
+		newTriggers      map[string]interface{}
+		newPowerState    string
+		expectedBuild    bool
+		expectedCommands []string
+	}{
+		{
+			oldTriggers:      map[string]string{},
+			newTriggers:      map[string]interface{}{"kickstart": "1"},
+			expectedBuild:    false,
+			expectedCommands: []string{},
+		},
+		{
+			oldTriggers:      map[string]string{"kickstart": "1"},
+			newTriggers:      map[string]interface{}{"kickstart": "2"},
+			expectedBuild:    true,
+			expectedCommands: []string{"status", "cycle"},
+		},
+		{
+			oldTriggers:      map[string]string{"kickstart": "1"},
+			newTriggers:      map[string]interface{}{},
+			expectedBuild:    false,
+			expectedCommands: []string{},
+		},
+		{
+			oldTriggers:      map[string]string{"kickstart": "1", "partitions": "1"},
+			newTriggers:      map[string]interface{}{"kickstart": "1"},
+			expectedBuild:    false,
+			expectedCommands: []string{},
+		},
+		{
+			oldTriggers:      map[string]string{"kickstart": "1"},
+			newTriggers:      map[string]interface{}{"kickstart": "2"},
+			newPowerState:    api.PowerOff,
+			expectedBuild:    true,
+			expectedCommands: []string{"status", "cycle", "status", api.PowerOff},
+		},
+	}
+
+	for _, testCase := range testCases {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+
+		var build bool
+		mux.HandleFunc(hostURI, func(w http.ResponseWriter, r *http.Request) {
+			var body map[string]map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			build = body["host"]["build"] == true
+			fmt.Fprintf(w, `{"id": %d, "name": "%s", "build": %t}`, obj.Id, obj.Name, build)
+		})
+		commands := []string{}
+		mux.HandleFunc(hostURI+"/power_status", func(w http.ResponseWriter, r *http.Request) {
+			commands = append(commands, "status")
+			fmt.Fprintf(w, `{"id": %d, "state": "on"}`, obj.Id)
+		})
+		mux.HandleFunc(hostURI+"/power", func(w http.ResponseWriter, r *http.Request) {
+			var power api.Power
+			json.NewDecoder(r.Body).Decode(&power)
+			commands = append(commands, power.PowerAction)
+			w.Write([]byte(`{"power": true}`))
+		})
+
+		s := ForemanHostToInstanceState(obj)
+		s.Attributes["manage_power_operations"] = "true"
+		s.Attributes["rebuild_triggers.%"] = strconv.Itoa(len(testCase.oldTriggers))
+		for key, value := range testCase.oldTriggers {
+			s.Attributes["rebuild_triggers."+key] = value
+		}
+		config := map[string]interface{}{
+			"name":             obj.Name,
+			"rebuild_triggers": testCase.newTriggers,
+		}
+		if testCase.newPowerState != "" {
+			config["power_state"] = testCase.newPowerState
+		}
+		r := resourceForemanHost()
+		diff, diffErr := r.Diff(context.TODO(), s, terraform.NewResourceConfigRaw(config), nil)
+		if diffErr != nil {
+			t.Fatalf("resourceForemanHost could not diff the rebuild triggers: %s", diffErr)
+		}
+		resourceData, _ := schema.InternalMap(r.Schema).Data(s, diff)
+
+		diags := resourceForemanHostUpdate(context.TODO(), resourceData, client)
+		server.Close()
+
+		if diags.HasError() || build != testCase.expectedBuild ||
+			!reflect.DeepEqual(commands, testCase.expectedCommands) {
+			t.Errorf(
+				"resourceForemanHostUpdate with rebuild triggers %v -> %v and power "+
+					"state [%s] set the build flag [%t] and sent %v with diagnostics "+
+					"[%+v]. Expected [%t] and %v.",
+				testCase.oldTriggers,
+				testCase.newTriggers,
+				testCase.newPowerState,
+				build,
+				commands,
+				diags,
+				testCase.expectedBuild,
+				testCase.expectedCommands,
+			)
+		}
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// Interface Reconciliation
+// -----------------------------------------------------------------------------
+
+// Ensures interfaces are matched by identifier instead of their position when
+// interfaces are removed or reordered, the plan carries the IDs of the
+// matched interfaces and removed interfaces are destroyed
+func TestReconcileForemanInterfacesAttributes(t *testing.T) {
+
+	obj := RandForemanHost()
+	obj.Id = rand.Intn(100) + 1
+	obj.InterfacesAttributes = nil
+
+	oldIfaces := []api.ForemanInterfacesAttribute{
+		{Id: 1, Identifier: "eth0", MAC: "00:00:00:00:00:01", IP: "10.0.0.1", Primary: true, Provision: true},
+		{Id: 2, Identifier: "eth1", MAC: "00:00:00:00:00:02", IP: "10.0.0.2"},
+		{Id: 3, Identifier: "eth2", MAC: "00:00:00:00:00:03", IP: "10.0.0.3"},
+	}
+
+	testCases := []struct {
+		identifiers []string
+		expected    []api.ForemanInterfacesAttribute
+	}{
+		// removing an interface in the middle of the list
+		{
+			identifiers: []string{"eth0", "eth2"},
+			expected: []api.ForemanInterfacesAttribute{
+				{Id: 1, Identifier: "eth0", MAC: "00:00:00:00:00:01", IP: "10.0.0.1", Primary: true, Provision: true},
+				{Id: 3, Identifier: "eth2", MAC: "00:00:00:00:00:03", IP: "10.0.0.3"},
+				{Id: 2, Destroy: true},
+			},
+		},
+		// removing the first interface
+		{
+			identifiers: []string{"eth1", "eth2"},
+			expected: []api.ForemanInterfacesAttribute{
+				{Id: 2, Identifier: "eth1", MAC: "00:00:00:00:00:02", IP: "10.0.0.2"},
+				{Id: 3, Identifier: "eth2", MAC: "00:00:00:00:00:03", IP: "10.0.0.3"},
+				{Id: 1, Destroy: true},
+			},
+		},
+		// reordering and adding interfaces
+		{
+			identifiers: []string{"eth2", "eth3", "eth1", "eth0"},
+			expected: []api.ForemanInterfacesAttribute{
+				{Id: 3, Identifier: "eth2", MAC: "00:00:00:00:00:03", IP: "10.0.0.3"},
+				{Identifier: "eth3"},
+				{Id: 2, Identifier: "eth1", MAC: "00:00:00:00:00:02", IP: "10.0.0.2"},
+				{Id: 1, Identifier: "eth0", MAC: "00:00:00:00:00:01", IP: "10.0.0.1", Primary: true, Provision: true},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		s := ForemanHostToInstanceState(obj)
+		s.Attributes["provision_method"] = "build"
+		s.Attributes["interfaces_attributes.#"] = strconv.Itoa(len(oldIfaces))
+		for idx, iface := range oldIfaces {
+			prefix := fmt.Sprintf("interfaces_attributes.%d.", idx)
+			s.Attributes[prefix+"id"] = strconv.Itoa(iface.Id)
+			s.Attributes[prefix+"identifier"] = iface.Identifier
+			s.Attributes[prefix+"mac"] = iface.MAC
+			s.Attributes[prefix+"ip"] = iface.IP
+			s.Attributes[prefix+"primary"] = strconv.FormatBool(iface.Primary)
+			s.Attributes[prefix+"provision"] = strconv.FormatBool(iface.Provision)
+		}
+
+		rawIfaces := []interface{}{}
+		ctyIfaces := []cty.Value{}
+		for _, identifier := range testCase.identifiers {
+			rawIfaces = append(rawIfaces, map[string]interface{}{
+				"identifier": identifier,
+				"primary":    identifier == "eth0",
+				"provision":  identifier == "eth0",
+			})
+			ctyIfaces = append(ctyIfaces, cty.ObjectVal(map[string]cty.Value{
+				"identifier": cty.StringVal(identifier),
+				"ip":         cty.NullVal(cty.String),
+				"mac":        cty.NullVal(cty.String),
+				"name":       cty.NullVal(cty.String),
+				"subnet_id":  cty.NullVal(cty.Number),
+				"mtu":        cty.NullVal(cty.Number),
+				"mode":       cty.NullVal(cty.String),
+			}))
+		}
+
+		r := resourceForemanHost()
+		s.RawConfig = cty.ObjectVal(map[string]cty.Value{
+			"interfaces_attributes": cty.ListVal(ctyIfaces),
+		})
+		diff, diffErr := r.Diff(context.TODO(), s, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":                  obj.Name,
+			"interfaces_attributes": rawIfaces,
+		}), nil)
+		if diffErr != nil {
+			t.Fatalf("resourceForemanHost could not diff the interfaces: %s", diffErr)
+		}
+		resourceData, _ := schema.InternalMap(r.Schema).Data(s, diff)
+
+		for idx := range testCase.identifiers {
+			prefix := fmt.Sprintf("interfaces_attributes.%d.", idx)
+			plannedId := resourceData.Get(prefix + "id").(int)
+			plannedIP := resourceData.Get(prefix + "ip").(string)
+			if plannedId != testCase.expected[idx].Id || plannedIP != testCase.expected[idx].IP {
+				t.Errorf(
+					"resourceForemanHost planned the id [%d] and ip [%s] for the "+
+						"interface [%s]. Expected [%d] and [%s].",
+					plannedId,
+					plannedIP,
+					testCase.identifiers[idx],
+					testCase.expected[idx].Id,
+					testCase.expected[idx].IP,
+				)
+			}
+		}
+
+		actual := reconcileForemanInterfacesAttributes(resourceData)
+		for idx := range actual {
+			actual[idx].ComputeAttributes = nil
+		}
+
+		if !reflect.DeepEqual(actual, testCase.expected) {
+			t.Errorf(
+				"reconcileForemanInterfacesAttributes for the interfaces %v did not "+
+					"match the interfaces by identifier. Expected [%+v], got [%+v].",
+				testCase.identifiers,
+				testCase.expected,
+				actual,
+			)
+		}
+	}
+
+}
+
+// Ensures the interfaces returned by the API keep the order of the prior
+// interfaces
+func TestSortForemanInterfacesAttributes(t *testing.T) {
+
+	prior := []api.ForemanInterfacesAttribute{
+		{Id: 2, Identifier: "eth1"},
+		{Identifier: "eth2"},
+		{MAC: "00:00:00:00:00:01"},
+	}
+	ifaces := []api.ForemanInterfacesAttribute{
+		{Id: 1, Identifier: "eth0", MAC: "00:00:00:00:00:01"},
+		{Id: 2, Identifier: "eth1"},
+		{Id: 3, Identifier: "eth2"},
+		{Id: 4, Identifier: "eth3"},
+	}
+	expectedIds := []int{2, 3, 1, 4}
+
+	actualIds := []int{}
+	for _, iface := range sortForemanInterfacesAttributes(prior, ifaces) {
+		actualIds = append(actualIds, iface.Id)
+	}
+
+	if !reflect.DeepEqual(actualIds, expectedIds) {
+		t.Fatalf(
+			"sortForemanInterfacesAttributes did not keep the prior order. "+
+				"Expected %v, got %v.",
+			expectedIds,
+			actualIds,
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// Interface Types
+// -----------------------------------------------------------------------------
+
+// Ensures only the attributes which apply to the type of an interface are
+// accepted
+func TestValidateForemanInterfacesAttribute(t *testing.T) {
+
+	testCases := []struct {
+		iface       api.ForemanInterfacesAttribute
+		expectError bool
+	}{
+		// LACP bond of two interfaces
+		{iface: api.ForemanInterfacesAttribute{Type: "bond", Mode: "802.3ad", BondOptions: "miimon=100", AttachedDevices: "eth0,eth1", MTU: 9000}},
+		// tagged VLAN on top of the bond
+		{iface: api.ForemanInterfacesAttribute{Virtual: true, AttachedTo: "bond0", Tag: "42"}},
+		{iface: api.ForemanInterfacesAttribute{Type: "bridge", AttachedDevices: "bond0.42"}},
+		{iface: api.ForemanInterfacesAttribute{Type: "bmc", Username: "admin", Password: "s3cr3t", Provider: "IPMI"}},
+		{iface: api.ForemanInterfacesAttribute{Type: "interface", Mode: "802.3ad"}, expectError: true},
+		{iface: api.ForemanInterfacesAttribute{Type: "bridge", BondOptions: "miimon=100"}, expectError: true},
+		{iface: api.ForemanInterfacesAttribute{Type: "interface", AttachedDevices: "eth1"}, expectError: true},
+		{iface: api.ForemanInterfacesAttribute{Type: "bond", Username: "admin"}, expectError: true},
+		{iface: api.ForemanInterfacesAttribute{Tag: "42"}, expectError: true},
+		{iface: api.ForemanInterfacesAttribute{Virtual: true, Tag: "42"}, expectError: true},
+		{iface: api.ForemanInterfacesAttribute{Type: "bmc", Virtual: true, AttachedTo: "eth0", Tag: "42"}, expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		err := validateForemanInterfacesAttribute(testCase.iface)
+		if (err != nil) != testCase.expectError {
+			t.Errorf(
+				"validateForemanInterfacesAttribute returned [%v] for the interface [%+v]",
+				err,
+				testCase.iface,
+			)
+		}
+	}
+
+}
+
+// Ensures the VLAN, MTU and bonding attributes are converted between the
+// resource data and the API model
+func TestForemanInterfacesAttribute_BondAndVLAN(t *testing.T) {
+
+	obj := RandForemanHost()
+	obj.InterfacesAttributes = []api.ForemanInterfacesAttribute{
+		{Id: 1, Identifier: "bond0", Type: "bond", Mode: "802.3ad", BondOptions: "miimon=100", AttachedDevices: "eth0,eth1", MTU: 9000},
+		{Id: 2, Identifier: "bond0.42", Type: "interface", Virtual: true, AttachedTo: "bond0", Tag: "42"},
+	}
+
+	resourceData := MockForemanHostResourceData(ForemanHostToInstanceState(api.ForemanHost{}))
+	if err := setResourceDataFromForemanInterfacesAttributes(resourceData, &obj); err != nil {
+		t.Fatalf("setResourceDataFromForemanInterfacesAttributes returned [%s]", err)
+	}
+
+	if tag := resourceData.Get("interfaces_attributes.1.tag").(int); tag != 42 {
+		t.Fatalf("setResourceDataFromForemanInterfacesAttributes did not set the tag. Expected [42], got [%d].", tag)
+	}
+
+	actual := buildForemanInterfacesAttributes(resourceData)
+	for idx := range actual {
+		actual[idx].ComputeAttributes = nil
+	}
+	if !reflect.DeepEqual(actual, obj.InterfacesAttributes) {
+		t.Fatalf(
+			"buildForemanInterfacesAttributes did not build the interfaces. "+
+				"Expected [%+v], got [%+v].",
+			obj.InterfacesAttributes,
+			actual,
+		)
+	}
+
+}
+
 func testResourceHostStateDataV0() map[string]interface{} {
 	return map[string]interface{}{
 		"method":       "build",
@@ -23,6 +23,8 @@ func resourceForemanHostgroup() *schema.Resource {
 		UpdateContext: resourceForemanHostgroupUpdate,
 		DeleteContext: resourceForemanHostgroupDelete,
 
+		CustomizeDiff: resourceParametersCustomizeDiffValues,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -87,10 +89,13 @@ func resourceForemanHostgroup() *schema.Resource {
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
+				DiffSuppressFunc: parametersDiffSuppressFunc,
 				Description: "A map of parameters that will be saved as hostgroup parameters " +
 					"in the group config.",
 			},
 
+			"parameter_types": parameterTypesSchema("hostgroup"),
+
 			// -- Foreign Key Relationships --
 
 			"architecture_id": {
@@ -237,6 +242,11 @@ func resourceForemanHostgroup() *schema.Resource {
 				ValidateFunc: validation.IntAtLeast(0),
 				Description:  "ID of the subnet associated with the hostgroup.",
 			},
+
+			// -- Taxonomies --
+
+			"location_ids":     locationIdsSchema("hostgroup"),
+			"organization_ids": organizationIdsSchema("hostgroup"),
 		},
 	}
 }
@@ -346,9 +356,14 @@ func buildForemanHostgroup(d *schema.ResourceData) *api.ForemanHostgroup {
 		hostgroup.SubnetId = attr.(int)
 	}
 	if attr, ok = d.GetOk("parameters"); ok {
-		hostgroup.HostGroupParameters = api.ToKV(attr.(map[string]interface{}))
+		hostgroup.HostGroupParameters = api.ToKV(
+			attr.(map[string]interface{}),
+			d.Get("parameter_types").(map[string]interface{}),
+		)
 	}
 
+	hostgroup.ForemanTaxonomies = buildForemanTaxonomies(d)
+
 	return &hostgroup
 }
 
@@ -362,6 +377,7 @@ func setResourceDataFromForemanHostgroup(d *schema.ResourceData, fh *api.Foreman
 	d.Set("name", fh.Name)
 	d.Set("pxe_loader", fh.PXELoader)
 	d.Set("parameters", api.FromKV(fh.HostGroupParameters))
+	d.Set("parameter_types", api.FromKVTypes(fh.HostGroupParameters))
 	d.Set("architecture_id", fh.ArchitectureId)
 	d.Set("compute_profile_id", fh.ComputeProfileId)
 	d.Set("compute_resource_id", fh.ComputeResourceId)
@@ -380,6 +396,7 @@ func setResourceDataFromForemanHostgroup(d *schema.ResourceData, fh *api.Foreman
 	d.Set("puppet_proxy_id", fh.PuppetProxyId)
 	d.Set("realm_id", fh.RealmId)
 	d.Set("subnet_id", fh.SubnetId)
+	setResourceDataFromForemanTaxonomies(d, fh.ForemanTaxonomies)
 }
 
 // -----------------------------------------------------------------------------
@@ -396,7 +413,7 @@ func resourceForemanHostgroupCreate(ctx context.Context, d *schema.ResourceData,
 
 	createdHostgroup, createErr := client.CreateHostgroup(ctx, h)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanHostgroup: [%+v]", createdHostgroup)
@@ -441,7 +458,7 @@ func resourceForemanHostgroupUpdate(ctx context.Context, d *schema.ResourceData,
 
 	updatedHostgroup, updateErr := client.UpdateHostgroup(ctx, h)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	log.Debugf("Updated ForemanHostgroup: [%+v]", updatedHostgroup)
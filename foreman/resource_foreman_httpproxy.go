@@ -58,6 +58,55 @@ func resourceForemanHTTPProxy() *schema.Resource {
 					autodoc.MetaExample,
 				),
 			},
+
+			"username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Username used to authenticate with the proxy.",
+			},
+
+			"password": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				Description: "Password used to authenticate with the proxy. The password " +
+					"is hidden in the plan output, but stored in plain text in the " +
+					"Terraform state - write-only attributes are not supported by this " +
+					"provider. The password is not read back from Foreman - changes " +
+					"made outside of Terraform are not detected. Removing it from the " +
+					"configuration removes the password from the proxy.",
+			},
+
+			"cacert": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "PEM encoded CA certificate used to verify the TLS " +
+					"certificate of the proxy.",
+			},
+
+			"test_connection": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether to test the connection to `test_url` through the " +
+					"proxy before creating or updating it. The apply fails and the " +
+					"proxy is left unchanged if Foreman cannot connect. Defaults to " +
+					"`false`.",
+			},
+
+			"test_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "https://theforeman.org",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description: "URL requested through the proxy to test the connection. " +
+					"Defaults to `https://theforeman.org`.",
+			},
+
+			// -- Taxonomies --
+
+			"location_ids":     locationIdsSchema("http proxy"),
+			"organization_ids": organizationIdsSchema("http proxy"),
 		},
 	}
 }
@@ -79,18 +128,70 @@ func buildForemanHTTPProxy(d *schema.ResourceData) *api.ForemanHTTPProxy {
 	proxy.ForemanObject = *obj
 
 	proxy.URL = d.Get("url").(string)
+	proxy.Username = d.Get("username").(string)
+	if attr, ok := d.GetOk("password"); ok {
+		password := attr.(string)
+		proxy.Password = &password
+	} else if d.HasChange("password") {
+		// Foreman keeps the current password if none is sent
+		password := ""
+		proxy.Password = &password
+	}
+	cacert := d.Get("cacert").(string)
+	proxy.CACert = &cacert
+
+	proxy.ForemanTaxonomies = buildForemanTaxonomies(d)
 
 	return &proxy
 }
 
 // setResourceDataFromForemanHTTPProxy sets a ResourceData's attributes from
-// the attributes of the supplied ForemanHTTPProxy struct
+// the attributes of the supplied ForemanHTTPProxy struct.  The password is
+// never returned by the API and left untouched.
 func setResourceDataFromForemanHTTPProxy(d *schema.ResourceData, fp *api.ForemanHTTPProxy) {
 	log.Tracef("resource_foreman_httpproxy.go#setResourceDataFromForemanHTTPProxy")
 
 	d.SetId(strconv.Itoa(fp.Id))
 	d.Set("name", fp.Name)
 	d.Set("url", fp.URL)
+	d.Set("username", fp.Username)
+	if fp.CACert != nil {
+		d.Set("cacert", *fp.CACert)
+	}
+	setResourceDataFromForemanTaxonomies(d, fp.ForemanTaxonomies)
+}
+
+// testForemanHTTPProxyConnection tests the connection through the http proxy
+// with the planned attributes if the resource enables the connection test
+func testForemanHTTPProxyConnection(ctx context.Context, d *schema.ResourceData, client *api.Client) diag.Diagnostics {
+	if !d.Get("test_connection").(bool) {
+		return nil
+	}
+
+	testURL := d.Get("test_url").(string)
+	testResult, testErr := client.TestHTTPProxyConnection(ctx, buildForemanHTTPProxy(d), testURL)
+	if testErr != nil {
+		return api.DiagnosticsFromError(d, testErr)
+	}
+
+	log.Debugf("Tested ForemanHTTPProxy: [%+v]", testResult)
+
+	if !testResult.Success() {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  "HTTP proxy connection test failed",
+				Detail: fmt.Sprintf(
+					"Foreman could not connect to [%s] through the http proxy [%s]: %s",
+					testURL,
+					d.Get("url"),
+					testResult.Message,
+				),
+			},
+		}
+	}
+
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -105,9 +206,13 @@ func resourceForemanHTTPProxyCreate(ctx context.Context, d *schema.ResourceData,
 
 	log.Debugf("ForemanHTTPProxy: [%+v]", p)
 
+	if diags := testForemanHTTPProxyConnection(ctx, d, client); diags.HasError() {
+		return diags
+	}
+
 	createdHTTPProxy, createErr := client.CreateHTTPProxy(ctx, p)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanHTTPProxy: [%+v]", createdHTTPProxy)
@@ -145,9 +250,13 @@ func resourceForemanHTTPProxyUpdate(ctx context.Context, d *schema.ResourceData,
 
 	log.Debugf("ForemanHTTPProxy: [%+v]", p)
 
+	if diags := testForemanHTTPProxyConnection(ctx, d, client); diags.HasError() {
+		return diags
+	}
+
 	updatedHTTPProxy, updateErr := client.UpdateHTTPProxy(ctx, p)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	log.Debugf("ForemanHTTPProxy: [%+v]", updatedHTTPProxy)
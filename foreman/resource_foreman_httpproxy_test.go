@@ -1,6 +1,7 @@
 package foreman
 
 import (
+	"context"
 	"encoding/json"
 	"math/rand"
 	"net/http"
@@ -30,6 +31,10 @@ func ForemanHTTPProxyToInstanceState(obj api.ForemanHTTPProxy) *terraform.Instan
 	attr := map[string]string{}
 	attr["name"] = obj.Name
 	attr["url"] = obj.URL
+	attr["username"] = obj.Username
+	if obj.CACert != nil {
+		attr["cacert"] = *obj.CACert
+	}
 	state.Attributes = attr
 	return &state
 }
@@ -58,6 +63,7 @@ func RandForemanHTTPProxy() api.ForemanHTTPProxy {
 	obj.ForemanObject = fo
 
 	obj.URL = tfrand.String(30, tfrand.Lower+"/:.")
+	obj.Username = tfrand.String(10, tfrand.Lower)
 
 	return obj
 }
@@ -162,6 +168,180 @@ func TestSetResourceDataFromForemanHTTPProxy_Value(t *testing.T) {
 
 }
 
+// Ensures the password, which is not returned by the API, is not replaced
+func TestSetResourceDataFromForemanHTTPProxy_Password(t *testing.T) {
+
+	obj := RandForemanHTTPProxy()
+	resourceData := MockForemanHTTPProxyResourceData(ForemanHTTPProxyToInstanceState(obj))
+	resourceData.Set("password", "s3cr3t")
+
+	setResourceDataFromForemanHTTPProxy(resourceData, &obj)
+
+	if resourceData.Get("password").(string) != "s3cr3t" {
+		t.Fatalf(
+			"setResourceDataFromForemanHTTPProxy replaced the password. "+
+				"Expected [s3cr3t], got [%s].",
+			resourceData.Get("password"),
+		)
+	}
+
+}
+
+// Ensures the configured password is sent and a password removed from the
+// configuration is removed from the proxy
+func TestBuildForemanHTTPProxy_Password(t *testing.T) {
+
+	obj := RandForemanHTTPProxy()
+	obj.Id = rand.Intn(100) + 1
+	r := resourceForemanHTTPProxy()
+
+	testCases := []struct {
+		config   map[string]interface{}
+		expected string
+	}{
+		{config: map[string]interface{}{"password": "s3cr3t"}, expected: `"s3cr3t"`},
+		{config: map[string]interface{}{"password": "n3w"}, expected: `"n3w"`},
+		{config: map[string]interface{}{}, expected: `""`},
+	}
+
+	for _, testCase := range testCases {
+		s := ForemanHTTPProxyToInstanceState(obj)
+		s.Attributes["password"] = "s3cr3t"
+		s.Attributes["test_url"] = "https://theforeman.org"
+
+		testCase.config["name"] = obj.Name
+		testCase.config["url"] = "https://proxy.example.com:8443"
+		diff, diffErr := r.Diff(context.TODO(), s, terraform.NewResourceConfigRaw(testCase.config), nil)
+		if diffErr != nil {
+			t.Fatalf("resourceForemanHTTPProxy could not diff the password: %s", diffErr)
+		}
+		resourceData, _ := schema.InternalMap(r.Schema).Data(s, diff)
+
+		proxyJSON, _ := json.Marshal(buildForemanHTTPProxy(resourceData))
+		var proxyMap map[string]json.RawMessage
+		json.Unmarshal(proxyJSON, &proxyMap)
+
+		actual := "absent"
+		if password, ok := proxyMap["password"]; ok {
+			actual = string(password)
+		}
+		if actual != testCase.expected {
+			t.Errorf(
+				"buildForemanHTTPProxy with the configured password [%v] sent the "+
+					"password [%s]. Expected [%s].",
+				testCase.config["password"],
+				actual,
+				testCase.expected,
+			)
+		}
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// testForemanHTTPProxyConnection
+// -----------------------------------------------------------------------------
+
+// Ensures the connection is only tested if enabled and a failed test fails
+// the operation
+func TestForemanHTTPProxyConnection(t *testing.T) {
+
+	obj := RandForemanHTTPProxy()
+	obj.Id = rand.Intn(100) + 1
+	testURI := HTTPProxiesURI + "/test_connection"
+
+	testCases := []struct {
+		testConnection bool
+		statusCode     int
+		response       string
+		expectedTests  int
+		expectError    bool
+	}{
+		{testConnection: false, statusCode: http.StatusOK, response: `{"status": "success"}`, expectedTests: 0},
+		{testConnection: true, statusCode: http.StatusOK, response: `{"status": "success", "message": "HTTP Proxy connection successful."}`, expectedTests: 1},
+		{testConnection: true, statusCode: http.StatusUnprocessableEntity, response: `{"status": "error", "message": "Connection refused"}`, expectedTests: 1, expectError: true},
+		{testConnection: true, statusCode: http.StatusInternalServerError, response: `{}`, expectedTests: 1, expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+
+		tests := 0
+		mux.HandleFunc(testURI, func(w http.ResponseWriter, r *http.Request) {
+			tests++
+			if r.Method != http.MethodPut {
+				t.Errorf("Connection test used [%s]. Expected [PUT]", r.Method)
+			}
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["test_url"] != "https://theforeman.org" || body["id"] != float64(obj.Id) {
+				t.Errorf("Connection test sent unexpected request data [%+v]", body)
+			}
+			w.WriteHeader(testCase.statusCode)
+			w.Write([]byte(testCase.response))
+		})
+
+		s := ForemanHTTPProxyToInstanceState(obj)
+		s.Attributes["test_connection"] = strconv.FormatBool(testCase.testConnection)
+		s.Attributes["test_url"] = "https://theforeman.org"
+		diags := testForemanHTTPProxyConnection(context.TODO(), MockForemanHTTPProxyResourceData(s), client)
+		server.Close()
+
+		if tests != testCase.expectedTests || diags.HasError() != testCase.expectError {
+			t.Errorf(
+				"testForemanHTTPProxyConnection with test_connection [%t] and "+
+					"response [%d %s] tested [%d] times with diagnostics [%+v]",
+				testCase.testConnection,
+				testCase.statusCode,
+				testCase.response,
+				tests,
+				diags,
+			)
+		}
+	}
+
+}
+
+// Ensures the connection is tested before the proxy is created, so that a
+// failed test does not leave a proxy behind
+func TestResourceForemanHTTPProxyCreate_TestConnection(t *testing.T) {
+
+	obj := RandForemanHTTPProxy()
+	obj.Id = 0
+
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(HTTPProxiesURI+"/test_connection", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if _, ok := body["id"]; ok {
+			t.Errorf("Connection test of a new proxy sent an ID [%+v]", body)
+		}
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"status": "error", "message": "Connection refused"}`))
+	})
+	mux.HandleFunc(HTTPProxiesURI, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("resourceForemanHTTPProxyCreate created a proxy failing the connection test")
+	})
+
+	s := ForemanHTTPProxyToInstanceState(obj)
+	s.Attributes["test_connection"] = "true"
+	s.Attributes["test_url"] = "https://theforeman.org"
+	resourceData := MockForemanHTTPProxyResourceData(s)
+	diags := resourceForemanHTTPProxyCreate(context.TODO(), resourceData, client)
+
+	if !diags.HasError() || resourceData.Id() != "0" {
+		t.Fatalf(
+			"resourceForemanHTTPProxyCreate did not fail on the connection test. "+
+				"Got ID [%s] and diagnostics [%+v].",
+			resourceData.Id(),
+			diags,
+		)
+	}
+
+}
+
 // ----------------------------------------------------------------------------
 // Test Cases for the Unit Test Framework
 // ----------------------------------------------------------------------------
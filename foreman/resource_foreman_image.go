@@ -150,12 +150,12 @@ func resourceForemanImageCreate(ctx context.Context, d *schema.ResourceData, met
 	if createErr != nil {
 		log.Debugf("%+v", createErr)
 
-		isUuidError := strings.Contains(createErr.(api.HTTPError).RespBody, "UUID has already been taken")
-		if createErr.(api.HTTPError).StatusCode == 422 && isUuidError {
+		isUuidError := strings.Contains(createErr.Error(), "UUID has already been taken")
+		if api.IsValidation(createErr) && isUuidError {
 			return diag.Errorf("You cannot use the same UUID for multiple images: '%s' is already taken by another Foreman image", img.UUID)
 		}
 
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	setResourceDataFromForemanImage(d, createdImage)
@@ -191,12 +191,12 @@ func resourceForemanImageUpdate(ctx context.Context, d *schema.ResourceData, met
 
 	updatedImage, updateErr := client.UpdateImage(ctx, img)
 	if updateErr != nil {
-		isUuidError := strings.Contains(updateErr.(api.HTTPError).RespBody, "UUID has already been taken")
-		if updateErr.(api.HTTPError).StatusCode == 422 && isUuidError {
+		isUuidError := strings.Contains(updateErr.Error(), "UUID has already been taken")
+		if api.IsValidation(updateErr) && isUuidError {
 			return diag.Errorf("You cannot use the same UUID for multiple images: '%s' is already taken by another Foreman image", img.UUID)
 		}
 
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	setResourceDataFromForemanImage(d, updatedImage)
@@ -216,7 +216,7 @@ func resourceForemanJobTemplateCreate(ctx context.Context, resdata *schema.Resou
 
 	created, err := client.CreateJobTemplate(ctx, jt)
 	if err != nil {
-		return diag.FromErr(err)
+		return api.DiagnosticsFromError(resdata, err)
 	}
 
 	setResourceDataFromForemanJobTemplate(resdata, created)
@@ -252,7 +252,7 @@ func resourceForemanJobTemplateUpdate(ctx context.Context, resdata *schema.Resou
 
 	updatedJT, err := c.UpdateJobTemplate(ctx, jt)
 	if err != nil {
-		return diag.FromErr(err)
+		return api.DiagnosticsFromError(resdata, err)
 	}
 
 	setResourceDataFromForemanJobTemplate(resdata, updatedJT)
@@ -107,7 +107,7 @@ func resourceForemanKatelloContentCredentialCreate(ctx context.Context, d *schem
 
 	createdKatelloContentCredential, createErr := client.CreateKatelloContentCredential(ctx, contentCredential)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanKatelloContentCredential: [%+v]", createdKatelloContentCredential)
@@ -147,7 +147,7 @@ func resourceForemanKatelloContentCredentialUpdate(ctx context.Context, d *schem
 
 	updatedKatelloContentCredential, updateErr := client.UpdateKatelloContentCredential(ctx, contentCredential)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	log.Debugf("ForemanKatelloContentCredential: [%+v]", updatedKatelloContentCredential)
@@ -439,7 +439,7 @@ func resourceForemanKatelloContentViewCreate(ctx context.Context, d *schema.Reso
 
 	createdCv, err := client.CreateKatelloContentView(ctx, cv)
 	if err != nil {
-		return diag.FromErr(err)
+		return api.DiagnosticsFromError(d, err)
 	}
 	utils.Debugf("createdCv: %+v", createdCv)
 
@@ -472,7 +472,7 @@ func resourceForemanKatelloContentViewUpdate(ctx context.Context, d *schema.Reso
 
 	updatedCv, err := client.UpdateKatelloContentView(ctx, cv)
 	if err != nil {
-		return diag.FromErr(err)
+		return api.DiagnosticsFromError(d, err)
 	}
 	utils.Debugf("updatedCv: %+v", updatedCv)
 
@@ -130,7 +130,7 @@ func resourceForemanKatelloLifecycleEnvironmentCreate(ctx context.Context, d *sc
 
 	createdLce, err := client.CreateKatelloLifecycleEnvironment(ctx, lce)
 	if err != nil {
-		return diag.FromErr(err)
+		return api.DiagnosticsFromError(d, err)
 	}
 	utils.Debugf("Created lce: %+v", createdLce)
 
@@ -163,7 +163,7 @@ func resourceForemanKatelloLifecycleEnvironmentUpdate(ctx context.Context, d *sc
 
 	updatedLce, err := client.UpdateKatelloLifecycleEnvironment(ctx, lce)
 	if err != nil {
-		return diag.FromErr(err)
+		return api.DiagnosticsFromError(d, err)
 	}
 	utils.Debugf("updatedLce: %+v", updatedLce)
 
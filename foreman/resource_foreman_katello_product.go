@@ -170,7 +170,7 @@ func resourceForemanKatelloProductCreate(ctx context.Context, d *schema.Resource
 
 	createdKatelloProduct, createErr := client.CreateKatelloProduct(ctx, product)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanKatelloProduct: [%+v]", createdKatelloProduct)
@@ -210,7 +210,7 @@ func resourceForemanKatelloProductUpdate(ctx context.Context, d *schema.Resource
 
 	updatedKatelloProduct, updateErr := client.UpdateKatelloProduct(ctx, product)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	log.Debugf("ForemanKatelloProduct: [%+v]", updatedKatelloProduct)
@@ -431,7 +431,7 @@ func resourceForemanKatelloRepositoryCreate(ctx context.Context, d *schema.Resou
 
 	createdKatelloRepository, createErr := client.CreateKatelloRepository(ctx, repository)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	err := handleDownloadConcurrencyBetweenTerraformAndKatello(d, createdKatelloRepository)
@@ -481,7 +481,7 @@ func resourceForemanKatelloRepositoryUpdate(ctx context.Context, d *schema.Resou
 
 	updatedKatelloRepository, updateErr := client.UpdateKatelloRepository(ctx, repository)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	err := handleDownloadConcurrencyBetweenTerraformAndKatello(d, updatedKatelloRepository)
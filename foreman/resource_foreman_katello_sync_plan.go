@@ -215,7 +215,7 @@ func resourceForemanKatelloSyncPlanCreate(ctx context.Context, d *schema.Resourc
 
 	createdKatelloSyncPlan, createErr := client.CreateKatelloSyncPlan(ctx, syncPlan)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanKatelloSyncPlan: [%+v]", createdKatelloSyncPlan)
@@ -255,7 +255,7 @@ func resourceForemanKatelloSyncPlanUpdate(ctx context.Context, d *schema.Resourc
 
 	updatedKatelloSyncPlan, updateErr := client.UpdateKatelloSyncPlan(ctx, syncPlan)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	log.Debugf("ForemanKatelloSyncPlan: [%+v]", updatedKatelloSyncPlan)
@@ -0,0 +1,254 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanLocation() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanLocationCreate,
+		ReadContext:   resourceForemanLocationRead,
+		UpdateContext: resourceForemanLocationUpdate,
+		DeleteContext: resourceForemanLocationDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Foreman representation of a location. Together with "+
+						"organizations, locations scope the other objects of Foreman. "+
+						"Locations can be nested.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description: fmt.Sprintf(
+					"The name of the location. "+
+						"%s \"DC1\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"title": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The name of the location including the names of " +
+					"its parents, ie: \"Europe/DC1\".",
+			},
+
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the location.",
+			},
+
+			"parent_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the parent location.",
+			},
+
+			"organization_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the organizations assigned to this location.",
+			},
+
+			"domain_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the domains assigned to this location.",
+			},
+
+			"subnet_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the subnets assigned to this location.",
+			},
+
+			"smart_proxy_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the smart proxies assigned to this location.",
+			},
+
+			"compute_resource_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the compute resources assigned to this location.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanLocation constructs a ForemanLocation reference from a
+// resource data reference.  The struct's  members are populated from the data
+// populated in the resource data.  Missing members will be left to the zero
+// value for that member's type.
+func buildForemanLocation(d *schema.ResourceData) *api.ForemanLocation {
+	log.Tracef("resource_foreman_location.go#buildForemanLocation")
+
+	location := api.ForemanLocation{}
+
+	obj := buildForemanObject(d)
+	location.ForemanObject = *obj
+
+	var attr interface{}
+	var ok bool
+
+	if attr, ok = d.GetOk("title"); ok {
+		location.Title = attr.(string)
+	}
+	if attr, ok = d.GetOk("description"); ok {
+		location.Description = attr.(string)
+	}
+	if attr, ok = d.GetOk("parent_id"); ok {
+		location.ParentId = attr.(int)
+	}
+
+	location.OrganizationIds = buildForemanIds(d, "organization_ids")
+	location.DomainIds = buildForemanIds(d, "domain_ids")
+	location.SubnetIds = buildForemanIds(d, "subnet_ids")
+	location.SmartProxyIds = buildForemanIds(d, "smart_proxy_ids")
+	location.ComputeResourceIds = buildForemanIds(d, "compute_resource_ids")
+
+	return &location
+}
+
+// setResourceDataFromForemanLocation sets a ResourceData's attributes from
+// the attributes of the supplied ForemanLocation reference
+func setResourceDataFromForemanLocation(d *schema.ResourceData, fl *api.ForemanLocation) {
+	log.Tracef("resource_foreman_location.go#setResourceDataFromForemanLocation")
+
+	d.SetId(strconv.Itoa(fl.Id))
+	d.Set("name", fl.Name)
+	d.Set("title", fl.Title)
+	d.Set("description", fl.Description)
+	d.Set("parent_id", fl.ParentId)
+	d.Set("organization_ids", fl.OrganizationIds)
+	d.Set("domain_ids", fl.DomainIds)
+	d.Set("subnet_ids", fl.SubnetIds)
+	d.Set("smart_proxy_ids", fl.SmartProxyIds)
+	d.Set("compute_resource_ids", fl.ComputeResourceIds)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanLocationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_location.go#Create")
+
+	client := meta.(*api.Client)
+	l := buildForemanLocation(d)
+
+	log.Debugf("ForemanLocation: [%+v]", l)
+
+	createdLocation, createErr := client.CreateLocation(ctx, l)
+	if createErr != nil {
+		return api.DiagnosticsFromError(d, createErr)
+	}
+
+	log.Debugf("Created ForemanLocation: [%+v]", createdLocation)
+
+	setResourceDataFromForemanLocation(d, createdLocation)
+
+	return nil
+}
+
+func resourceForemanLocationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_location.go#Read")
+
+	client := meta.(*api.Client)
+	l := buildForemanLocation(d)
+
+	log.Debugf("ForemanLocation: [%+v]", l)
+
+	readLocation, readErr := client.ReadLocation(ctx, l.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanLocation: [%+v]", readLocation)
+
+	setResourceDataFromForemanLocation(d, readLocation)
+
+	return nil
+}
+
+func resourceForemanLocationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_location.go#Update")
+
+	client := meta.(*api.Client)
+	l := buildForemanLocation(d)
+
+	log.Debugf("ForemanLocation: [%+v]", l)
+
+	updatedLocation, updateErr := client.UpdateLocation(ctx, l)
+	if updateErr != nil {
+		return api.DiagnosticsFromError(d, updateErr)
+	}
+
+	log.Debugf("Updated ForemanLocation: [%+v]", updatedLocation)
+
+	setResourceDataFromForemanLocation(d, updatedLocation)
+
+	return nil
+}
+
+func resourceForemanLocationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_location.go#Delete")
+
+	client := meta.(*api.Client)
+	l := buildForemanLocation(d)
+
+	log.Debugf("ForemanLocation: [%+v]", l)
+
+	return diag.FromErr(api.CheckDeleted(d, client.DeleteLocation(ctx, l.Id)))
+}
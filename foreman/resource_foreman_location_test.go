@@ -0,0 +1,522 @@
+package foreman
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const LocationsURI = api.FOREMAN_API_URL_PREFIX + "/locations"
+const LocationsTestDataPath = "testdata/1.11/locations"
+
+// Set attributes of the location resource holding IDs
+var locationIdSetAttributes = []string{
+	"organization_ids",
+	"domain_ids",
+	"subnet_ids",
+	"smart_proxy_ids",
+	"compute_resource_ids",
+}
+
+// Returns the ID slices of the location by the name of their attribute
+func foremanLocationIdSets(obj api.ForemanLocation) map[string][]int {
+	return map[string][]int{
+		"organization_ids":     obj.OrganizationIds,
+		"domain_ids":           obj.DomainIds,
+		"subnet_ids":           obj.SubnetIds,
+		"smart_proxy_ids":      obj.SmartProxyIds,
+		"compute_resource_ids": obj.ComputeResourceIds,
+	}
+}
+
+// Given a ForemanLocation, create a mock instance state reference
+func ForemanLocationToInstanceState(obj api.ForemanLocation) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = strconv.Itoa(obj.Id)
+	// Build the attribute map from ForemanLocation
+	attr := map[string]string{}
+	attr["name"] = obj.Name
+	attr["title"] = obj.Title
+	attr["description"] = obj.Description
+	attr["parent_id"] = strconv.Itoa(obj.ParentId)
+	for key, ids := range foremanLocationIdSets(obj) {
+		attr[key+".#"] = strconv.Itoa(len(ids))
+		for idx, val := range ids {
+			attr[fmt.Sprintf("%s.%d", key, idx)] = strconv.Itoa(val)
+		}
+	}
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanLocation resource, create a
+// mock ResourceData reference.
+func MockForemanLocationResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanLocation()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates an location
+// ResourceData reference
+func MockForemanLocationResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanLocation
+	ParseJSONFile(t, path, &obj)
+	s := ForemanLocationToInstanceState(obj)
+	return MockForemanLocationResourceData(s)
+}
+
+// Creates a random ForemanLocation struct
+func RandForemanLocation() api.ForemanLocation {
+	obj := api.ForemanLocation{}
+
+	fo := RandForemanObject()
+	obj.ForemanObject = fo
+
+	obj.Title = tfrand.String(10, tfrand.Lower) + "/" + obj.Name
+	obj.Description = tfrand.String(30, tfrand.Lower+" ")
+	obj.ParentId = rand.Intn(100) + 1
+	obj.OrganizationIds = tfrand.IntArrayUnique(rand.Intn(5))
+	obj.DomainIds = tfrand.IntArrayUnique(rand.Intn(5))
+	obj.SubnetIds = tfrand.IntArrayUnique(rand.Intn(5))
+	obj.SmartProxyIds = tfrand.IntArrayUnique(rand.Intn(5))
+	obj.ComputeResourceIds = tfrand.IntArrayUnique(rand.Intn(5))
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanLocation resource.
+// If the two references differ in their attributes, the test will raise
+// a fatal.
+func ForemanLocationResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := resourceForemanLocation()
+	for key, value := range r.Schema {
+		if value.Type != schema.TypeSet {
+			m[key] = value.Type
+		}
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+	for _, key := range locationIdSetAttributes {
+		set1 := r1.Get(key).(*schema.Set)
+		set2 := r2.Get(key).(*schema.Set)
+		if !set1.Equal(set2) {
+			t.Fatalf(
+				"ResourceData references differ in %s. [%v], [%v]",
+				key,
+				set1.List(),
+				set2.List(),
+			)
+		}
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// UnmarshalJSON
+// -----------------------------------------------------------------------------
+
+// Ensures the JSON unmarshal correctly sets the base attributes from
+// ForemanObject
+func TestLocationUnmarshalJSON_ForemanObject(t *testing.T) {
+
+	randObj := RandForemanObject()
+	randObjBytes, _ := json.Marshal(randObj)
+
+	var obj api.ForemanLocation
+	jsonDecErr := json.Unmarshal(randObjBytes, &obj)
+	if jsonDecErr != nil {
+		t.Errorf(
+			"ForemanLocation UnmarshalJSON could not decode base ForemanObject. "+
+				"Expected [nil] got [error]. Error value: [%s]",
+			jsonDecErr,
+		)
+	}
+
+	if !reflect.DeepEqual(obj.ForemanObject, randObj) {
+		t.Errorf(
+			"ForemanLocation UnmarshalJSON did not properly decode base "+
+				"ForemanObject properties. Expected [%+v], got [%+v]",
+			randObj,
+			obj.ForemanObject,
+		)
+	}
+
+}
+
+// Ensures the JSON unmarshal reduces the assigned objects to their IDs
+func TestLocationUnmarshalJSON_AssignedIds(t *testing.T) {
+
+	var obj api.ForemanLocation
+	ParseJSONFile(t, LocationsTestDataPath+"/read_response.json", &obj)
+
+	expected := map[string][]int{
+		"organization_ids":     {1, 12},
+		"domain_ids":           {39},
+		"subnet_ids":           {5, 6},
+		"smart_proxy_ids":      {1},
+		"compute_resource_ids": {2},
+	}
+	actual := foremanLocationIdSets(obj)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf(
+			"ForemanLocation UnmarshalJSON did not properly decode the "+
+				"assigned objects. Expected [%v], got [%v]",
+			expected,
+			actual,
+		)
+	}
+
+	if obj.ParentId != 3 || obj.Title != "Europe/DC1" {
+		t.Fatalf(
+			"ForemanLocation UnmarshalJSON did not properly decode the "+
+				"location. Got [%+v]",
+			obj,
+		)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// MarshalJSON
+// -----------------------------------------------------------------------------
+
+// Ensures only the managed assignments and no computed values are sent
+func TestLocationMarshalJSON(t *testing.T) {
+
+	obj := api.ForemanLocation{}
+	obj.Name = "DC1"
+	obj.Title = "Europe/DC1"
+	obj.OrganizationIds = []int{12}
+	obj.DomainIds = []int{}
+
+	objBytes, _ := json.Marshal(obj)
+
+	var actual map[string]interface{}
+	json.Unmarshal(objBytes, &actual)
+
+	expected := map[string]interface{}{
+		"name":             "DC1",
+		"description":      "",
+		"parent_id":        nil,
+		"organization_ids": []interface{}{float64(12)},
+		"domain_ids":       []interface{}{},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf(
+			"ForemanLocation MarshalJSON did not return the expected "+
+				"properties. Expected [%v], got [%v]",
+			expected,
+			actual,
+		)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// buildForemanLocation
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being read to
+// create a ForemanLocation
+func TestBuildForemanLocation(t *testing.T) {
+
+	expectedObj := RandForemanLocation()
+	expectedState := ForemanLocationToInstanceState(expectedObj)
+	expectedResourceData := MockForemanLocationResourceData(expectedState)
+
+	actualObj := *buildForemanLocation(expectedResourceData)
+
+	actualState := ForemanLocationToInstanceState(actualObj)
+	actualResourceData := MockForemanLocationResourceData(actualState)
+
+	ForemanLocationResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// -----------------------------------------------------------------------------
+// setResourceDataFromForemanLocation
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being set
+func TestSetResourceDataFromForemanLocation_Value(t *testing.T) {
+
+	expectedObj := RandForemanLocation()
+	expectedState := ForemanLocationToInstanceState(expectedObj)
+	expectedResourceData := MockForemanLocationResourceData(expectedState)
+
+	actualObj := api.ForemanLocation{}
+	actualState := ForemanLocationToInstanceState(actualObj)
+	actualResourceData := MockForemanLocationResourceData(actualState)
+
+	setResourceDataFromForemanLocation(actualResourceData, &expectedObj)
+
+	ForemanLocationResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanLocationCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := api.ForemanLocation{}
+	obj.Id = rand.Intn(100)
+	s := ForemanLocationToInstanceState(obj)
+	locationsURIById := LocationsURI + "/" + strconv.Itoa(obj.Id)
+
+	return []TestCaseCorrectURLAndMethod{
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanLocationCreate",
+				crudFunc:     resourceForemanLocationCreate,
+				resourceData: MockForemanLocationResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    LocationsURI,
+					expectedMethod: http.MethodPost,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanLocationRead",
+				crudFunc:     resourceForemanLocationRead,
+				resourceData: MockForemanLocationResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    locationsURIById,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanLocationUpdate",
+				crudFunc:     resourceForemanLocationUpdate,
+				resourceData: MockForemanLocationResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    locationsURIById,
+					expectedMethod: http.MethodPut,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanLocationDelete",
+				crudFunc:     resourceForemanLocationDelete,
+				resourceData: MockForemanLocationResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    locationsURIById,
+					expectedMethod: http.MethodDelete,
+				},
+			},
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func ResourceForemanLocationRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanLocation{}
+	obj.Id = rand.Intn(100)
+	s := ForemanLocationToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanLocationRead",
+			crudFunc:     resourceForemanLocationRead,
+			resourceData: MockForemanLocationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanLocationDelete",
+			crudFunc:     resourceForemanLocationDelete,
+			resourceData: MockForemanLocationResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestData()
+func ResourceForemanLocationRequestDataTestCases(t *testing.T) []TestCaseRequestData {
+
+	obj := RandForemanLocation()
+	s := ForemanLocationToInstanceState(obj)
+
+	rd := MockForemanLocationResourceData(s)
+	obj = *buildForemanLocation(rd)
+
+	_, _, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	reqData, _ := client.WrapJSON("location", obj)
+
+	return []TestCaseRequestData{
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanLocationCreate",
+				crudFunc:     resourceForemanLocationCreate,
+				resourceData: MockForemanLocationResourceData(s),
+			},
+			expectedData: reqData,
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanLocationUpdate",
+				crudFunc:     resourceForemanLocationUpdate,
+				resourceData: MockForemanLocationResourceData(s),
+			},
+			expectedData: reqData,
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func ResourceForemanLocationStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanLocation{}
+	obj.Id = rand.Intn(100)
+	s := ForemanLocationToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanLocationCreate",
+			crudFunc:     resourceForemanLocationCreate,
+			resourceData: MockForemanLocationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanLocationRead",
+			crudFunc:     resourceForemanLocationRead,
+			resourceData: MockForemanLocationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanLocationUpdate",
+			crudFunc:     resourceForemanLocationUpdate,
+			resourceData: MockForemanLocationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanLocationDelete",
+			crudFunc:     resourceForemanLocationDelete,
+			resourceData: MockForemanLocationResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func ResourceForemanLocationEmptyResponseTestCases(t *testing.T) []TestCase {
+	obj := api.ForemanLocation{}
+	obj.Id = rand.Intn(100)
+	s := ForemanLocationToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanLocationCreate",
+			crudFunc:     resourceForemanLocationCreate,
+			resourceData: MockForemanLocationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanLocationRead",
+			crudFunc:     resourceForemanLocationRead,
+			resourceData: MockForemanLocationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanLocationUpdate",
+			crudFunc:     resourceForemanLocationUpdate,
+			resourceData: MockForemanLocationResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func ResourceForemanLocationMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanLocation()
+	s := ForemanLocationToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper create response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanLocationCreate",
+				crudFunc:     resourceForemanLocationCreate,
+				resourceData: MockForemanLocationResourceData(s),
+			},
+			responseFile: LocationsTestDataPath + "/create_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanLocationResourceDataFromFile(
+				t,
+				LocationsTestDataPath+"/create_response.json",
+			),
+			compareFunc: ForemanLocationResourceDataCompare,
+		},
+		// If the server responds with a proper read response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanLocationRead",
+				crudFunc:     resourceForemanLocationRead,
+				resourceData: MockForemanLocationResourceData(s),
+			},
+			responseFile: LocationsTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanLocationResourceDataFromFile(
+				t,
+				LocationsTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanLocationResourceDataCompare,
+		},
+		// If the server responds with a proper update response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanLocationUpdate",
+				crudFunc:     resourceForemanLocationUpdate,
+				resourceData: MockForemanLocationResourceData(s),
+			},
+			responseFile: LocationsTestDataPath + "/update_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanLocationResourceDataFromFile(
+				t,
+				LocationsTestDataPath+"/update_response.json",
+			),
+			compareFunc: ForemanLocationResourceDataCompare,
+		},
+	}
+
+}
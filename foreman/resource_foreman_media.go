@@ -100,6 +100,11 @@ func resourceForemanMedia() *schema.Resource {
 				},
 				Description: "IDs of the operating systems associated with this media.",
 			},
+
+			// -- Taxonomies --
+
+			"location_ids":     locationIdsSchema("media"),
+			"organization_ids": organizationIdsSchema("media"),
 		},
 	}
 }
@@ -134,6 +139,8 @@ func buildForemanMedia(d *schema.ResourceData) *api.ForemanMedia {
 		media.OperatingSystemIds = conv.InterfaceSliceToIntSlice(attrSet.List())
 	}
 
+	media.ForemanTaxonomies = buildForemanTaxonomies(d)
+
 	return &media
 }
 
@@ -147,6 +154,7 @@ func setResourceDataFromForemanMedia(d *schema.ResourceData, fm *api.ForemanMedi
 	d.Set("path", fm.Path)
 	d.Set("os_family", fm.OSFamily)
 	d.Set("operatingsystem_ids", fm.OperatingSystemIds)
+	setResourceDataFromForemanTaxonomies(d, fm.ForemanTaxonomies)
 }
 
 // -----------------------------------------------------------------------------
@@ -163,7 +171,7 @@ func resourceForemanMediaCreate(ctx context.Context, d *schema.ResourceData, met
 
 	createdMedia, createErr := client.CreateMedia(ctx, m)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanMedia: [%+v]", createdMedia)
@@ -203,7 +211,7 @@ func resourceForemanMediaUpdate(ctx context.Context, d *schema.ResourceData, met
 
 	updatedMedia, updateErr := client.UpdateMedia(ctx, m)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	log.Debugf("Updated ForemanMedia: [%+v]", updatedMedia)
@@ -127,7 +127,7 @@ func resourceForemanModelCreate(ctx context.Context, d *schema.ResourceData, met
 
 	createdModel, createErr := client.CreateModel(ctx, m)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanModel: [%+v]", createdModel)
@@ -167,7 +167,7 @@ func resourceForemanModelUpdate(ctx context.Context, d *schema.ResourceData, met
 
 	updatedModel, updateErr := client.UpdateModel(ctx, m)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	log.Debugf("Updated ForemanModel: [%+v]", updatedModel)
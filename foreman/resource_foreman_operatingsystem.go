@@ -237,7 +237,7 @@ func buildForemanOperatingSystem(d *schema.ResourceData) *api.ForemanOperatingSy
 		os.PartitiontableIds = conv.InterfaceSliceToIntSlice(attrSet.List())
 	}
 	if attr, ok = d.GetOk("parameters"); ok {
-		os.OperatingSystemParameters = api.ToKV(attr.(map[string]interface{}))
+		os.OperatingSystemParameters = api.ToKV(attr.(map[string]interface{}), nil)
 	}
 
 	return &os
@@ -276,7 +276,7 @@ func resourceForemanOperatingSystemCreate(ctx context.Context, d *schema.Resourc
 
 	createdOs, createErr := client.CreateOperatingSystem(ctx, o)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanOperatingSystem: [%+v]", createdOs)
@@ -316,7 +316,7 @@ func resourceForemanOperatingSystemUpdate(ctx context.Context, d *schema.Resourc
 
 	updatedOs, updateErr := client.UpdateOperatingSystem(ctx, o)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	log.Debugf("Updated ForemanOperatingSystem: [%+v]", updatedOs)
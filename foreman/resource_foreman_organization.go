@@ -0,0 +1,306 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceForemanOrganization() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanOrganizationCreate,
+		ReadContext:   resourceForemanOrganizationRead,
+		UpdateContext: resourceForemanOrganizationUpdate,
+		DeleteContext: resourceForemanOrganizationDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Foreman representation of an organization. Together with "+
+						"locations, organizations scope the other objects of Foreman.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description: fmt.Sprintf(
+					"The name of the organization. "+
+						"%s \"Tenant A\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"title": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The name of the organization including the names of " +
+					"its parents, ie: \"Parent Org/Child Org\".",
+			},
+
+			"label": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Description: "Unique label of the organization. Only supported if " +
+					"Katello is installed. Katello derives the label from the name if " +
+					"it is not set. The label can not be changed after the " +
+					"organization was created.",
+			},
+
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the organization.",
+			},
+
+			"parent_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the parent organization.",
+			},
+
+			"location_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the locations assigned to this organization.",
+			},
+
+			"domain_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the domains assigned to this organization.",
+			},
+
+			"subnet_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the subnets assigned to this organization.",
+			},
+
+			"smart_proxy_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the smart proxies assigned to this organization.",
+			},
+
+			"compute_resource_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the compute resources assigned to this organization.",
+			},
+
+			"medium_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the installation media assigned to this organization.",
+			},
+
+			"hostgroup_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the hostgroups assigned to this organization.",
+			},
+
+			"user_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the users assigned to this organization.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// buildForemanOrganization constructs a ForemanOrganization reference from a
+// resource data reference.  The struct's  members are populated from the data
+// populated in the resource data.  Missing members will be left to the zero
+// value for that member's type.
+func buildForemanOrganization(d *schema.ResourceData) *api.ForemanOrganization {
+	log.Tracef("resource_foreman_organization.go#buildForemanOrganization")
+
+	organization := api.ForemanOrganization{}
+
+	obj := buildForemanObject(d)
+	organization.ForemanObject = *obj
+
+	var attr interface{}
+	var ok bool
+
+	if attr, ok = d.GetOk("title"); ok {
+		organization.Title = attr.(string)
+	}
+	if attr, ok = d.GetOk("label"); ok {
+		organization.Label = attr.(string)
+	}
+	if attr, ok = d.GetOk("description"); ok {
+		organization.Description = attr.(string)
+	}
+	if attr, ok = d.GetOk("parent_id"); ok {
+		organization.ParentId = attr.(int)
+	}
+
+	organization.LocationIds = buildForemanIds(d, "location_ids")
+	organization.DomainIds = buildForemanIds(d, "domain_ids")
+	organization.SubnetIds = buildForemanIds(d, "subnet_ids")
+	organization.SmartProxyIds = buildForemanIds(d, "smart_proxy_ids")
+	organization.ComputeResourceIds = buildForemanIds(d, "compute_resource_ids")
+	organization.MediumIds = buildForemanIds(d, "medium_ids")
+	organization.HostgroupIds = buildForemanIds(d, "hostgroup_ids")
+	organization.UserIds = buildForemanIds(d, "user_ids")
+
+	return &organization
+}
+
+// setResourceDataFromForemanOrganization sets a ResourceData's attributes from
+// the attributes of the supplied ForemanOrganization reference
+func setResourceDataFromForemanOrganization(d *schema.ResourceData, fo *api.ForemanOrganization) {
+	log.Tracef("resource_foreman_organization.go#setResourceDataFromForemanOrganization")
+
+	d.SetId(strconv.Itoa(fo.Id))
+	d.Set("name", fo.Name)
+	d.Set("title", fo.Title)
+	d.Set("label", fo.Label)
+	d.Set("description", fo.Description)
+	d.Set("parent_id", fo.ParentId)
+	d.Set("location_ids", fo.LocationIds)
+	d.Set("domain_ids", fo.DomainIds)
+	d.Set("subnet_ids", fo.SubnetIds)
+	d.Set("smart_proxy_ids", fo.SmartProxyIds)
+	d.Set("compute_resource_ids", fo.ComputeResourceIds)
+	d.Set("medium_ids", fo.MediumIds)
+	d.Set("hostgroup_ids", fo.HostgroupIds)
+	d.Set("user_ids", fo.UserIds)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanOrganizationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_organization.go#Create")
+
+	client := meta.(*api.Client)
+	o := buildForemanOrganization(d)
+
+	log.Debugf("ForemanOrganization: [%+v]", o)
+
+	createdOrganization, createErr := client.CreateOrganization(ctx, o)
+	if createErr != nil {
+		return api.DiagnosticsFromError(d, createErr)
+	}
+
+	log.Debugf("Created ForemanOrganization: [%+v]", createdOrganization)
+
+	setResourceDataFromForemanOrganization(d, createdOrganization)
+
+	return nil
+}
+
+func resourceForemanOrganizationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_organization.go#Read")
+
+	client := meta.(*api.Client)
+	o := buildForemanOrganization(d)
+
+	log.Debugf("ForemanOrganization: [%+v]", o)
+
+	readOrganization, readErr := client.ReadOrganization(ctx, o.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanOrganization: [%+v]", readOrganization)
+
+	setResourceDataFromForemanOrganization(d, readOrganization)
+
+	return nil
+}
+
+func resourceForemanOrganizationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_organization.go#Update")
+
+	client := meta.(*api.Client)
+	o := buildForemanOrganization(d)
+
+	log.Debugf("ForemanOrganization: [%+v]", o)
+
+	updatedOrganization, updateErr := client.UpdateOrganization(ctx, o)
+	if updateErr != nil {
+		return api.DiagnosticsFromError(d, updateErr)
+	}
+
+	log.Debugf("Updated ForemanOrganization: [%+v]", updatedOrganization)
+
+	setResourceDataFromForemanOrganization(d, updatedOrganization)
+
+	return nil
+}
+
+func resourceForemanOrganizationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_organization.go#Delete")
+
+	client := meta.(*api.Client)
+	o := buildForemanOrganization(d)
+
+	log.Debugf("ForemanOrganization: [%+v]", o)
+
+	// NOTE(ALL): With Katello installed, DeleteOrganization waits for the
+	//   asynchronous destroy task to finish
+	return diag.FromErr(api.CheckDeleted(d, client.DeleteOrganization(ctx, o.Id)))
+}
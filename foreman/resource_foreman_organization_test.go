@@ -0,0 +1,536 @@
+package foreman
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	tfrand "github.com/HanseMerkur/terraform-provider-utils/rand"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const OrganizationsURI = api.FOREMAN_API_URL_PREFIX + "/organizations"
+const OrganizationsTestDataPath = "testdata/1.11/organizations"
+
+// Set attributes of the organization resource holding IDs
+var organizationIdSetAttributes = []string{
+	"location_ids",
+	"domain_ids",
+	"subnet_ids",
+	"smart_proxy_ids",
+	"compute_resource_ids",
+	"medium_ids",
+	"hostgroup_ids",
+	"user_ids",
+}
+
+// Returns the ID slices of the organization by the name of their attribute
+func foremanOrganizationIdSets(obj api.ForemanOrganization) map[string][]int {
+	return map[string][]int{
+		"location_ids":         obj.LocationIds,
+		"domain_ids":           obj.DomainIds,
+		"subnet_ids":           obj.SubnetIds,
+		"smart_proxy_ids":      obj.SmartProxyIds,
+		"compute_resource_ids": obj.ComputeResourceIds,
+		"medium_ids":           obj.MediumIds,
+		"hostgroup_ids":        obj.HostgroupIds,
+		"user_ids":             obj.UserIds,
+	}
+}
+
+// Given a ForemanOrganization, create a mock instance state reference
+func ForemanOrganizationToInstanceState(obj api.ForemanOrganization) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = strconv.Itoa(obj.Id)
+	// Build the attribute map from ForemanOrganization
+	attr := map[string]string{}
+	attr["name"] = obj.Name
+	attr["title"] = obj.Title
+	attr["label"] = obj.Label
+	attr["description"] = obj.Description
+	attr["parent_id"] = strconv.Itoa(obj.ParentId)
+	for key, ids := range foremanOrganizationIdSets(obj) {
+		attr[key+".#"] = strconv.Itoa(len(ids))
+		for idx, val := range ids {
+			attr[fmt.Sprintf("%s.%d", key, idx)] = strconv.Itoa(val)
+		}
+	}
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanOrganization resource, create a
+// mock ResourceData reference.
+func MockForemanOrganizationResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanOrganization()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates an organization
+// ResourceData reference
+func MockForemanOrganizationResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanOrganization
+	ParseJSONFile(t, path, &obj)
+	s := ForemanOrganizationToInstanceState(obj)
+	return MockForemanOrganizationResourceData(s)
+}
+
+// Creates a random ForemanOrganization struct
+func RandForemanOrganization() api.ForemanOrganization {
+	obj := api.ForemanOrganization{}
+
+	fo := RandForemanObject()
+	obj.ForemanObject = fo
+
+	obj.Title = tfrand.String(10, tfrand.Lower) + "/" + obj.Name
+	obj.Label = tfrand.String(10, tfrand.Lower+"_")
+	obj.Description = tfrand.String(30, tfrand.Lower+" ")
+	obj.ParentId = rand.Intn(100) + 1
+	obj.LocationIds = tfrand.IntArrayUnique(rand.Intn(5))
+	obj.DomainIds = tfrand.IntArrayUnique(rand.Intn(5))
+	obj.SubnetIds = tfrand.IntArrayUnique(rand.Intn(5))
+	obj.SmartProxyIds = tfrand.IntArrayUnique(rand.Intn(5))
+	obj.ComputeResourceIds = tfrand.IntArrayUnique(rand.Intn(5))
+	obj.MediumIds = tfrand.IntArrayUnique(rand.Intn(5))
+	obj.HostgroupIds = tfrand.IntArrayUnique(rand.Intn(5))
+	obj.UserIds = tfrand.IntArrayUnique(rand.Intn(5))
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanOrganization resource.
+// If the two references differ in their attributes, the test will raise
+// a fatal.
+func ForemanOrganizationResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := resourceForemanOrganization()
+	for key, value := range r.Schema {
+		if value.Type != schema.TypeSet {
+			m[key] = value.Type
+		}
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+	for _, key := range organizationIdSetAttributes {
+		set1 := r1.Get(key).(*schema.Set)
+		set2 := r2.Get(key).(*schema.Set)
+		if !set1.Equal(set2) {
+			t.Fatalf(
+				"ResourceData references differ in %s. [%v], [%v]",
+				key,
+				set1.List(),
+				set2.List(),
+			)
+		}
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// UnmarshalJSON
+// -----------------------------------------------------------------------------
+
+// Ensures the JSON unmarshal correctly sets the base attributes from
+// ForemanObject
+func TestOrganizationUnmarshalJSON_ForemanObject(t *testing.T) {
+
+	randObj := RandForemanObject()
+	randObjBytes, _ := json.Marshal(randObj)
+
+	var obj api.ForemanOrganization
+	jsonDecErr := json.Unmarshal(randObjBytes, &obj)
+	if jsonDecErr != nil {
+		t.Errorf(
+			"ForemanOrganization UnmarshalJSON could not decode base ForemanObject. "+
+				"Expected [nil] got [error]. Error value: [%s]",
+			jsonDecErr,
+		)
+	}
+
+	if !reflect.DeepEqual(obj.ForemanObject, randObj) {
+		t.Errorf(
+			"ForemanOrganization UnmarshalJSON did not properly decode base "+
+				"ForemanObject properties. Expected [%+v], got [%+v]",
+			randObj,
+			obj.ForemanObject,
+		)
+	}
+
+}
+
+// Ensures the JSON unmarshal reduces the assigned objects to their IDs
+func TestOrganizationUnmarshalJSON_AssignedIds(t *testing.T) {
+
+	var obj api.ForemanOrganization
+	ParseJSONFile(t, OrganizationsTestDataPath+"/read_response.json", &obj)
+
+	expected := map[string][]int{
+		"location_ids":         {2},
+		"domain_ids":           {39},
+		"subnet_ids":           {5, 6},
+		"smart_proxy_ids":      {1},
+		"compute_resource_ids": {},
+		"medium_ids":           {9},
+		"hostgroup_ids":        {},
+		"user_ids":             {4},
+	}
+	actual := foremanOrganizationIdSets(obj)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf(
+			"ForemanOrganization UnmarshalJSON did not properly decode the "+
+				"assigned objects. Expected [%v], got [%v]",
+			expected,
+			actual,
+		)
+	}
+
+	if obj.ParentId != 1 || obj.Title != "Default Organization/Tenant A" || obj.Label != "Tenant_A" {
+		t.Fatalf(
+			"ForemanOrganization UnmarshalJSON did not properly decode the "+
+				"organization. Got [%+v]",
+			obj,
+		)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// MarshalJSON
+// -----------------------------------------------------------------------------
+
+// Ensures only the managed assignments and no computed values are sent
+func TestOrganizationMarshalJSON(t *testing.T) {
+
+	obj := api.ForemanOrganization{}
+	obj.Name = "Tenant A"
+	obj.Title = "Default Organization/Tenant A"
+	obj.LocationIds = []int{2}
+	obj.DomainIds = []int{}
+
+	objBytes, _ := json.Marshal(obj)
+
+	var actual map[string]interface{}
+	json.Unmarshal(objBytes, &actual)
+
+	expected := map[string]interface{}{
+		"name":         "Tenant A",
+		"description":  "",
+		"parent_id":    nil,
+		"location_ids": []interface{}{float64(2)},
+		"domain_ids":   []interface{}{},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf(
+			"ForemanOrganization MarshalJSON did not return the expected "+
+				"properties. Expected [%v], got [%v]",
+			expected,
+			actual,
+		)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// buildForemanOrganization
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being read to
+// create a ForemanOrganization
+func TestBuildForemanOrganization(t *testing.T) {
+
+	expectedObj := RandForemanOrganization()
+	expectedState := ForemanOrganizationToInstanceState(expectedObj)
+	expectedResourceData := MockForemanOrganizationResourceData(expectedState)
+
+	actualObj := *buildForemanOrganization(expectedResourceData)
+
+	actualState := ForemanOrganizationToInstanceState(actualObj)
+	actualResourceData := MockForemanOrganizationResourceData(actualState)
+
+	ForemanOrganizationResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// -----------------------------------------------------------------------------
+// setResourceDataFromForemanOrganization
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being set
+func TestSetResourceDataFromForemanOrganization_Value(t *testing.T) {
+
+	expectedObj := RandForemanOrganization()
+	expectedState := ForemanOrganizationToInstanceState(expectedObj)
+	expectedResourceData := MockForemanOrganizationResourceData(expectedState)
+
+	actualObj := api.ForemanOrganization{}
+	actualState := ForemanOrganizationToInstanceState(actualObj)
+	actualResourceData := MockForemanOrganizationResourceData(actualState)
+
+	setResourceDataFromForemanOrganization(actualResourceData, &expectedObj)
+
+	ForemanOrganizationResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanOrganizationCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := api.ForemanOrganization{}
+	obj.Id = rand.Intn(100)
+	s := ForemanOrganizationToInstanceState(obj)
+	organizationsURIById := OrganizationsURI + "/" + strconv.Itoa(obj.Id)
+
+	return []TestCaseCorrectURLAndMethod{
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationCreate",
+				crudFunc:     resourceForemanOrganizationCreate,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    OrganizationsURI,
+					expectedMethod: http.MethodPost,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationRead",
+				crudFunc:     resourceForemanOrganizationRead,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    organizationsURIById,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationUpdate",
+				crudFunc:     resourceForemanOrganizationUpdate,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    organizationsURIById,
+					expectedMethod: http.MethodPut,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationDelete",
+				crudFunc:     resourceForemanOrganizationDelete,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    organizationsURIById,
+					expectedMethod: http.MethodDelete,
+				},
+			},
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func ResourceForemanOrganizationRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanOrganization{}
+	obj.Id = rand.Intn(100)
+	s := ForemanOrganizationToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanOrganizationRead",
+			crudFunc:     resourceForemanOrganizationRead,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanOrganizationDelete",
+			crudFunc:     resourceForemanOrganizationDelete,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestData()
+func ResourceForemanOrganizationRequestDataTestCases(t *testing.T) []TestCaseRequestData {
+
+	obj := RandForemanOrganization()
+	s := ForemanOrganizationToInstanceState(obj)
+
+	rd := MockForemanOrganizationResourceData(s)
+	obj = *buildForemanOrganization(rd)
+
+	_, _, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	reqData, _ := client.WrapJSON("organization", obj)
+
+	return []TestCaseRequestData{
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationCreate",
+				crudFunc:     resourceForemanOrganizationCreate,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			expectedData: reqData,
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationUpdate",
+				crudFunc:     resourceForemanOrganizationUpdate,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			expectedData: reqData,
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func ResourceForemanOrganizationStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := api.ForemanOrganization{}
+	obj.Id = rand.Intn(100)
+	s := ForemanOrganizationToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanOrganizationCreate",
+			crudFunc:     resourceForemanOrganizationCreate,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanOrganizationRead",
+			crudFunc:     resourceForemanOrganizationRead,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanOrganizationUpdate",
+			crudFunc:     resourceForemanOrganizationUpdate,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanOrganizationDelete",
+			crudFunc:     resourceForemanOrganizationDelete,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func ResourceForemanOrganizationEmptyResponseTestCases(t *testing.T) []TestCase {
+	obj := api.ForemanOrganization{}
+	obj.Id = rand.Intn(100)
+	s := ForemanOrganizationToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanOrganizationCreate",
+			crudFunc:     resourceForemanOrganizationCreate,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanOrganizationRead",
+			crudFunc:     resourceForemanOrganizationRead,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanOrganizationUpdate",
+			crudFunc:     resourceForemanOrganizationUpdate,
+			resourceData: MockForemanOrganizationResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func ResourceForemanOrganizationMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanOrganization()
+	s := ForemanOrganizationToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper create response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationCreate",
+				crudFunc:     resourceForemanOrganizationCreate,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			responseFile: OrganizationsTestDataPath + "/create_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanOrganizationResourceDataFromFile(
+				t,
+				OrganizationsTestDataPath+"/create_response.json",
+			),
+			compareFunc: ForemanOrganizationResourceDataCompare,
+		},
+		// If the server responds with a proper read response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationRead",
+				crudFunc:     resourceForemanOrganizationRead,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			responseFile: OrganizationsTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanOrganizationResourceDataFromFile(
+				t,
+				OrganizationsTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanOrganizationResourceDataCompare,
+		},
+		// If the server responds with a proper update response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanOrganizationUpdate",
+				crudFunc:     resourceForemanOrganizationUpdate,
+				resourceData: MockForemanOrganizationResourceData(s),
+			},
+			responseFile: OrganizationsTestDataPath + "/update_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanOrganizationResourceDataFromFile(
+				t,
+				OrganizationsTestDataPath+"/update_response.json",
+			),
+			compareFunc: ForemanOrganizationResourceDataCompare,
+		},
+	}
+
+}
@@ -173,7 +173,7 @@ func resourceForemanOverrideValueCreate(ctx context.Context, d *schema.ResourceD
 
 	createdOverrideValue, createErr := client.CreateOverrideValue(ctx, override)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanOverrideValue: [%+v]", createdOverrideValue)
@@ -218,7 +218,7 @@ func resourceForemanOverrideValueUpdate(ctx context.Context, d *schema.ResourceD
 
 	updatedOverrideValue, updateErr := client.UpdateOverrideValue(ctx, override)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	log.Debugf("ForemanOverrideValue: [%+v]", updatedOverrideValue)
@@ -22,6 +22,8 @@ func resourceForemanParameter() *schema.Resource {
 		UpdateContext: resourceForemanParameterUpdate,
 		DeleteContext: resourceForemanParameterDelete,
 
+		CustomizeDiff: resourceParameterCustomizeDiffValue,
+
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -80,9 +82,11 @@ func resourceForemanParameter() *schema.Resource {
 				Required: true,
 			},
 			"value": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: parameterValueDiffSuppressFunc,
 			},
+			"parameter_type": parameterTypeSchema(),
 		},
 	}
 }
@@ -127,6 +131,9 @@ func buildForemanParameter(d *schema.ResourceData) *api.ForemanParameter {
 	if attr, ok = d.GetOk("value"); ok {
 		parameter.Parameter.Value = attr.(string)
 	}
+	if attr, ok = d.GetOk("parameter_type"); ok {
+		parameter.Parameter.ParameterType = attr.(string)
+	}
 	return &parameter
 }
 
@@ -143,6 +150,7 @@ func setResourceDataFromForemanParameter(d *schema.ResourceData, fd *api.Foreman
 	d.Set("subnet_id", fd.SubnetID)
 	d.Set("name", fd.Parameter.Name)
 	d.Set("value", fd.Parameter.Value)
+	d.Set("parameter_type", parameterTypeOrDefault(fd.Parameter.ParameterType))
 }
 
 // -----------------------------------------------------------------------------
@@ -159,7 +167,7 @@ func resourceForemanParameterCreate(ctx context.Context, d *schema.ResourceData,
 
 	createdParam, createErr := client.CreateParameter(ctx, p)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanParameter: [%+v]", createdParam)
@@ -199,7 +207,7 @@ func resourceForemanParameterUpdate(ctx context.Context, d *schema.ResourceData,
 
 	updatedParam, updateErr := client.UpdateParameter(ctx, p, p.Id)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	log.Debugf("Updated ForemanParameter: [%+v]", updatedParam)
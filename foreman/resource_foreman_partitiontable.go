@@ -146,6 +146,11 @@ func resourceForemanPartitionTable() *schema.Resource {
 				Optional:    true,
 				Description: "Description of the partition table",
 			},
+
+			// -- Taxonomies --
+
+			"location_ids":     locationIdsSchema("partition table"),
+			"organization_ids": organizationIdsSchema("partition table"),
 		},
 	}
 }
@@ -206,6 +211,8 @@ func buildForemanPartitionTable(d *schema.ResourceData) *api.ForemanPartitionTab
 		table.Description = attr.(string)
 	}
 
+	table.ForemanTaxonomies = buildForemanTaxonomies(d)
+
 	return &table
 }
 
@@ -255,6 +262,7 @@ func setResourceDataFromForemanPartitionTable(d *schema.ResourceData, ft *api.Fo
 	if attr, ok = d.GetOk("description"); ok {
 		d.Set("description", attr.(string))
 	}
+	setResourceDataFromForemanTaxonomies(d, ft.ForemanTaxonomies)
 }
 
 // -----------------------------------------------------------------------------
@@ -271,7 +279,7 @@ func resourceForemanPartitionTableCreate(ctx context.Context, d *schema.Resource
 
 	createdTable, createErr := client.CreatePartitionTable(ctx, t)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanPartitionTable: [%+v]", createdTable)
@@ -311,7 +319,7 @@ func resourceForemanPartitionTableUpdate(ctx context.Context, d *schema.Resource
 
 	updatedTable, updateErr := client.UpdatePartitionTable(ctx, t)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	log.Debugf("Updated ForemanPartitionTable: [%+v]", updatedTable)
@@ -0,0 +1,253 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Layout of the timestamps returned by the personal access token API
+const personalAccessTokenTimeLayout = "2006-01-02 15:04:05 MST"
+
+func resourceForemanPersonalAccessToken() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanPersonalAccessTokenCreate,
+		ReadContext:   resourceForemanPersonalAccessTokenRead,
+		DeleteContext: resourceForemanPersonalAccessTokenDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceForemanPersonalAccessTokenImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s A personal access token of a user. The value of the token "+
+						"is only known after it has been created - imported tokens "+
+						"have no value. Destroying the resource revokes the token. "+
+						"Tokens are imported as `<user_id>/<id>`.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"user_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "ID of the user the token belongs to.",
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: fmt.Sprintf(
+					"Name of the token."+
+						"%s \"ci-pipeline\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"expires_at": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description: fmt.Sprintf(
+					"Expiry of the token as RFC 3339 timestamp. Tokens without an "+
+						"expiry never expire."+
+						"%s \"2027-01-01T00:00:00Z\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Value of the token, used in place of the user's password.",
+			},
+
+			"active": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the token can be used, ie: it is neither revoked nor expired.",
+			},
+
+			"revoked": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the token has been revoked.",
+			},
+
+			"last_used_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Timestamp of the last request authenticated with the token.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// parsePersonalAccessTokenTime parses a timestamp either in RFC 3339 or in the
+// layout returned by Foreman
+func parsePersonalAccessTokenTime(value string) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Parse(personalAccessTokenTimeLayout, value)
+	}
+	return t, nil
+}
+
+// buildForemanPersonalAccessToken constructs a ForemanPersonalAccessToken
+// struct from a resource data reference.  The struct's members are populated
+// from the data populated in the resource data.  Missing members will be left
+// to the zero value for that member's type.
+func buildForemanPersonalAccessToken(d *schema.ResourceData) *api.ForemanPersonalAccessToken {
+	log.Tracef("resource_foreman_personal_access_token.go#buildForemanPersonalAccessToken")
+
+	token := api.ForemanPersonalAccessToken{}
+
+	obj := buildForemanObject(d)
+	token.ForemanObject = *obj
+
+	token.UserId = d.Get("user_id").(int)
+	token.ExpiresAt = d.Get("expires_at").(string)
+
+	return &token
+}
+
+// setResourceDataFromForemanPersonalAccessToken sets a ResourceData's
+// attributes from the attributes of the supplied ForemanPersonalAccessToken
+// struct.  The token's value is only set when present, ie: on create.
+func setResourceDataFromForemanPersonalAccessToken(d *schema.ResourceData, ft *api.ForemanPersonalAccessToken) {
+	log.Tracef("resource_foreman_personal_access_token.go#setResourceDataFromForemanPersonalAccessToken")
+
+	d.SetId(strconv.Itoa(ft.Id))
+	d.Set("name", ft.Name)
+	if ft.UserId != 0 {
+		d.Set("user_id", ft.UserId)
+	}
+	if ft.TokenValue != "" {
+		d.Set("token", ft.TokenValue)
+	}
+
+	// Foreman returns the expiry in its own layout - keep the configured value
+	// as long as it denotes the same point in time
+	expiresAt := ft.ExpiresAt
+	if configured, ok := d.GetOk("expires_at"); ok && expiresAt != "" {
+		configuredTime, configuredErr := parsePersonalAccessTokenTime(configured.(string))
+		readTime, readErr := parsePersonalAccessTokenTime(expiresAt)
+		if configuredErr == nil && readErr == nil && configuredTime.Equal(readTime) {
+			expiresAt = configured.(string)
+		}
+	}
+	d.Set("expires_at", expiresAt)
+
+	d.Set("active", ft.Active)
+	d.Set("revoked", ft.Revoked)
+	d.Set("last_used_at", ft.LastUsedAt)
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanPersonalAccessTokenCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_personal_access_token.go#Create")
+
+	client := meta.(*api.Client)
+	t := buildForemanPersonalAccessToken(d)
+
+	createdToken, createErr := client.CreatePersonalAccessToken(ctx, t)
+	if createErr != nil {
+		return api.DiagnosticsFromError(d, createErr)
+	}
+
+	log.Debugf("Created ForemanPersonalAccessToken: [%d]", createdToken.Id)
+
+	setResourceDataFromForemanPersonalAccessToken(d, createdToken)
+
+	return nil
+}
+
+func resourceForemanPersonalAccessTokenRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_personal_access_token.go#Read")
+
+	client := meta.(*api.Client)
+	t := buildForemanPersonalAccessToken(d)
+
+	readToken, readErr := client.ReadPersonalAccessToken(ctx, t.UserId, t.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanPersonalAccessToken: [%+v]", readToken)
+
+	// A revoked or expired token cannot be used again - remove it from the
+	// state so that a new token is created
+	if readToken.Revoked || !readToken.Active {
+		log.Warningf(
+			"Personal access token [%d] of user [%d] is revoked or expired, removing it from the state",
+			t.Id,
+			t.UserId,
+		)
+		d.SetId("")
+		return nil
+	}
+
+	setResourceDataFromForemanPersonalAccessToken(d, readToken)
+
+	return nil
+}
+
+func resourceForemanPersonalAccessTokenDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_personal_access_token.go#Delete")
+
+	client := meta.(*api.Client)
+	t := buildForemanPersonalAccessToken(d)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return diag.FromErr(api.CheckDeleted(d, client.RevokePersonalAccessToken(ctx, t.UserId, t.Id)))
+}
+
+// resourceForemanPersonalAccessTokenImport splits the imported ID of the form
+// <user_id>/<id> since tokens are nested below their user
+func resourceForemanPersonalAccessTokenImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Tracef("resource_foreman_personal_access_token.go#Import")
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("unexpected ID [%s], expected <user_id>/<id>", d.Id())
+	}
+	userId, userErr := strconv.Atoi(parts[0])
+	id, idErr := strconv.Atoi(parts[1])
+	if userErr != nil || idErr != nil {
+		return nil, fmt.Errorf("unexpected ID [%s], expected <user_id>/<id>", d.Id())
+	}
+
+	d.SetId(strconv.Itoa(id))
+	d.Set("user_id", userId)
+
+	return []*schema.ResourceData{d}, nil
+}
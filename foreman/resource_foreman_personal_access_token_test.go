@@ -0,0 +1,343 @@
+package foreman
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const PersonalAccessTokensTestDataPath = "testdata/1.11/personal_access_tokens"
+
+// Returns the URI of the personal access tokens of the user
+func PersonalAccessTokensURI(userId int) string {
+	return api.FOREMAN_API_URL_PREFIX + fmt.Sprintf("/users/%d/personal_access_tokens", userId)
+}
+
+// Given a ForemanPersonalAccessToken, create a mock instance state reference
+func ForemanPersonalAccessTokenToInstanceState(obj api.ForemanPersonalAccessToken) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = strconv.Itoa(obj.Id)
+	// Build the attribute map from ForemanPersonalAccessToken
+	attr := map[string]string{}
+	attr["name"] = obj.Name
+	attr["user_id"] = strconv.Itoa(obj.UserId)
+	attr["expires_at"] = obj.ExpiresAt
+	attr["token"] = obj.TokenValue
+	attr["active"] = strconv.FormatBool(obj.Active)
+	attr["revoked"] = strconv.FormatBool(obj.Revoked)
+	attr["last_used_at"] = obj.LastUsedAt
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanPersonalAccessToken resource,
+// create a mock ResourceData reference.
+func MockForemanPersonalAccessTokenResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanPersonalAccessToken()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates a personal access token
+// ResourceData reference
+func MockForemanPersonalAccessTokenResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanPersonalAccessToken
+	ParseJSONFile(t, path, &obj)
+	s := ForemanPersonalAccessTokenToInstanceState(obj)
+	return MockForemanPersonalAccessTokenResourceData(s)
+}
+
+// Creates a random ForemanPersonalAccessToken struct
+func RandForemanPersonalAccessToken() api.ForemanPersonalAccessToken {
+	obj := api.ForemanPersonalAccessToken{}
+
+	fo := RandForemanObject()
+	obj.ForemanObject = fo
+
+	obj.UserId = rand.Intn(100) + 1
+	obj.Active = true
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanPersonalAccessToken
+// resource.  If the two references differ in their attributes, the test will
+// raise a fatal.
+func ForemanPersonalAccessTokenResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := resourceForemanPersonalAccessToken()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+}
+
+// -----------------------------------------------------------------------------
+// setResourceDataFromForemanPersonalAccessToken
+// -----------------------------------------------------------------------------
+
+// Ensures the configured expiry is kept if Foreman returns the same point in
+// time in its own layout, and the token value is not reset by reads
+func TestSetResourceDataFromForemanPersonalAccessToken_ExpiresAt(t *testing.T) {
+
+	testCases := []struct {
+		readExpiresAt     string
+		expectedExpiresAt string
+	}{
+		{readExpiresAt: "2027-01-01 00:00:00 UTC", expectedExpiresAt: "2027-01-01T01:00:00+01:00"},
+		{readExpiresAt: "2027-02-01 00:00:00 UTC", expectedExpiresAt: "2027-02-01 00:00:00 UTC"},
+	}
+
+	for _, testCase := range testCases {
+		obj := RandForemanPersonalAccessToken()
+		obj.ExpiresAt = "2027-01-01T01:00:00+01:00"
+		obj.TokenValue = "s3cr3t"
+		resourceData := MockForemanPersonalAccessTokenResourceData(ForemanPersonalAccessTokenToInstanceState(obj))
+
+		obj.ExpiresAt = testCase.readExpiresAt
+		obj.TokenValue = ""
+		setResourceDataFromForemanPersonalAccessToken(resourceData, &obj)
+
+		if resourceData.Get("expires_at").(string) != testCase.expectedExpiresAt ||
+			resourceData.Get("token").(string) != "s3cr3t" {
+			t.Errorf(
+				"setResourceDataFromForemanPersonalAccessToken with expiry [%s] "+
+					"set expires_at [%s] and token [%s]. Expected [%s] and [s3cr3t].",
+				testCase.readExpiresAt,
+				resourceData.Get("expires_at"),
+				resourceData.Get("token"),
+				testCase.expectedExpiresAt,
+			)
+		}
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// resourceForemanPersonalAccessTokenRead
+// -----------------------------------------------------------------------------
+
+// Ensures a revoked token is removed from the state
+func TestResourceForemanPersonalAccessTokenRead_Revoked(t *testing.T) {
+
+	obj := RandForemanPersonalAccessToken()
+	mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+	defer server.Close()
+
+	mux.HandleFunc(PersonalAccessTokensURI(obj.UserId)+"/"+strconv.Itoa(obj.Id), func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, PersonalAccessTokensTestDataPath+"/read_response_revoked.json")
+	})
+
+	resourceData := MockForemanPersonalAccessTokenResourceData(ForemanPersonalAccessTokenToInstanceState(obj))
+	diags := resourceForemanPersonalAccessTokenRead(context.TODO(), resourceData, client)
+
+	if diags.HasError() || resourceData.Id() != "" {
+		t.Fatalf(
+			"resourceForemanPersonalAccessTokenRead did not remove the revoked "+
+				"token. Got ID [%s] and diagnostics [%+v].",
+			resourceData.Id(),
+			diags,
+		)
+	}
+
+}
+
+// Ensures the imported ID is split into the user and the token ID
+func TestResourceForemanPersonalAccessTokenImport(t *testing.T) {
+
+	s := ForemanPersonalAccessTokenToInstanceState(api.ForemanPersonalAccessToken{})
+	s.ID = "4/7"
+	resourceData := MockForemanPersonalAccessTokenResourceData(s)
+
+	_, err := resourceForemanPersonalAccessTokenImport(context.TODO(), resourceData, nil)
+	if err != nil || resourceData.Id() != "7" || resourceData.Get("user_id").(int) != 4 {
+		t.Fatalf(
+			"resourceForemanPersonalAccessTokenImport did not split [4/7]. "+
+				"Got ID [%s], user_id [%d] and error [%v].",
+			resourceData.Id(),
+			resourceData.Get("user_id"),
+			err,
+		)
+	}
+
+	s.ID = "7"
+	_, err = resourceForemanPersonalAccessTokenImport(context.TODO(), MockForemanPersonalAccessTokenResourceData(s), nil)
+	if err == nil {
+		t.Fatalf("resourceForemanPersonalAccessTokenImport did not reject the ID [7]")
+	}
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanPersonalAccessTokenCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := RandForemanPersonalAccessToken()
+	s := ForemanPersonalAccessTokenToInstanceState(obj)
+	tokensURI := PersonalAccessTokensURI(obj.UserId)
+	tokensURIById := tokensURI + "/" + strconv.Itoa(obj.Id)
+
+	return []TestCaseCorrectURLAndMethod{
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanPersonalAccessTokenCreate",
+				crudFunc:     resourceForemanPersonalAccessTokenCreate,
+				resourceData: MockForemanPersonalAccessTokenResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    tokensURI,
+					expectedMethod: http.MethodPost,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanPersonalAccessTokenRead",
+				crudFunc:     resourceForemanPersonalAccessTokenRead,
+				resourceData: MockForemanPersonalAccessTokenResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    tokensURIById,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanPersonalAccessTokenDelete",
+				crudFunc:     resourceForemanPersonalAccessTokenDelete,
+				resourceData: MockForemanPersonalAccessTokenResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    tokensURIById,
+					expectedMethod: http.MethodDelete,
+				},
+			},
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func ResourceForemanPersonalAccessTokenRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanPersonalAccessToken()
+	s := ForemanPersonalAccessTokenToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanPersonalAccessTokenRead",
+			crudFunc:     resourceForemanPersonalAccessTokenRead,
+			resourceData: MockForemanPersonalAccessTokenResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanPersonalAccessTokenDelete",
+			crudFunc:     resourceForemanPersonalAccessTokenDelete,
+			resourceData: MockForemanPersonalAccessTokenResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func ResourceForemanPersonalAccessTokenStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanPersonalAccessToken()
+	s := ForemanPersonalAccessTokenToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanPersonalAccessTokenCreate",
+			crudFunc:     resourceForemanPersonalAccessTokenCreate,
+			resourceData: MockForemanPersonalAccessTokenResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanPersonalAccessTokenRead",
+			crudFunc:     resourceForemanPersonalAccessTokenRead,
+			resourceData: MockForemanPersonalAccessTokenResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanPersonalAccessTokenDelete",
+			crudFunc:     resourceForemanPersonalAccessTokenDelete,
+			resourceData: MockForemanPersonalAccessTokenResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func ResourceForemanPersonalAccessTokenEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanPersonalAccessToken()
+	s := ForemanPersonalAccessTokenToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanPersonalAccessTokenCreate",
+			crudFunc:     resourceForemanPersonalAccessTokenCreate,
+			resourceData: MockForemanPersonalAccessTokenResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanPersonalAccessTokenRead",
+			crudFunc:     resourceForemanPersonalAccessTokenRead,
+			resourceData: MockForemanPersonalAccessTokenResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func ResourceForemanPersonalAccessTokenMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanPersonalAccessToken()
+	s := ForemanPersonalAccessTokenToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper read response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanPersonalAccessTokenRead",
+				crudFunc:     resourceForemanPersonalAccessTokenRead,
+				resourceData: MockForemanPersonalAccessTokenResourceData(s),
+			},
+			responseFile: PersonalAccessTokensTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanPersonalAccessTokenResourceDataFromFile(
+				t,
+				PersonalAccessTokensTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanPersonalAccessTokenResourceDataCompare,
+		},
+	}
+
+}
@@ -123,6 +123,11 @@ func resourceForemanProvisioningTemplate() *schema.Resource {
 				Optional:    true,
 				Description: "A description of the provisioning template.",
 			},
+
+			// -- Taxonomies --
+
+			"location_ids":     locationIdsSchema("provisioning template"),
+			"organization_ids": organizationIdsSchema("provisioning template"),
 		},
 	}
 }
@@ -203,6 +208,8 @@ func buildForemanProvisioningTemplate(d *schema.ResourceData) *api.ForemanProvis
 
 	template.TemplateCombinationsAttributes = buildForemanTemplateCombinationsAttributes(d)
 
+	template.ForemanTaxonomies = buildForemanTaxonomies(d)
+
 	return &template
 }
 
@@ -295,6 +302,7 @@ func setResourceDataFromForemanProvisioningTemplate(d *schema.ResourceData, ft *
 
 	setResourceDataFromForemanTemplateCombinationsAttributes(d, ft.TemplateCombinationsAttributes)
 
+	setResourceDataFromForemanTaxonomies(d, ft.ForemanTaxonomies)
 }
 
 // setResourceDataFromForemanTemplateCombinationsAttributes sets a
@@ -343,7 +351,7 @@ func resourceForemanProvisioningTemplateCreate(ctx context.Context, d *schema.Re
 
 	createdTemplate, createErr := client.CreateProvisioningTemplate(ctx, t)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanProvisioningTemplate: [%+v]", createdTemplate)
@@ -461,7 +469,7 @@ func resourceForemanProvisioningTemplateDelete(ctx context.Context, d *schema.Re
 
 		updatedTemplate, updateErr := client.UpdateProvisioningTemplate(ctx, t)
 		if updateErr != nil {
-			return diag.FromErr(updateErr)
+			return api.DiagnosticsFromError(d, updateErr)
 		}
 
 		log.Debugf("Updated ForemanProvisioningTemplate: [%+v]", updatedTemplate)
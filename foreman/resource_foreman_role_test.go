@@ -0,0 +1,351 @@
+package foreman
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const RolesURI = api.FOREMAN_API_URL_PREFIX + "/roles"
+const RolesTestDataPath = "testdata/1.11/roles"
+
+// Given a ForemanRole, create a mock instance state reference
+func ForemanRoleToInstanceState(obj api.ForemanRole) *terraform.InstanceState {
+	state := terraform.InstanceState{}
+	state.ID = strconv.Itoa(obj.Id)
+	// Build the attribute map from ForemanRole
+	attr := map[string]string{}
+	attr["name"] = obj.Name
+	attr["description"] = obj.Description
+	attr["builtin"] = strconv.FormatBool(obj.Builtin)
+	attr["origin"] = obj.Origin
+	state.Attributes = attr
+	return &state
+}
+
+// Given a mock instance state for a ForemanRole resource, create a mock
+// ResourceData reference.
+func MockForemanRoleResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanRole()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates a role ResourceData
+// reference
+func MockForemanRoleResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanRole
+	ParseJSONFile(t, path, &obj)
+	s := ForemanRoleToInstanceState(obj)
+	return MockForemanRoleResourceData(s)
+}
+
+// Creates a random ForemanRole struct
+func RandForemanRole() api.ForemanRole {
+	obj := api.ForemanRole{}
+
+	fo := RandForemanObject()
+	obj.ForemanObject = fo
+
+	obj.Description = fo.Name + " role"
+
+	return obj
+}
+
+// Compares two ResourceData references for a ForemanRole resource.  If the
+// two references differ in their attributes, the test will raise a fatal.
+func ForemanRoleResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := resourceForemanRole()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+}
+
+// -----------------------------------------------------------------------------
+// UnmarshalJSON
+// -----------------------------------------------------------------------------
+
+// Ensures the JSON unmarshal correctly sets the base attributes from
+// ForemanObject
+func TestRoleUnmarshalJSON_ForemanObject(t *testing.T) {
+
+	randObj := RandForemanObject()
+	randObjBytes, _ := json.Marshal(randObj)
+
+	var obj api.ForemanRole
+	jsonDecErr := json.Unmarshal(randObjBytes, &obj)
+	if jsonDecErr != nil {
+		t.Errorf(
+			"ForemanRole UnmarshalJSON could not decode base ForemanObject. "+
+				"Expected [nil] got [error]. Error value: [%s]",
+			jsonDecErr,
+		)
+	}
+
+	if !reflect.DeepEqual(obj.ForemanObject, randObj) {
+		t.Errorf(
+			"ForemanRole UnmarshalJSON did not properly decode base "+
+				"ForemanObject properties. Expected [%+v], got [%+v]",
+			randObj,
+			obj.ForemanObject,
+		)
+	}
+
+}
+
+// Ensures the JSON unmarshal decodes the filters and taxonomies from the
+// nested objects of the API response
+func TestRoleUnmarshalJSON_Filters(t *testing.T) {
+
+	var obj api.ForemanRole
+	ParseJSONFile(t, RolesTestDataPath+"/read_response.json", &obj)
+
+	if obj.Builtin || obj.ClonedFromId != 3 ||
+		!reflect.DeepEqual(obj.FilterIds, []int{301, 302}) ||
+		!reflect.DeepEqual(obj.LocationIds, []int{2}) ||
+		!reflect.DeepEqual(obj.OrganizationIds, []int{1}) {
+		t.Errorf(
+			"ForemanRole UnmarshalJSON did not properly decode the filters and "+
+				"taxonomies. Got [%+v]",
+			obj,
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// setResourceDataFromForemanRole
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being set
+func TestSetResourceDataFromForemanRole_Value(t *testing.T) {
+
+	expectedObj := RandForemanRole()
+	expectedState := ForemanRoleToInstanceState(expectedObj)
+	expectedResourceData := MockForemanRoleResourceData(expectedState)
+
+	actualObj := api.ForemanRole{}
+	actualState := ForemanRoleToInstanceState(actualObj)
+	actualResourceData := MockForemanRoleResourceData(actualState)
+
+	setResourceDataFromForemanRole(actualResourceData, &expectedObj)
+
+	ForemanRoleResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// ----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// ----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanRoleCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := RandForemanRole()
+	obj.Id = rand.Intn(100)
+	s := ForemanRoleToInstanceState(obj)
+	rolesURIById := RolesURI + "/" + strconv.Itoa(obj.Id)
+
+	cloneObj := RandForemanRole()
+	cloneState := ForemanRoleToInstanceState(cloneObj)
+	cloneState.Attributes["clone_from_id"] = "3"
+
+	return []TestCaseCorrectURLAndMethod{
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRoleCreate",
+				crudFunc:     resourceForemanRoleCreate,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    RolesURI,
+					expectedMethod: http.MethodPost,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRoleCreate",
+				crudFunc:     resourceForemanRoleCreate,
+				resourceData: MockForemanRoleResourceData(cloneState),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    RolesURI + "/3/clone",
+					expectedMethod: http.MethodPost,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRoleRead",
+				crudFunc:     resourceForemanRoleRead,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    rolesURIById,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRoleUpdate",
+				crudFunc:     resourceForemanRoleUpdate,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    rolesURIById,
+					expectedMethod: http.MethodPut,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRoleDelete",
+				crudFunc:     resourceForemanRoleDelete,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    rolesURIById,
+					expectedMethod: http.MethodDelete,
+				},
+			},
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func ResourceForemanRoleRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanRole()
+	s := ForemanRoleToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanRoleRead",
+			crudFunc:     resourceForemanRoleRead,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanRoleDelete",
+			crudFunc:     resourceForemanRoleDelete,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func ResourceForemanRoleStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanRole()
+	s := ForemanRoleToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanRoleCreate",
+			crudFunc:     resourceForemanRoleCreate,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanRoleRead",
+			crudFunc:     resourceForemanRoleRead,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanRoleUpdate",
+			crudFunc:     resourceForemanRoleUpdate,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanRoleDelete",
+			crudFunc:     resourceForemanRoleDelete,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func ResourceForemanRoleEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanRole()
+	s := ForemanRoleToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanRoleCreate",
+			crudFunc:     resourceForemanRoleCreate,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanRoleRead",
+			crudFunc:     resourceForemanRoleRead,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanRoleUpdate",
+			crudFunc:     resourceForemanRoleUpdate,
+			resourceData: MockForemanRoleResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func ResourceForemanRoleMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanRole()
+	s := ForemanRoleToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper read response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanRoleRead",
+				crudFunc:     resourceForemanRoleRead,
+				resourceData: MockForemanRoleResourceData(s),
+			},
+			responseFile: RolesTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockForemanRoleResourceDataFromFile(
+				t,
+				RolesTestDataPath+"/read_response.json",
+			),
+			compareFunc: ForemanRoleResourceDataCompare,
+		},
+	}
+
+}
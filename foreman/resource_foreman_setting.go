@@ -0,0 +1,312 @@
+package foreman
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
+	"github.com/HanseMerkur/terraform-provider-utils/log"
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceForemanSetting() *schema.Resource {
+	return &schema.Resource{
+
+		CreateContext: resourceForemanSettingCreate,
+		ReadContext:   resourceForemanSettingRead,
+		UpdateContext: resourceForemanSettingUpdate,
+		DeleteContext: resourceForemanSettingDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+
+			autodoc.MetaAttribute: {
+				Type:     schema.TypeBool,
+				Computed: true,
+				Description: fmt.Sprintf(
+					"%s Manages the value of a global Foreman setting. Settings "+
+						"cannot be created or deleted - destroying the resource restores "+
+						"the setting's default value.",
+					autodoc.MetaSummary,
+				),
+			},
+
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: fmt.Sprintf(
+					"Name of the setting. Read-only settings, ie: settings defined in "+
+						"Foreman's configuration file, cannot be managed."+
+						"%s \"append_domain_name_for_hosts\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"value": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: resourceForemanSettingValueDiffSuppressFunc,
+				Description: fmt.Sprintf(
+					"Value of the setting as string. It is converted to the type of "+
+						"the setting: `\"true\"` or `\"false\"` for boolean settings, a "+
+						"number for integer settings and JSON for array and hash "+
+						"settings, ie: `jsonencode([\"a\", \"b\"])`. The value of "+
+						"encrypted settings is not read back from Foreman."+
+						"%s \"false\"",
+					autodoc.MetaExample,
+				),
+			},
+
+			"default": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Default value of the setting, restored on destroy",
+			},
+
+			"readonly": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Indicates whether the setting is read-only or not.",
+			},
+
+			"encrypted": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Indicates whether the value is stored encrypted.",
+			},
+
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the setting",
+			},
+
+			"category_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the category the setting is in.",
+			},
+
+			"settings_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Data type of this setting. One of `boolean`, `integer`, " +
+					"`array`, `hash` or `string`.",
+			},
+		},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Conversion Helpers
+// -----------------------------------------------------------------------------
+
+// settingValueFromString converts the string value of the resource to the
+// type of the setting expected by the API
+func settingValueFromString(settingsType string, value string) (interface{}, error) {
+	switch settingsType {
+	case "boolean":
+		return strconv.ParseBool(value)
+	case "integer":
+		return strconv.Atoi(value)
+	case "array":
+		var arr []interface{}
+		err := json.Unmarshal([]byte(value), &arr)
+		return arr, err
+	case "hash":
+		var hash map[string]interface{}
+		err := json.Unmarshal([]byte(value), &hash)
+		return hash, err
+	}
+	return value, nil
+}
+
+// settingValueToString converts the typed value of a setting to its string
+// representation.  Arrays and hashes are encoded as JSON.
+func settingValueToString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	valueJSON, _ := json.Marshal(value)
+	return string(valueJSON)
+}
+
+// resourceForemanSettingValueDiffSuppressFunc suppresses the difference
+// between array and hash values which only differ in their JSON formatting
+func resourceForemanSettingValueDiffSuppressFunc(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	switch d.Get("settings_type").(string) {
+	case "array", "hash":
+		var oldObj, newObj interface{}
+		if json.Unmarshal([]byte(oldValue), &oldObj) != nil ||
+			json.Unmarshal([]byte(newValue), &newObj) != nil {
+			return false
+		}
+		return reflect.DeepEqual(oldObj, newObj)
+	}
+	return oldValue == newValue
+}
+
+// buildForemanSetting constructs a ForemanSetting struct from a resource
+// data reference. The value is left as string, it is converted once the
+// type of the setting is known.
+func buildForemanSetting(d *schema.ResourceData) *api.ForemanSetting {
+	log.Tracef("resource_foreman_setting.go#buildForemanSetting")
+
+	setting := api.ForemanSetting{}
+
+	obj := buildForemanObject(d)
+	setting.ForemanObject = *obj
+
+	setting.Name = d.Get("name").(string)
+	// Settings are identified by their name
+	setting.Id = d.Id()
+	if setting.Id == "" {
+		setting.Id = setting.Name
+	}
+
+	setting.Value = d.Get("value").(string)
+
+	return &setting
+}
+
+// setResourceDataFromForemanSetting sets a ResourceData's attributes from
+// the attributes of the supplied ForemanSetting struct
+func setResourceDataFromForemanSetting(d *schema.ResourceData, fs *api.ForemanSetting) {
+	log.Tracef("resource_foreman_setting.go#setResourceDataFromForemanSetting")
+
+	d.SetId(fs.Id)
+	d.Set("name", fs.Name)
+	// Foreman masks the value of encrypted settings - keep the configured
+	// value instead
+	if !fs.Encrypted {
+		d.Set("value", settingValueToString(fs.Value))
+	}
+	d.Set("default", settingValueToString(fs.Default))
+	d.Set("readonly", fs.ReadOnly)
+	d.Set("encrypted", fs.Encrypted)
+	d.Set("description", fs.Description)
+	d.Set("category_name", fs.CategoryName)
+	d.Set("settings_type", fs.SettingsType)
+}
+
+// updateForemanSettingValue reads the setting to make sure it can be changed
+// and converts the configured value to the setting's type before updating it
+func updateForemanSettingValue(ctx context.Context, d *schema.ResourceData, client *api.Client) diag.Diagnostics {
+	s := buildForemanSetting(d)
+
+	log.Debugf("ForemanSetting: [%+v]", s)
+
+	readSetting, readErr := client.ReadSetting(ctx, s.Id)
+	if readErr != nil {
+		return api.DiagnosticsFromError(d, readErr)
+	}
+	if readSetting.ReadOnly {
+		return diag.Errorf(
+			"Setting [%s] is read-only. It is defined in Foreman's configuration "+
+				"file and cannot be changed through the API.",
+			readSetting.Name,
+		)
+	}
+
+	value, convErr := settingValueFromString(readSetting.SettingsType, s.Value.(string))
+	if convErr != nil {
+		return diag.Errorf(
+			"Value [%s] of setting [%s] is not a valid %s: %s",
+			s.Value,
+			readSetting.Name,
+			readSetting.SettingsType,
+			convErr,
+		)
+	}
+	s.Id = readSetting.Id
+	s.Value = value
+
+	updatedSetting, updateErr := client.UpdateSetting(ctx, s)
+	if updateErr != nil {
+		return api.DiagnosticsFromError(d, updateErr)
+	}
+
+	log.Debugf("Updated ForemanSetting: [%+v]", updatedSetting)
+
+	setResourceDataFromForemanSetting(d, updatedSetting)
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Resource CRUD Operations
+// -----------------------------------------------------------------------------
+
+func resourceForemanSettingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_setting.go#Create")
+
+	return updateForemanSettingValue(ctx, d, meta.(*api.Client))
+}
+
+func resourceForemanSettingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_setting.go#Read")
+
+	client := meta.(*api.Client)
+	s := buildForemanSetting(d)
+
+	log.Debugf("ForemanSetting: [%+v]", s)
+
+	readSetting, readErr := client.ReadSetting(ctx, s.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+
+	log.Debugf("Read ForemanSetting: [%+v]", readSetting)
+
+	setResourceDataFromForemanSetting(d, readSetting)
+
+	return nil
+}
+
+func resourceForemanSettingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_setting.go#Update")
+
+	return updateForemanSettingValue(ctx, d, meta.(*api.Client))
+}
+
+func resourceForemanSettingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Tracef("resource_foreman_setting.go#Delete")
+
+	client := meta.(*api.Client)
+	s := buildForemanSetting(d)
+
+	log.Debugf("ForemanSetting: [%+v]", s)
+
+	// Settings cannot be deleted - restore the default value instead
+	readSetting, readErr := client.ReadSetting(ctx, s.Id)
+	if readErr != nil {
+		return diag.FromErr(api.CheckDeleted(d, readErr))
+	}
+	if readSetting.ReadOnly {
+		return nil
+	}
+	readSetting.Value = readSetting.Default
+
+	_, updateErr := client.UpdateSetting(ctx, readSetting)
+
+	// NOTE(ALL): d.SetId("") is automatically called by terraform assuming delete
+	//   returns no errors
+	return diag.FromErr(api.CheckDeleted(d, updateErr))
+}
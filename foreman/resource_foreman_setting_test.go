@@ -0,0 +1,331 @@
+package foreman
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Test Helper Functions
+// -----------------------------------------------------------------------------
+
+const SettingsURI = api.FOREMAN_API_URL_PREFIX + "/settings"
+const SettingsTestDataPath = "testdata/1.11/settings"
+
+// Given a mock instance state for a ForemanSetting resource, create a
+// mock ResourceData reference.
+func MockResourceForemanSettingResourceData(s *terraform.InstanceState) *schema.ResourceData {
+	r := resourceForemanSetting()
+	return r.Data(s)
+}
+
+// Reads the JSON for the file at the path and creates a setting
+// ResourceData reference
+func MockResourceForemanSettingResourceDataFromFile(t *testing.T, path string) *schema.ResourceData {
+	var obj api.ForemanSetting
+	ParseJSONFile(t, path, &obj)
+	s := ForemanSettingToInstanceState(obj)
+	return MockResourceForemanSettingResourceData(s)
+}
+
+// Compares two ResourceData references for a ForemanSetting resource.
+// If the two references differ in their attributes, the test will raise
+// a fatal.
+func ResourceForemanSettingResourceDataCompare(t *testing.T, r1 *schema.ResourceData, r2 *schema.ResourceData) {
+
+	// compare IDs
+	if r1.Id() != r2.Id() {
+		t.Fatalf(
+			"ResourceData references differ in Id. [%s], [%s]",
+			r1.Id(),
+			r2.Id(),
+		)
+	}
+
+	// build the attribute map
+	m := map[string]schema.ValueType{}
+	r := resourceForemanSetting()
+	for key, value := range r.Schema {
+		m[key] = value.Type
+	}
+
+	// compare the rest of the attributes
+	CompareResourceDataAttributes(t, m, r1, r2)
+
+}
+
+// -----------------------------------------------------------------------------
+// Value conversion
+// -----------------------------------------------------------------------------
+
+// Ensures the string values of the resource are converted to the type of the
+// setting and back
+func TestSettingValue_Conversion(t *testing.T) {
+	testCases := []struct {
+		settingsType string
+		value        string
+		expected     interface{}
+	}{
+		{settingsType: "boolean", value: "true", expected: true},
+		{settingsType: "integer", value: "300", expected: 300},
+		{settingsType: "array", value: `["a","b"]`, expected: []interface{}{"a", "b"}},
+		{settingsType: "hash", value: `{"a":"b"}`, expected: map[string]interface{}{"a": "b"}},
+		{settingsType: "string", value: "https://foreman.example.com", expected: "https://foreman.example.com"},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := settingValueFromString(testCase.settingsType, testCase.value)
+		if err != nil || !reflect.DeepEqual(actual, testCase.expected) {
+			t.Fatalf(
+				"settingValueFromString did not convert the %s value [%s]. "+
+					"Expected [%#v], got [%#v] with error [%v].",
+				testCase.settingsType,
+				testCase.value,
+				testCase.expected,
+				actual,
+				err,
+			)
+		}
+
+		if str := settingValueToString(actual); str != testCase.value {
+			t.Fatalf(
+				"settingValueToString did not convert the %s value back. "+
+					"Expected [%s], got [%s].",
+				testCase.settingsType,
+				testCase.value,
+				str,
+			)
+		}
+	}
+
+	if _, err := settingValueFromString("integer", "five"); err == nil {
+		t.Fatalf("settingValueFromString did not reject an invalid integer value")
+	}
+}
+
+// -----------------------------------------------------------------------------
+// setResourceDataFromForemanSetting
+// -----------------------------------------------------------------------------
+
+// Ensures the ResourceData's attributes are correctly being set
+func TestSetResourceDataFromForemanSetting_Value(t *testing.T) {
+
+	expectedObj := RandForemanSetting()
+	expectedObj.Value = "https://foreman.example.com"
+	expectedState := ForemanSettingToInstanceState(expectedObj)
+	expectedResourceData := MockResourceForemanSettingResourceData(expectedState)
+
+	actualObj := api.ForemanSetting{}
+	actualState := ForemanSettingToInstanceState(actualObj)
+	actualResourceData := MockResourceForemanSettingResourceData(actualState)
+
+	setResourceDataFromForemanSetting(actualResourceData, &expectedObj)
+
+	ResourceForemanSettingResourceDataCompare(t, actualResourceData, expectedResourceData)
+
+}
+
+// Ensures the masked value of an encrypted setting does not replace the
+// configured value
+func TestSetResourceDataFromForemanSetting_Encrypted(t *testing.T) {
+
+	obj := RandForemanSetting()
+	obj.Value = "s3cr3t"
+	resourceData := MockResourceForemanSettingResourceData(ForemanSettingToInstanceState(obj))
+
+	obj.Value = "*****"
+	obj.Encrypted = true
+	setResourceDataFromForemanSetting(resourceData, &obj)
+
+	if resourceData.Get("value").(string) != "s3cr3t" {
+		t.Fatalf(
+			"setResourceDataFromForemanSetting replaced the value of an encrypted "+
+				"setting. Expected [s3cr3t], got [%s].",
+			resourceData.Get("value"),
+		)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Test Cases for the Unit Test Framework
+// -----------------------------------------------------------------------------
+
+// SEE: foreman_api_test.go#TestCRUDFunction_CorrectURLAndMethod()
+func ResourceForemanSettingCorrectURLAndMethodTestCases(t *testing.T) []TestCaseCorrectURLAndMethod {
+
+	obj := RandForemanSetting()
+	obj.Id = fmt.Sprintf("setting%d", rand.Intn(100))
+	obj.Name = obj.Id
+	obj.Value = "true"
+	s := ForemanSettingToInstanceState(obj)
+	settingsURIById := SettingsURI + "/" + obj.Id
+
+	return []TestCaseCorrectURLAndMethod{
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanSettingCreate",
+				crudFunc:     resourceForemanSettingCreate,
+				resourceData: MockResourceForemanSettingResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    settingsURIById,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanSettingRead",
+				crudFunc:     resourceForemanSettingRead,
+				resourceData: MockResourceForemanSettingResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    settingsURIById,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanSettingDelete",
+				crudFunc:     resourceForemanSettingDelete,
+				resourceData: MockResourceForemanSettingResourceData(s),
+			},
+			expectedURIs: []ExpectedUri{
+				{
+					expectedURI:    settingsURIById,
+					expectedMethod: http.MethodGet,
+				},
+			},
+		},
+	}
+
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_RequestDataEmpty()
+func ResourceForemanSettingRequestDataEmptyTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanSetting()
+	s := ForemanSettingToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanSettingRead",
+			crudFunc:     resourceForemanSettingRead,
+			resourceData: MockResourceForemanSettingResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_StatusCodeError()
+func ResourceForemanSettingStatusCodeTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanSetting()
+	obj.Value = "true"
+	s := ForemanSettingToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanSettingCreate",
+			crudFunc:     resourceForemanSettingCreate,
+			resourceData: MockResourceForemanSettingResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanSettingRead",
+			crudFunc:     resourceForemanSettingRead,
+			resourceData: MockResourceForemanSettingResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanSettingUpdate",
+			crudFunc:     resourceForemanSettingUpdate,
+			resourceData: MockResourceForemanSettingResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanSettingDelete",
+			crudFunc:     resourceForemanSettingDelete,
+			resourceData: MockResourceForemanSettingResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_EmptyResponseError()
+func ResourceForemanSettingEmptyResponseTestCases(t *testing.T) []TestCase {
+
+	obj := RandForemanSetting()
+	obj.Value = "true"
+	s := ForemanSettingToInstanceState(obj)
+
+	return []TestCase{
+		{
+			funcName:     "resourceForemanSettingCreate",
+			crudFunc:     resourceForemanSettingCreate,
+			resourceData: MockResourceForemanSettingResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanSettingRead",
+			crudFunc:     resourceForemanSettingRead,
+			resourceData: MockResourceForemanSettingResourceData(s),
+		},
+		{
+			funcName:     "resourceForemanSettingUpdate",
+			crudFunc:     resourceForemanSettingUpdate,
+			resourceData: MockResourceForemanSettingResourceData(s),
+		},
+	}
+}
+
+// SEE: foreman_api_test.go#TestCRUDFunction_MockResponse()
+func ResourceForemanSettingMockResponseTestCases(t *testing.T) []TestCaseMockResponse {
+
+	obj := RandForemanSetting()
+	obj.Value = "false"
+	s := ForemanSettingToInstanceState(obj)
+
+	return []TestCaseMockResponse{
+		// If the server responds with a proper read response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanSettingRead",
+				crudFunc:     resourceForemanSettingRead,
+				resourceData: MockResourceForemanSettingResourceData(s),
+			},
+			responseFile: SettingsTestDataPath + "/read_response.json",
+			returnError:  false,
+			expectedResourceData: MockResourceForemanSettingResourceDataFromFile(
+				t,
+				SettingsTestDataPath+"/read_response.json",
+			),
+			compareFunc: ResourceForemanSettingResourceDataCompare,
+		},
+		// If the server responds with a proper update response, the operation
+		// should succeed and the ResourceData's attributes should be updated
+		// to server's response
+		{
+			TestCase: TestCase{
+				funcName:     "resourceForemanSettingUpdate",
+				crudFunc:     resourceForemanSettingUpdate,
+				resourceData: MockResourceForemanSettingResourceData(s),
+			},
+			responseFile: SettingsTestDataPath + "/update_response.json",
+			returnError:  false,
+			expectedResourceData: MockResourceForemanSettingResourceDataFromFile(
+				t,
+				SettingsTestDataPath+"/update_response.json",
+			),
+			compareFunc: ResourceForemanSettingResourceDataCompare,
+		},
+	}
+
+}
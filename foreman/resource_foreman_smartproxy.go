@@ -60,6 +60,32 @@ func resourceForemanSmartProxy() *schema.Resource {
 					autodoc.MetaExample,
 				),
 			},
+
+			"features": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Names of the features the proxy provides, ie: `\"DHCP\"`. " +
+					"Foreman detects them when the proxy is created or refreshed.",
+			},
+
+			"refresh_on_change": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Arbitrary map of values that, when changed, refreshes the " +
+					"features of the proxy, ie: `{ version = var.proxy_version }` to " +
+					"detect the features of an upgraded proxy.",
+			},
+
+			// -- Taxonomies --
+
+			"location_ids":     locationIdsSchema("smart proxy"),
+			"organization_ids": organizationIdsSchema("smart proxy"),
 		},
 	}
 }
@@ -82,6 +108,8 @@ func buildForemanSmartProxy(d *schema.ResourceData) *api.ForemanSmartProxy {
 
 	proxy.URL = d.Get("url").(string)
 
+	proxy.ForemanTaxonomies = buildForemanTaxonomies(d)
+
 	return &proxy
 }
 
@@ -93,6 +121,14 @@ func setResourceDataFromForemanSmartProxy(d *schema.ResourceData, fp *api.Forema
 	d.SetId(strconv.Itoa(fp.Id))
 	d.Set("name", fp.Name)
 	d.Set("url", fp.URL)
+
+	features := make([]string, 0, len(fp.Features))
+	for _, feature := range fp.Features {
+		features = append(features, feature.Name)
+	}
+	d.Set("features", features)
+
+	setResourceDataFromForemanTaxonomies(d, fp.ForemanTaxonomies)
 }
 
 // -----------------------------------------------------------------------------
@@ -109,7 +145,7 @@ func resourceForemanSmartProxyCreate(ctx context.Context, d *schema.ResourceData
 
 	createdSmartProxy, createErr := client.CreateSmartProxy(ctx, s)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanSmartProxy: [%+v]", createdSmartProxy)
@@ -149,11 +185,22 @@ func resourceForemanSmartProxyUpdate(ctx context.Context, d *schema.ResourceData
 
 	updatedSmartProxy, updateErr := client.UpdateSmartProxy(ctx, s)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	log.Debugf("ForemanSmartProxy: [%+v]", updatedSmartProxy)
 
+	if d.HasChange("refresh_on_change") {
+		refreshedSmartProxy, refreshErr := client.RefreshSmartProxy(ctx, updatedSmartProxy.Id)
+		if refreshErr != nil {
+			return api.DiagnosticsFromError(d, refreshErr)
+		}
+
+		log.Debugf("Refreshed ForemanSmartProxy: [%+v]", refreshedSmartProxy)
+
+		updatedSmartProxy = refreshedSmartProxy
+	}
+
 	setResourceDataFromForemanSmartProxy(d, updatedSmartProxy)
 
 	return nil
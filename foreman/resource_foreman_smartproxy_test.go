@@ -1,7 +1,9 @@
 package foreman
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"math/rand"
 	"net/http"
 	"reflect"
@@ -30,6 +32,10 @@ func ForemanSmartProxyToInstanceState(obj api.ForemanSmartProxy) *terraform.Inst
 	attr := map[string]string{}
 	attr["name"] = obj.Name
 	attr["url"] = obj.URL
+	attr["features.#"] = strconv.Itoa(len(obj.Features))
+	for idx, feature := range obj.Features {
+		attr[fmt.Sprintf("features.%d", idx)] = feature.Name
+	}
 	state.Attributes = attr
 	return &state
 }
@@ -120,6 +126,75 @@ func TestSmartProxyUnmarshalJSON_ForemanObject(t *testing.T) {
 
 }
 
+// Ensures the JSON unmarshal decodes the features of the smart proxy
+func TestSmartProxyUnmarshalJSON_Features(t *testing.T) {
+
+	var obj api.ForemanSmartProxy
+	ParseJSONFile(t, SmartProxiesTestDataPath+"/read_response.json", &obj)
+
+	if !obj.HasFeature("DHCP") || obj.HasFeature("TFTP") {
+		t.Errorf(
+			"ForemanSmartProxy UnmarshalJSON did not properly decode the "+
+				"features. Got [%+v]",
+			obj.Features,
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// resourceForemanSmartProxyUpdate
+// -----------------------------------------------------------------------------
+
+// Ensures the features are only refreshed if refresh_on_change changes
+func TestResourceForemanSmartProxyUpdate_RefreshOnChange(t *testing.T) {
+
+	for _, refreshOnChange := range []map[string]interface{}{nil, {"version": "3.9.1"}} {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+
+		refreshes := 0
+		mux.HandleFunc(SmartProxiesURI+"/38", func(w http.ResponseWriter, r *http.Request) {
+			http.ServeFile(w, r, SmartProxiesTestDataPath+"/update_response.json")
+		})
+		mux.HandleFunc(SmartProxiesURI+"/38/refresh", func(w http.ResponseWriter, r *http.Request) {
+			refreshes++
+			if r.Method != http.MethodPut {
+				t.Errorf("Refresh used [%s]. Expected [PUT]", r.Method)
+			}
+			http.ServeFile(w, r, SmartProxiesTestDataPath+"/read_response.json")
+		})
+
+		raw := map[string]interface{}{
+			"name": "smartproxy01.dev.dc1.company.com",
+			"url":  "https://smartproxy01.dev.dc1.company.com:8443",
+		}
+		if refreshOnChange != nil {
+			raw["refresh_on_change"] = refreshOnChange
+		}
+		resourceData := schema.TestResourceDataRaw(t, resourceForemanSmartProxy().Schema, raw)
+		resourceData.SetId("38")
+
+		diags := resourceForemanSmartProxyUpdate(context.TODO(), resourceData, client)
+		server.Close()
+
+		expectedRefreshes := 0
+		if refreshOnChange != nil {
+			expectedRefreshes = 1
+		}
+		if diags.HasError() || refreshes != expectedRefreshes {
+			t.Errorf(
+				"resourceForemanSmartProxyUpdate with refresh_on_change [%v] "+
+					"refreshed [%d] times with diagnostics [%+v]. Expected [%d].",
+				refreshOnChange,
+				refreshes,
+				diags,
+				expectedRefreshes,
+			)
+		}
+	}
+
+}
+
 // -----------------------------------------------------------------------------
 // buildForemanSmartProxy
 // -----------------------------------------------------------------------------
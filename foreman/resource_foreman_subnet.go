@@ -11,6 +11,7 @@ import (
 	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -22,6 +23,11 @@ func resourceForemanSubnet() *schema.Resource {
 		UpdateContext: resourceForemanSubnetUpdate,
 		DeleteContext: resourceForemanSubnetDelete,
 
+		CustomizeDiff: customdiff.All(
+			resourceForemanSubnetCustomizeDiffIpam,
+			resourceParametersCustomizeDiffValues,
+		),
+
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -94,14 +100,18 @@ func resourceForemanSubnet() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 				ValidateFunc: validation.StringInSlice([]string{
-					"DHCP",
+					api.SubnetIpamDHCP,
 					"Internal DB",
 					"Random DB",
+					api.SubnetIpamExternalIPAM,
 					"None",
 					// NOTE(ALL): false - do not ignore case when comparing values
 				}, false),
 				Description: "IP address auto-suggestion for this subnet. Valid " +
-					"values include: `\"DHCP\"`, `\"Internal DB\"`, `\"Random DB\"`,`\"None\"`.",
+					"values include: `\"DHCP\"`, `\"Internal DB\"`, `\"Random DB\"`, " +
+					"`\"External IPAM\"`, `\"None\"`. `\"DHCP\"` requires `dhcp_id` and " +
+					"`\"External IPAM\"` requires `externalipam_id`, the proxy must " +
+					"provide the feature of the same name.",
 			},
 
 			"from": {
@@ -169,6 +179,35 @@ func resourceForemanSubnet() *schema.Resource {
 				Optional:    true,
 				Description: "HTTPBoot Proxy ID to use within this subnet",
 			},
+			"dns_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "DNS Proxy ID to use within this subnet",
+			},
+			"externalipam_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "External IPAM Proxy ID to use within this subnet",
+			},
+			"discovery_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Discovery Proxy ID to use within this subnet",
+			},
+			"remote_execution_proxy_ids": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				Optional: true,
+				Computed: true,
+				Description: "IDs of the Remote Execution Proxies executing jobs on " +
+					"the hosts of this subnet. Defaults to the current proxies.",
+			},
 			"domain_ids": {
 				Type: schema.TypeSet,
 				Elem: &schema.Schema{
@@ -191,6 +230,23 @@ func resourceForemanSubnet() *schema.Resource {
 				Optional:    true,
 				Description: "Description of the subnet",
 			},
+			"parameters": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				DiffSuppressFunc: parametersDiffSuppressFunc,
+				Description: "A map of parameters that will be saved as subnet " +
+					"parameters in the subnet config.",
+			},
+
+			"parameter_types": parameterTypesSchema("subnet"),
+
+			// -- Taxonomies --
+
+			"location_ids":     locationIdsSchema("subnet"),
+			"organization_ids": organizationIdsSchema("subnet"),
 		},
 	}
 }
@@ -267,6 +323,19 @@ func buildForemanSubnet(d *schema.ResourceData) *api.ForemanSubnet {
 		httpBootID := attr.(int)
 		s.HTTPBootID = &httpBootID
 	}
+	if attr, ok = d.GetOk("dns_id"); ok {
+		dnsID := attr.(int)
+		s.DnsID = &dnsID
+	}
+	if attr, ok = d.GetOk("externalipam_id"); ok {
+		externalIpamID := attr.(int)
+		s.ExternalIpamID = &externalIpamID
+	}
+	if attr, ok = d.GetOk("discovery_id"); ok {
+		discoveryID := attr.(int)
+		s.DiscoveryID = &discoveryID
+	}
+	s.RemoteExecutionProxyIds = buildForemanIds(d, "remote_execution_proxy_ids")
 	if attr, ok = d.GetOk("domain_ids"); ok {
 		attrSet := attr.(*schema.Set)
 		s.DomainIDs = conv.InterfaceSliceToIntSlice(attrSet.List())
@@ -277,6 +346,15 @@ func buildForemanSubnet(d *schema.ResourceData) *api.ForemanSubnet {
 	if attr, ok = d.GetOk("description"); ok {
 		s.Description = attr.(string)
 	}
+	if attr, ok = d.GetOk("parameters"); ok {
+		s.SubnetParameters = api.ToKV(
+			attr.(map[string]interface{}),
+			d.Get("parameter_types").(map[string]interface{}),
+		)
+	}
+
+	s.ForemanTaxonomies = buildForemanTaxonomies(d)
+
 	return &s
 }
 
@@ -304,9 +382,71 @@ func setResourceDataFromForemanSubnet(d *schema.ResourceData, fs *api.ForemanSub
 	d.Set("bmc_id", fs.BmcID)
 	d.Set("tftp_id", fs.TftpID)
 	d.Set("httpboot_id", fs.HTTPBootID)
+	d.Set("dns_id", fs.DnsID)
+	d.Set("externalipam_id", fs.ExternalIpamID)
+	d.Set("discovery_id", fs.DiscoveryID)
+	// Remote execution proxies missing in the API response are left untouched
+	if fs.RemoteExecutionProxyIds != nil {
+		d.Set("remote_execution_proxy_ids", fs.RemoteExecutionProxyIds)
+	}
 	d.Set("domain_ids", fs.DomainIDs)
 	d.Set("network_type", fs.NetworkType)
 	d.Set("description", fs.Description)
+	d.Set("parameters", api.FromKV(fs.SubnetParameters))
+	d.Set("parameter_types", api.FromKVTypes(fs.SubnetParameters))
+	setResourceDataFromForemanTaxonomies(d, fs.ForemanTaxonomies)
+}
+
+// subnetIpamProxyAttributes maps the IP address management modes requiring a
+// smart proxy to the attribute holding the proxy's ID
+var subnetIpamProxyAttributes = map[string]string{
+	api.SubnetIpamDHCP:         "dhcp_id",
+	api.SubnetIpamExternalIPAM: "externalipam_id",
+}
+
+// resourceForemanSubnetCustomizeDiffIpam rejects IP address management modes
+// without the smart proxy they require when planning
+func resourceForemanSubnetCustomizeDiffIpam(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	ipam := d.Get("ipam").(string)
+	key, ok := subnetIpamProxyAttributes[ipam]
+	if !ok || !d.NewValueKnown(key) {
+		return nil
+	}
+	if d.Get(key).(int) == 0 {
+		return fmt.Errorf("ipam [%s] requires the %s of a smart proxy with the %s feature", ipam, key, ipam)
+	}
+	return nil
+}
+
+// checkForemanSubnetIpamProxy reads the smart proxy the IP address management
+// mode of the subnet requires and makes sure it provides the feature
+func checkForemanSubnetIpamProxy(ctx context.Context, client *api.Client, s *api.ForemanSubnet) diag.Diagnostics {
+	var proxyID *int
+	switch s.Ipam {
+	case api.SubnetIpamDHCP:
+		proxyID = s.DhcpID
+	case api.SubnetIpamExternalIPAM:
+		proxyID = s.ExternalIpamID
+	default:
+		return nil
+	}
+	if proxyID == nil {
+		return diag.Errorf("ipam [%s] requires a smart proxy with the %s feature", s.Ipam, s.Ipam)
+	}
+
+	proxy, readErr := client.ReadSmartProxy(ctx, *proxyID)
+	if readErr != nil {
+		return diag.FromErr(readErr)
+	}
+	if !proxy.HasFeature(s.Ipam) {
+		return diag.Errorf(
+			"Smart proxy [%s] does not provide the %s feature required by ipam [%s]",
+			proxy.Name,
+			s.Ipam,
+			s.Ipam,
+		)
+	}
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -321,9 +461,13 @@ func resourceForemanSubnetCreate(ctx context.Context, d *schema.ResourceData, me
 
 	log.Debugf("ForemanSubnet: [%+v]", s)
 
+	if diags := checkForemanSubnetIpamProxy(ctx, client, s); diags.HasError() {
+		return diags
+	}
+
 	createdSubnet, createErr := client.CreateSubnet(ctx, s)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanSubnet: [%+v]", createdSubnet)
@@ -360,9 +504,15 @@ func resourceForemanSubnetUpdate(ctx context.Context, d *schema.ResourceData, me
 
 	log.Debugf("ForemanSubnet: [%+v]", s)
 
+	if d.HasChanges("ipam", "dhcp_id", "externalipam_id") {
+		if diags := checkForemanSubnetIpamProxy(ctx, client, s); diags.HasError() {
+			return diags
+		}
+	}
+
 	updatedSubnet, updateErr := client.UpdateSubnet(ctx, s)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	log.Debugf("Updated ForemanSubnet: [%+v]", updatedSubnet)
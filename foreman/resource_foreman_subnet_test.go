@@ -1,7 +1,9 @@
 package foreman
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"math/rand"
 	"net/http"
 	"reflect"
@@ -41,6 +43,29 @@ func ForemanSubnetToInstanceState(obj api.ForemanSubnet) *terraform.InstanceStat
 	attr["to"] = obj.To
 	attr["boot_mode"] = obj.BootMode
 	attr["description"] = obj.Description
+	proxyIds := map[string]*int{
+		"template_id":     obj.TemplateID,
+		"dhcp_id":         obj.DhcpID,
+		"bmc_id":          obj.BmcID,
+		"tftp_id":         obj.TftpID,
+		"httpboot_id":     obj.HTTPBootID,
+		"dns_id":          obj.DnsID,
+		"externalipam_id": obj.ExternalIpamID,
+		"discovery_id":    obj.DiscoveryID,
+	}
+	for key, id := range proxyIds {
+		if id != nil {
+			attr[key] = strconv.Itoa(*id)
+		}
+	}
+	attr["remote_execution_proxy_ids.#"] = strconv.Itoa(len(obj.RemoteExecutionProxyIds))
+	for idx, val := range obj.RemoteExecutionProxyIds {
+		attr[fmt.Sprintf("remote_execution_proxy_ids.%d", idx)] = strconv.Itoa(val)
+	}
+	attr["parameters.%"] = strconv.Itoa(len(obj.SubnetParameters))
+	for _, parameter := range obj.SubnetParameters {
+		attr["parameters."+parameter.Name] = parameter.Value
+	}
 	state.Attributes = attr
 	return &state
 }
@@ -78,6 +103,9 @@ func RandForemanSubnet() api.ForemanSubnet {
 	obj.To = tfrand.IPv4Str(tfrand.IPv4PrivateClassCStart, tfrand.IPv4PrivateClassCMask)
 	obj.BootMode = tfrand.String(5, tfrand.Lower)
 	obj.Description = tfrand.String(10, tfrand.Lower+". ")
+	dnsID := rand.Intn(100) + 1
+	obj.DnsID = &dnsID
+	obj.RemoteExecutionProxyIds = []int{rand.Intn(100) + 1}
 
 	return obj
 }
@@ -140,6 +168,101 @@ func TestSubnetUnmarshalJSON_ForemanObject(t *testing.T) {
 
 }
 
+// Ensures the JSON unmarshal reconciles the nested remote execution proxies
+// and parameters of the API response
+func TestSubnetUnmarshalJSON_NestedObjects(t *testing.T) {
+
+	var obj api.ForemanSubnet
+	ParseJSONFile(t, SubnetsTestDataPath+"/read_response.json", &obj)
+
+	if obj.DnsID == nil || *obj.DnsID != 39 ||
+		obj.ExternalIpamID != nil ||
+		!reflect.DeepEqual(obj.RemoteExecutionProxyIds, []int{38}) ||
+		!reflect.DeepEqual(api.FromKV(obj.SubnetParameters), map[string]string{"ntp_server": "ntp.dev.dc1.company.com"}) {
+		t.Errorf(
+			"ForemanSubnet UnmarshalJSON did not properly decode the nested "+
+				"objects. Got [%+v]",
+			obj,
+		)
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// MarshalJSON
+// -----------------------------------------------------------------------------
+
+// Ensures the remote execution proxies are only sent if they are managed
+func TestSubnetMarshalJSON_RemoteExecutionProxyIds(t *testing.T) {
+
+	for _, ids := range [][]int{nil, {}, {5}} {
+		obj := RandForemanSubnet()
+		obj.RemoteExecutionProxyIds = ids
+
+		objBytes, _ := json.Marshal(obj)
+		var objMap map[string]interface{}
+		json.Unmarshal(objBytes, &objMap)
+
+		if _, ok := objMap["remote_execution_proxy_ids"]; ok != (ids != nil) {
+			t.Errorf(
+				"ForemanSubnet MarshalJSON did not handle the remote execution "+
+					"proxies [%v]. Got [%s]",
+				ids,
+				objBytes,
+			)
+		}
+	}
+
+}
+
+// -----------------------------------------------------------------------------
+// checkForemanSubnetIpamProxy
+// -----------------------------------------------------------------------------
+
+// Ensures IP address management modes are rejected if the smart proxy does
+// not provide the feature they require
+func TestCheckForemanSubnetIpamProxy(t *testing.T) {
+
+	proxyID := 38
+
+	testCases := []struct {
+		ipam        string
+		dhcpID      *int
+		externalID  *int
+		expectError bool
+	}{
+		{ipam: "Internal DB", expectError: false},
+		{ipam: api.SubnetIpamDHCP, dhcpID: &proxyID, expectError: false},
+		{ipam: api.SubnetIpamDHCP, expectError: true},
+		{ipam: api.SubnetIpamExternalIPAM, externalID: &proxyID, expectError: true},
+	}
+
+	for _, testCase := range testCases {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+		mux.HandleFunc(SmartProxiesURI+"/"+strconv.Itoa(proxyID), func(w http.ResponseWriter, r *http.Request) {
+			http.ServeFile(w, r, SmartProxiesTestDataPath+"/read_response.json")
+		})
+
+		obj := RandForemanSubnet()
+		obj.Ipam = testCase.ipam
+		obj.DhcpID = testCase.dhcpID
+		obj.ExternalIpamID = testCase.externalID
+		diags := checkForemanSubnetIpamProxy(context.TODO(), client, &obj)
+		server.Close()
+
+		if diags.HasError() != testCase.expectError {
+			t.Errorf(
+				"checkForemanSubnetIpamProxy with ipam [%s] returned diagnostics "+
+					"[%+v]. Expected an error: [%t]",
+				testCase.ipam,
+				diags,
+				testCase.expectError,
+			)
+		}
+	}
+
+}
+
 // -----------------------------------------------------------------------------
 // setResourceDataFromForemanSubnet
 // -----------------------------------------------------------------------------
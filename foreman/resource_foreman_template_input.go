@@ -235,7 +235,7 @@ func resourceForemanTemplateInputCreate(ctx context.Context, resdata *schema.Res
 
 	created, err := client.CreateTemplateInput(ctx, built)
 	if err != nil {
-		return diag.FromErr(err)
+		return api.DiagnosticsFromError(resdata, err)
 	}
 
 	setResourceDataFromForemanTemplateInput(resdata, created)
@@ -271,7 +271,7 @@ func resourceForemanTemplateInputUpdate(ctx context.Context, resdata *schema.Res
 
 	updated, err := c.UpdateTemplateInput(ctx, built)
 	if err != nil {
-		return diag.FromErr(err)
+		return api.DiagnosticsFromError(resdata, err)
 	}
 
 	setResourceDataFromForemanTemplateInput(resdata, updated)
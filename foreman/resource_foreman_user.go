@@ -6,7 +6,6 @@ import (
 	"strconv"
 
 	"github.com/HanseMerkur/terraform-provider-utils/autodoc"
-	"github.com/HanseMerkur/terraform-provider-utils/conv"
 	"github.com/HanseMerkur/terraform-provider-utils/log"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -67,6 +66,14 @@ func resourceForemanUser() *schema.Resource {
 				Description: "Email of user",
 			},
 
+			"mail_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+				Description: "Whether the user receives email notifications. Foreman enables " +
+					"them for new users if not set.",
+			},
+
 			"description": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -96,8 +103,9 @@ func resourceForemanUser() *schema.Resource {
 				Type:         schema.TypeInt,
 				Optional:     true,
 				Default:      1,
-				ValidateFunc: validation.IntBetween(1, 2),
-				Description:  "Set the authentication source, i.e internal (1,default) or external (2)",
+				ValidateFunc: validation.IntAtLeast(1),
+				Description: "Set the authentication source, i.e internal (1,default), external (2) " +
+					"or the ID of a `foreman_auth_source_ldap`",
 			},
 
 			"locale": {
@@ -112,7 +120,8 @@ func resourceForemanUser() *schema.Resource {
 					Type: schema.TypeInt,
 				},
 				Optional:    true,
-				Description: "List of all locations a user has access to",
+				Computed:    true,
+				Description: "List of all locations a user has access to. Defaults to the current locations.",
 			},
 
 			"organization_ids": {
@@ -121,7 +130,21 @@ func resourceForemanUser() *schema.Resource {
 					Type: schema.TypeInt,
 				},
 				Optional:    true,
-				Description: "List of all organizations a user has access to",
+				Computed:    true,
+				Description: "List of all organizations a user has access to. Defaults to the current organizations.",
+			},
+
+			"role_ids": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Optional: true,
+				Computed: true,
+				Description: "List of the roles granted to the user. Foreman grants the " +
+					"builtin `Default role` to every user - it is not part of the list. " +
+					"Roles granted through usergroups are not part of the list either. " +
+					"Defaults to the current roles.",
 			},
 		},
 	}
@@ -161,6 +184,10 @@ func buildForemanUser(d *schema.ResourceData) *api.ForemanUser {
 	if attr, ok = d.GetOk("mail"); ok {
 		u.Mail = attr.(string)
 	}
+	if isConfigured(d, "mail_enabled") {
+		mailEnabled := d.Get("mail_enabled").(bool)
+		u.MailEnabled = &mailEnabled
+	}
 	if attr, ok = d.GetOk("description"); ok {
 		u.Description = attr.(string)
 	}
@@ -179,14 +206,9 @@ func buildForemanUser(d *schema.ResourceData) *api.ForemanUser {
 	if attr, ok = d.GetOk("locale"); ok {
 		u.Locale = attr.(string)
 	}
-	if attr, ok = d.GetOk("location_ids"); ok {
-		attrSet := attr.(*schema.Set)
-		u.LocationIds = conv.InterfaceSliceToIntSlice(attrSet.List())
-	}
-	if attr, ok = d.GetOk("organization_ids"); ok {
-		attrSet := attr.(*schema.Set)
-		u.OrganizationIds = conv.InterfaceSliceToIntSlice(attrSet.List())
-	}
+	u.LocationIds = buildForemanIds(d, "location_ids")
+	u.OrganizationIds = buildForemanIds(d, "organization_ids")
+	u.RoleIds = buildForemanIds(d, "role_ids")
 	return &u
 }
 
@@ -201,14 +223,25 @@ func setResourceDataFromForemanUser(d *schema.ResourceData, fu *api.ForemanUser)
 	d.Set("firstname", fu.Firstname)
 	d.Set("lastname", fu.Lastname)
 	d.Set("mail", fu.Mail)
+	if fu.MailEnabled != nil {
+		d.Set("mail_enabled", *fu.MailEnabled)
+	}
 	d.Set("description", fu.Description)
 	d.Set("password", fu.Password)
 	d.Set("default_location_id", fu.DefaultLocationId)
 	d.Set("default_organization_id", fu.DefaultOrganizationId)
 	d.Set("auth_source_id", fu.AuthSourceId)
 	d.Set("locale", fu.Locale)
-	d.Set("location_ids", fu.LocationIds)
-	d.Set("organization_ids", fu.OrganizationIds)
+	// Assignments missing in the API response are left untouched
+	if fu.LocationIds != nil {
+		d.Set("location_ids", fu.LocationIds)
+	}
+	if fu.OrganizationIds != nil {
+		d.Set("organization_ids", fu.OrganizationIds)
+	}
+	if fu.RoleIds != nil {
+		d.Set("role_ids", fu.RoleIds)
+	}
 }
 
 // -----------------------------------------------------------------------------
@@ -225,7 +258,7 @@ func resourceForemanUserCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	createdUser, createErr := client.CreateUser(ctx, u)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanUser: [%+v]", createdUser)
@@ -265,7 +298,7 @@ func resourceForemanUserUpdate(ctx context.Context, d *schema.ResourceData, meta
 
 	updatedUser, updateErr := client.UpdateUser(ctx, u)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	log.Debugf("Updated ForemanUser: [%+v]", updatedUser)
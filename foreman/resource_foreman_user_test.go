@@ -0,0 +1,67 @@
+package foreman
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// buildForemanUser
+// -----------------------------------------------------------------------------
+
+// Ensures the email notifications are only sent if they are configured - the
+// value read from Foreman into the state is not sent back
+func TestBuildForemanUser_MailEnabled(t *testing.T) {
+	r := resourceForemanUser()
+	s := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"login":        "jdoe",
+			"mail_enabled": "true",
+		},
+	}
+
+	testCases := []struct {
+		mailEnabled cty.Value
+		expected    *bool
+	}{
+		{mailEnabled: cty.NullVal(cty.Bool), expected: nil},
+		{mailEnabled: cty.False, expected: new(bool)},
+	}
+
+	for _, testCase := range testCases {
+		configType := r.CoreConfigSchema().ImpliedType()
+		configAttrs := map[string]cty.Value{}
+		for key, attrType := range configType.AttributeTypes() {
+			configAttrs[key] = cty.NullVal(attrType)
+		}
+		configAttrs["login"] = cty.StringVal("jdoe")
+		configAttrs["mail_enabled"] = testCase.mailEnabled
+		diff := &terraform.InstanceDiff{
+			Attributes: map[string]*terraform.ResourceAttrDiff{},
+			RawConfig:  cty.ObjectVal(configAttrs),
+		}
+		if testCase.expected != nil {
+			diff.Attributes["mail_enabled"] = &terraform.ResourceAttrDiff{Old: "true", New: "false"}
+		}
+		resourceData, err := schema.InternalMap(r.Schema).Data(s, diff)
+		if err != nil {
+			t.Fatalf("Could not build the resource data: [%s]", err)
+		}
+
+		u := buildForemanUser(resourceData)
+		if (u.MailEnabled == nil) != (testCase.expected == nil) ||
+			(u.MailEnabled != nil && *u.MailEnabled != *testCase.expected) {
+			t.Fatalf(
+				"buildForemanUser did not build mail_enabled for the configured "+
+					"value [%#v]. Expected [%v], got [%v].",
+				testCase.mailEnabled,
+				testCase.expected,
+				u.MailEnabled,
+			)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceForemanUsergroup() *schema.Resource {
@@ -55,6 +56,87 @@ func resourceForemanUsergroup() *schema.Resource {
 					autodoc.MetaExample,
 				),
 			},
+
+			// -- Members and Roles --
+
+			"user_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the users which are members of the usergroup. " +
+					"Members of the `external_usergroup`s are added by Foreman as well - " +
+					"leave this unset when using external usergroups. Defaults to the " +
+					"current members.",
+			},
+			"usergroup_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the nested usergroups whose members are members " +
+					"of the usergroup as well. Defaults to the current nested usergroups.",
+			},
+			"role_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "IDs of the roles granted to the members of the usergroup. " +
+					"Defaults to the current roles.",
+			},
+
+			// -- External Usergroups --
+
+			"external_usergroup": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the external usergroup",
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+							Description: fmt.Sprintf(
+								"Name of the group in the authentication source."+
+									"%s \"foreman-admins\"",
+								autodoc.MetaExample,
+							),
+						},
+						"auth_source_id": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "ID of the authentication source, ie: an LDAP server, the group is defined in.",
+						},
+					},
+				},
+				Description: "Groups of external authentication sources whose members " +
+					"are members of the usergroup. Foreman adds the members when they log " +
+					"in or the external usergroups are refreshed. Defaults to the " +
+					"current external usergroups.",
+			},
+			"refresh_triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Arbitrary map of values that, when changed, refreshes the " +
+					"members of the external usergroups from their authentication " +
+					"sources, ie: `{ members = timestamp() }` to refresh on every apply.",
+			},
 		},
 	}
 }
@@ -84,9 +166,23 @@ func buildForemanUsergroup(d *schema.ResourceData) *api.ForemanUsergroup {
 
 	usergroup.Admin = d.Get("admin").(bool)
 
+	usergroup.UserIds = buildForemanIds(d, "user_ids")
+	usergroup.UsergroupIds = buildForemanIds(d, "usergroup_ids")
+	usergroup.RoleIds = buildForemanIds(d, "role_ids")
+
 	return &usergroup
 }
 
+// buildForemanExternalUsergroup constructs a ForemanExternalUsergroup struct
+// from an element of the "external_usergroup" set
+func buildForemanExternalUsergroup(m map[string]interface{}) api.ForemanExternalUsergroup {
+	externalUsergroup := api.ForemanExternalUsergroup{}
+	externalUsergroup.Id = m["id"].(int)
+	externalUsergroup.Name = m["name"].(string)
+	externalUsergroup.AuthSourceId = m["auth_source_id"].(int)
+	return externalUsergroup
+}
+
 // setResourceDataFromForemanUsergroup sets a ResourceData's attributes from
 // the attributes of the supplied ForemanUsergroup struct
 func setResourceDataFromForemanUsergroup(d *schema.ResourceData, fh *api.ForemanUsergroup) {
@@ -95,6 +191,60 @@ func setResourceDataFromForemanUsergroup(d *schema.ResourceData, fh *api.Foreman
 	d.SetId(strconv.Itoa(fh.Id))
 	d.Set("name", fh.Name)
 	d.Set("admin", fh.Admin)
+	d.Set("user_ids", fh.UserIds)
+	d.Set("usergroup_ids", fh.UsergroupIds)
+	d.Set("role_ids", fh.RoleIds)
+
+	// External usergroups missing in the API response are left untouched
+	if fh.ExternalUsergroups != nil {
+		externalUsergroups := make([]interface{}, len(fh.ExternalUsergroups))
+		for idx, externalUsergroup := range fh.ExternalUsergroups {
+			externalUsergroups[idx] = map[string]interface{}{
+				"id":             externalUsergroup.Id,
+				"name":           externalUsergroup.Name,
+				"auth_source_id": externalUsergroup.AuthSourceId,
+			}
+		}
+		d.Set("external_usergroup", externalUsergroups)
+	}
+}
+
+// updateForemanExternalUsergroups removes the external usergroups which were
+// removed from the "external_usergroup" set and creates the ones which were
+// added.  Returns the external usergroups of the usergroup.
+func updateForemanExternalUsergroups(ctx context.Context, d *schema.ResourceData, client *api.Client, usergroupId int) ([]api.ForemanExternalUsergroup, error) {
+	log.Tracef("resource_foreman_usergroup.go#updateForemanExternalUsergroups")
+
+	oldVal, newVal := d.GetChange("external_usergroup")
+	oldSet := oldVal.(*schema.Set)
+	newSet := newVal.(*schema.Set)
+
+	externalUsergroups := []api.ForemanExternalUsergroup{}
+	// Unchanged elements of the old set still carry their ID
+	for _, item := range oldSet.Intersection(newSet).List() {
+		externalUsergroups = append(externalUsergroups, buildForemanExternalUsergroup(item.(map[string]interface{})))
+	}
+
+	for _, item := range oldSet.Difference(newSet).List() {
+		removed := buildForemanExternalUsergroup(item.(map[string]interface{}))
+		log.Debugf("Removing ForemanExternalUsergroup: [%+v]", removed)
+		deleteErr := client.DeleteExternalUsergroup(ctx, usergroupId, removed.Id)
+		if deleteErr != nil && !api.IsNotFound(deleteErr) {
+			return externalUsergroups, deleteErr
+		}
+	}
+
+	for _, item := range newSet.Difference(oldSet).List() {
+		added := buildForemanExternalUsergroup(item.(map[string]interface{}))
+		log.Debugf("Adding ForemanExternalUsergroup: [%+v]", added)
+		created, createErr := client.CreateExternalUsergroup(ctx, usergroupId, &added)
+		if createErr != nil {
+			return externalUsergroups, createErr
+		}
+		externalUsergroups = append(externalUsergroups, *created)
+	}
+
+	return externalUsergroups, nil
 }
 
 // -----------------------------------------------------------------------------
@@ -111,13 +261,21 @@ func resourceForemanUsergroupCreate(ctx context.Context, d *schema.ResourceData,
 
 	createdUsergroup, createErr := client.CreateUsergroup(ctx, h)
 	if createErr != nil {
-		return diag.FromErr(createErr)
+		return api.DiagnosticsFromError(d, createErr)
 	}
 
 	log.Debugf("Created ForemanUsergroup: [%+v]", createdUsergroup)
 
+	// External usergroups can only be added once the usergroup exists
+	externalUsergroups, externalErr := updateForemanExternalUsergroups(ctx, d, client, createdUsergroup.Id)
+	createdUsergroup.ExternalUsergroups = externalUsergroups
+
 	setResourceDataFromForemanUsergroup(d, createdUsergroup)
 
+	if externalErr != nil {
+		return api.DiagnosticsFromError(d, externalErr)
+	}
+
 	return nil
 }
 
@@ -156,11 +314,37 @@ func resourceForemanUsergroupUpdate(ctx context.Context, d *schema.ResourceData,
 
 	updatedUsergroup, updateErr := client.UpdateUsergroup(ctx, h)
 	if updateErr != nil {
-		return diag.FromErr(updateErr)
+		return api.DiagnosticsFromError(d, updateErr)
 	}
 
 	log.Debugf("Updated ForemanUsergroup: [%+v]", updatedUsergroup)
 
+	if d.HasChange("external_usergroup") {
+		externalUsergroups, externalErr := updateForemanExternalUsergroups(ctx, d, client, updatedUsergroup.Id)
+		if externalErr != nil {
+			return api.DiagnosticsFromError(d, externalErr)
+		}
+		updatedUsergroup.ExternalUsergroups = externalUsergroups
+	}
+
+	if d.HasChange("refresh_triggers") {
+		for _, item := range d.Get("external_usergroup").(*schema.Set).List() {
+			externalUsergroup := buildForemanExternalUsergroup(item.(map[string]interface{}))
+			log.Debugf("Refreshing ForemanExternalUsergroup: [%+v]", externalUsergroup)
+			refreshErr := client.RefreshExternalUsergroup(ctx, updatedUsergroup.Id, externalUsergroup.Id)
+			if refreshErr != nil {
+				return api.DiagnosticsFromError(d, refreshErr)
+			}
+		}
+
+		// The refresh changes the members of the usergroup
+		readUsergroup, readErr := client.ReadUsergroup(ctx, updatedUsergroup.Id)
+		if readErr != nil {
+			return api.DiagnosticsFromError(d, readErr)
+		}
+		updatedUsergroup = readUsergroup
+	}
+
 	setResourceDataFromForemanUsergroup(d, updatedUsergroup)
 
 	return nil
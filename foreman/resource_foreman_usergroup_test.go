@@ -1,10 +1,13 @@
 package foreman
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"math/rand"
 	"net/http"
 	"reflect"
+	"sort"
 	"strconv"
 	"testing"
 
@@ -119,6 +122,44 @@ func TestUsergroupUnmarshalJSON_ForemanObject(t *testing.T) {
 
 }
 
+// Ensures the JSON unmarshal decodes the members, roles and external
+// usergroups from the nested objects of the API response
+func TestUsergroupUnmarshalJSON_Members(t *testing.T) {
+
+	usergroupJSON := []byte(`{
+		"id": 3,
+		"name": "admins",
+		"users": [{"id": 4, "login": "admin"}, {"id": 7, "login": "jdoe"}],
+		"usergroups": [{"id": 2, "name": "ops"}],
+		"roles": [{"id": 9, "name": "Viewer"}],
+		"external_usergroups": [
+			{"id": 1, "name": "foreman-admins", "auth_source": {"id": 5, "name": "ldap"}}
+		]
+	}`)
+
+	var obj api.ForemanUsergroup
+	jsonDecErr := json.Unmarshal(usergroupJSON, &obj)
+	if jsonDecErr != nil {
+		t.Fatalf("ForemanUsergroup UnmarshalJSON failed: [%s]", jsonDecErr)
+	}
+
+	expectedExternal := api.ForemanExternalUsergroup{AuthSourceId: 5}
+	expectedExternal.Id = 1
+	expectedExternal.Name = "foreman-admins"
+
+	if !reflect.DeepEqual(obj.UserIds, []int{4, 7}) ||
+		!reflect.DeepEqual(obj.UsergroupIds, []int{2}) ||
+		!reflect.DeepEqual(obj.RoleIds, []int{9}) ||
+		!reflect.DeepEqual(obj.ExternalUsergroups, []api.ForemanExternalUsergroup{expectedExternal}) {
+		t.Errorf(
+			"ForemanUsergroup UnmarshalJSON did not properly decode the members. "+
+				"Got [%+v]",
+			obj,
+		)
+	}
+
+}
+
 // -----------------------------------------------------------------------------
 // buildForemanUsergroup
 // -----------------------------------------------------------------------------
@@ -161,6 +202,144 @@ func TestSetResourceDataFromForemanUsergroup_Value(t *testing.T) {
 
 }
 
+// -----------------------------------------------------------------------------
+// External Usergroups
+// -----------------------------------------------------------------------------
+
+// Ensures added external usergroups are created, removed ones are deleted and
+// changed refresh triggers refresh the external usergroups of the usergroup
+func TestResourceForemanUsergroupUpdate_ExternalUsergroups(t *testing.T) {
+
+	obj := RandForemanUsergroup()
+	obj.Id = rand.Intn(100) + 1
+	usergroupsURIById := UsergroupsURI + "/" + strconv.Itoa(obj.Id)
+	externalUsergroupsURI := usergroupsURIById + "/external_usergroups"
+
+	admins := map[string]interface{}{"id": 7, "name": "admins", "auth_source_id": 1}
+	devs := map[string]interface{}{"id": 8, "name": "devs", "auth_source_id": 1}
+
+	testCases := []struct {
+		name         string
+		oldGroups    []interface{}
+		newGroups    []interface{}
+		oldTriggers  map[string]interface{}
+		newTriggers  map[string]interface{}
+		expectedURIs []ExpectedUri
+		expectedIds  []int
+	}{
+		{
+			name:      "adding an external usergroup",
+			oldGroups: []interface{}{admins},
+			newGroups: []interface{}{admins, devs},
+			expectedURIs: []ExpectedUri{
+				{expectedURI: usergroupsURIById, expectedMethod: http.MethodPut},
+				{expectedURI: externalUsergroupsURI, expectedMethod: http.MethodPost},
+			},
+			expectedIds: []int{7, 8},
+		},
+		{
+			name:      "removing an external usergroup",
+			oldGroups: []interface{}{admins, devs},
+			newGroups: []interface{}{admins},
+			expectedURIs: []ExpectedUri{
+				{expectedURI: usergroupsURIById, expectedMethod: http.MethodPut},
+				{expectedURI: externalUsergroupsURI + "/8", expectedMethod: http.MethodDelete},
+			},
+			expectedIds: []int{7},
+		},
+		{
+			name:        "refreshing the external usergroups",
+			oldGroups:   []interface{}{admins},
+			newGroups:   []interface{}{admins},
+			oldTriggers: map[string]interface{}{"members": "1"},
+			newTriggers: map[string]interface{}{"members": "2"},
+			expectedURIs: []ExpectedUri{
+				{expectedURI: usergroupsURIById, expectedMethod: http.MethodPut},
+				{expectedURI: externalUsergroupsURI + "/7/refresh", expectedMethod: http.MethodPut},
+				{expectedURI: usergroupsURIById, expectedMethod: http.MethodGet},
+			},
+			expectedIds: []int{7},
+		},
+	}
+
+	for _, testCase := range testCases {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+
+		actualURIs := []ExpectedUri{}
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			actualURIs = append(actualURIs, ExpectedUri{
+				expectedURI:    r.URL.Path,
+				expectedMethod: r.Method,
+			})
+			switch {
+			case r.URL.Path == externalUsergroupsURI && r.Method == http.MethodPost:
+				fmt.Fprint(w, `{"id": 8, "name": "devs", "auth_source_id": 1}`)
+			case r.URL.Path == usergroupsURIById && r.Method == http.MethodGet:
+				fmt.Fprintf(
+					w,
+					`{"id": %d, "name": "%s", "external_usergroups": [{"id": 7, "name": "admins", "auth_source": {"id": 1}}]}`,
+					obj.Id,
+					obj.Name,
+				)
+			case r.URL.Path == usergroupsURIById:
+				fmt.Fprintf(w, `{"id": %d, "name": "%s"}`, obj.Id, obj.Name)
+			default:
+				w.Write([]byte(`{}`))
+			}
+		})
+
+		r := resourceForemanUsergroup()
+		oldResourceData := MockForemanUsergroupResourceData(ForemanUsergroupToInstanceState(obj))
+		oldResourceData.Set("external_usergroup", testCase.oldGroups)
+		oldResourceData.Set("refresh_triggers", testCase.oldTriggers)
+		s := oldResourceData.State()
+
+		newGroups := []interface{}{}
+		for _, group := range testCase.newGroups {
+			newGroups = append(newGroups, map[string]interface{}{
+				"name":           group.(map[string]interface{})["name"],
+				"auth_source_id": group.(map[string]interface{})["auth_source_id"],
+			})
+		}
+		config := map[string]interface{}{
+			"name":               obj.Name,
+			"external_usergroup": newGroups,
+		}
+		if testCase.newTriggers != nil {
+			config["refresh_triggers"] = testCase.newTriggers
+		}
+		diff, diffErr := r.Diff(context.TODO(), s, terraform.NewResourceConfigRaw(config), nil)
+		if diffErr != nil {
+			t.Fatalf("resourceForemanUsergroup could not diff %s: %s", testCase.name, diffErr)
+		}
+		resourceData, _ := schema.InternalMap(r.Schema).Data(s, diff)
+
+		diags := resourceForemanUsergroupUpdate(context.TODO(), resourceData, client)
+		server.Close()
+
+		actualIds := []int{}
+		for _, item := range resourceData.Get("external_usergroup").(*schema.Set).List() {
+			actualIds = append(actualIds, item.(map[string]interface{})["id"].(int))
+		}
+		sort.Ints(actualIds)
+
+		if diags.HasError() || !reflect.DeepEqual(actualURIs, testCase.expectedURIs) ||
+			!reflect.DeepEqual(actualIds, testCase.expectedIds) {
+			t.Errorf(
+				"resourceForemanUsergroupUpdate %s called %+v and set the external "+
+					"usergroups %v with diagnostics [%+v]. Expected %+v and %v.",
+				testCase.name,
+				actualURIs,
+				actualIds,
+				diags,
+				testCase.expectedURIs,
+				testCase.expectedIds,
+			)
+		}
+	}
+
+}
+
 // ----------------------------------------------------------------------------
 // Test Cases for the Unit Test Framework
 // ----------------------------------------------------------------------------
@@ -1,11 +1,18 @@
 package foreman
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
 	"strconv"
+	"strings"
 
+	"github.com/HanseMerkur/terraform-provider-utils/conv"
 	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // buildForemanObject constructs a base ForemanObject reference from a
@@ -41,3 +48,305 @@ func buildForemanObject(d *schema.ResourceData) *api.ForemanObject {
 
 	return &obj
 }
+
+// buildForemanIds returns the IDs of a set attribute.  Returns nil if the
+// attribute is neither set nor was changed - an empty slice if all IDs were
+// removed from the set.
+func buildForemanIds(d *schema.ResourceData, key string) []int {
+	if attr, ok := d.GetOk(key); ok {
+		return conv.InterfaceSliceToIntSlice(attr.(*schema.Set).List())
+	}
+	if d.HasChange(key) {
+		return []int{}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Taxonomies
+// -----------------------------------------------------------------------------
+
+// locationIdsSchema returns the schema of the "location_ids" attribute of
+// resources for taxable objects
+func locationIdsSchema(objectName string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeInt,
+		},
+		Description: fmt.Sprintf(
+			"IDs of the locations the %s is assigned to. Overrides the "+
+				"provider's `location_id`. Defaults to the location of the provider.",
+			objectName,
+		),
+	}
+}
+
+// organizationIdsSchema returns the schema of the "organization_ids"
+// attribute of resources for taxable objects
+func organizationIdsSchema(objectName string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeInt,
+		},
+		Description: fmt.Sprintf(
+			"IDs of the organizations the %s is assigned to. Overrides the "+
+				"provider's `organization_id`. Defaults to the organization of the "+
+				"provider.",
+			objectName,
+		),
+	}
+}
+
+// dataSourceLocationIdsSchema returns the schema of the "location_ids"
+// attribute of data sources for taxable objects
+func dataSourceLocationIdsSchema(objectName string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeInt,
+		},
+		Description: fmt.Sprintf("IDs of the locations the %s is assigned to.", objectName),
+	}
+}
+
+// dataSourceOrganizationIdsSchema returns the schema of the
+// "organization_ids" attribute of data sources for taxable objects
+func dataSourceOrganizationIdsSchema(objectName string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeInt,
+		},
+		Description: fmt.Sprintf("IDs of the organizations the %s is assigned to.", objectName),
+	}
+}
+
+// isConfigured reports whether the attribute is set in the configuration of
+// the resource.  Attributes computed by Foreman are only part of the state.
+// Without a known configuration, the attribute is treated as configured.
+func isConfigured(d *schema.ResourceData, key string) bool {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return true
+	}
+	return !config.GetAttr(key).IsNull()
+}
+
+// buildForemanTaxonomies constructs a ForemanTaxonomies struct from the
+// "location_ids" and "organization_ids" attributes of a ResourceData
+// reference.  Taxonomies which are not configured for the resource are left
+// nil, so that the provider's defaults apply - even if Foreman reported
+// assignments which were read into the state.
+func buildForemanTaxonomies(d *schema.ResourceData) api.ForemanTaxonomies {
+	var ft api.ForemanTaxonomies
+	if isConfigured(d, "location_ids") {
+		ft.LocationIds = buildForemanIds(d, "location_ids")
+	}
+	if isConfigured(d, "organization_ids") {
+		ft.OrganizationIds = buildForemanIds(d, "organization_ids")
+	}
+	return ft
+}
+
+// setResourceDataFromForemanTaxonomies sets the "location_ids" and
+// "organization_ids" attributes of a ResourceData reference.  Taxonomies
+// missing in the API response are left untouched.
+func setResourceDataFromForemanTaxonomies(d *schema.ResourceData, ft api.ForemanTaxonomies) {
+	if ft.LocationIds != nil {
+		d.Set("location_ids", ft.LocationIds)
+	}
+	if ft.OrganizationIds != nil {
+		d.Set("organization_ids", ft.OrganizationIds)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Parameters
+// -----------------------------------------------------------------------------
+
+// parameterTypeSchema returns the schema of the "parameter_type" attribute of
+// parameter resources
+func parameterTypeSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      api.ParameterTypeString,
+		ValidateFunc: validation.StringInSlice(api.ParameterTypes, false),
+		Description: fmt.Sprintf(
+			"Type Foreman casts the value to. One of `%s`. The value is validated "+
+				"against the type: `\"true\"` or `\"false\"` for booleans, a number "+
+				"for integers and reals and JSON for arrays, hashes and json. YAML "+
+				"values are not validated - use JSON, ie: `jsonencode()`, to avoid "+
+				"differences in formatting. Defaults to `\"string\"`.",
+			strings.Join(api.ParameterTypes, "`, `"),
+		),
+	}
+}
+
+// parameterTypeOrDefault returns the parameter type read from Foreman or the
+// default type if Foreman omits it
+func parameterTypeOrDefault(parameterType string) string {
+	if parameterType == "" {
+		return api.ParameterTypeString
+	}
+	return parameterType
+}
+
+// parameterTypesSchema returns the schema of the "parameter_types" attribute
+// which accompanies the inline "parameters" map of a resource
+func parameterTypesSchema(objectName string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+		ValidateFunc: func(i interface{}, k string) (warnings []string, errs []error) {
+			for name, parameterType := range i.(map[string]interface{}) {
+				_, typeErrs := validation.StringInSlice(api.ParameterTypes, false)(parameterType, k+"."+name)
+				errs = append(errs, typeErrs...)
+			}
+			return warnings, errs
+		},
+		// Parameters of the default type are not read back.  The number of
+		// types differs then as well, changed types show up on their own.
+		DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+			if strings.HasSuffix(k, ".%") {
+				return true
+			}
+			return (oldValue == "" || oldValue == api.ParameterTypeString) &&
+				(newValue == "" || newValue == api.ParameterTypeString)
+		},
+		Description: fmt.Sprintf(
+			"A map of the types of the %s's `parameters`, see the `parameter_type` "+
+				"of `foreman_parameter` for the possible types. Parameters missing in "+
+				"this map are strings.",
+			objectName,
+		),
+	}
+}
+
+// validateParameterValue returns an error if Foreman cannot cast the value to
+// the parameter type
+func validateParameterValue(parameterType string, value string) error {
+	var err error
+	switch parameterType {
+	case api.ParameterTypeBoolean:
+		_, err = strconv.ParseBool(value)
+	case api.ParameterTypeInteger:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case api.ParameterTypeReal:
+		_, err = strconv.ParseFloat(value, 64)
+	case api.ParameterTypeArray:
+		var arr []interface{}
+		if json.Unmarshal([]byte(value), &arr) != nil {
+			err = fmt.Errorf("expected a JSON array")
+		}
+	case api.ParameterTypeHash:
+		var hash map[string]interface{}
+		if json.Unmarshal([]byte(value), &hash) != nil {
+			err = fmt.Errorf("expected a JSON object")
+		}
+	case api.ParameterTypeJSON:
+		if !json.Valid([]byte(value)) {
+			err = fmt.Errorf("expected JSON")
+		}
+	}
+	return err
+}
+
+// parameterValuesEqual reports whether both values are equal once Foreman
+// casts them to the parameter type.  Foreman returns typed values, ie: "1"
+// is read back as "true" for booleans and JSON is read back compacted.
+func parameterValuesEqual(parameterType string, a string, b string) bool {
+	if a == b {
+		return true
+	}
+
+	switch parameterType {
+	case api.ParameterTypeBoolean:
+		boolA, errA := strconv.ParseBool(a)
+		boolB, errB := strconv.ParseBool(b)
+		return errA == nil && errB == nil && boolA == boolB
+	case api.ParameterTypeInteger, api.ParameterTypeReal:
+		floatA, errA := strconv.ParseFloat(a, 64)
+		floatB, errB := strconv.ParseFloat(b, 64)
+		return errA == nil && errB == nil && floatA == floatB
+	case api.ParameterTypeArray, api.ParameterTypeHash, api.ParameterTypeJSON,
+		api.ParameterTypeYAML:
+		var objA, objB interface{}
+		if json.Unmarshal([]byte(a), &objA) != nil || json.Unmarshal([]byte(b), &objB) != nil {
+			return false
+		}
+		return reflect.DeepEqual(objA, objB)
+	}
+	return false
+}
+
+// parameterValueDiffSuppressFunc suppresses differences of the "value"
+// attribute which are equal for the "parameter_type" of the resource
+func parameterValueDiffSuppressFunc(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	return parameterValuesEqual(d.Get("parameter_type").(string), oldValue, newValue)
+}
+
+// parametersDiffSuppressFunc suppresses differences of the values in the
+// inline "parameters" map which are equal for their type in the
+// "parameter_types" map
+func parametersDiffSuppressFunc(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	name := strings.TrimPrefix(k, "parameters.")
+	parameterType, _ := d.Get("parameter_types").(map[string]interface{})[name].(string)
+	return parameterValuesEqual(parameterType, oldValue, newValue)
+}
+
+// resourceParameterCustomizeDiffValue validates the "value" attribute
+// against the "parameter_type" attribute when planning
+func resourceParameterCustomizeDiffValue(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("value") || !d.NewValueKnown("parameter_type") {
+		return nil
+	}
+
+	parameterType := d.Get("parameter_type").(string)
+	if err := validateParameterValue(parameterType, d.Get("value").(string)); err != nil {
+		return fmt.Errorf(
+			"value of parameter [%s] is not a valid %s: %w",
+			d.Get("name"),
+			parameterType,
+			err,
+		)
+	}
+	return nil
+}
+
+// resourceParametersCustomizeDiffValues validates the values of the inline
+// "parameters" map against the types in the "parameter_types" map when
+// planning
+func resourceParametersCustomizeDiffValues(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("parameters") || !d.NewValueKnown("parameter_types") {
+		return nil
+	}
+
+	parameters := d.Get("parameters").(map[string]interface{})
+	for name, parameterType := range d.Get("parameter_types").(map[string]interface{}) {
+		value, ok := parameters[name].(string)
+		if !ok {
+			return fmt.Errorf("parameter_types contains parameter [%s] which is missing in parameters", name)
+		}
+		if err := validateParameterValue(parameterType.(string), value); err != nil {
+			return fmt.Errorf(
+				"value of parameter [%s] is not a valid %s: %w",
+				name,
+				parameterType,
+				err,
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package foreman
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/terraform-coop/terraform-provider-foreman/foreman/api"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// -----------------------------------------------------------------------------
+// Parameters
+// -----------------------------------------------------------------------------
+
+// Ensures parameter values are validated against their type
+func TestValidateParameterValue(t *testing.T) {
+	testCases := []struct {
+		parameterType string
+		value         string
+		valid         bool
+	}{
+		{parameterType: api.ParameterTypeString, value: "anything", valid: true},
+		{parameterType: api.ParameterTypeBoolean, value: "true", valid: true},
+		{parameterType: api.ParameterTypeBoolean, value: "yes please", valid: false},
+		{parameterType: api.ParameterTypeInteger, value: "42", valid: true},
+		{parameterType: api.ParameterTypeInteger, value: "4.2", valid: false},
+		{parameterType: api.ParameterTypeReal, value: "4.2", valid: true},
+		{parameterType: api.ParameterTypeArray, value: `["a", "b"]`, valid: true},
+		{parameterType: api.ParameterTypeArray, value: `{"a": "b"}`, valid: false},
+		{parameterType: api.ParameterTypeHash, value: `{"a": "b"}`, valid: true},
+		{parameterType: api.ParameterTypeHash, value: "a: b", valid: false},
+		{parameterType: api.ParameterTypeJSON, value: `"a"`, valid: true},
+		{parameterType: api.ParameterTypeJSON, value: "{", valid: false},
+		{parameterType: api.ParameterTypeYAML, value: "a: b", valid: true},
+	}
+
+	for _, testCase := range testCases {
+		err := validateParameterValue(testCase.parameterType, testCase.value)
+		if (err == nil) != testCase.valid {
+			t.Fatalf(
+				"validateParameterValue did not validate the %s value [%s]. "+
+					"Expected valid [%t], got error [%v].",
+				testCase.parameterType,
+				testCase.value,
+				testCase.valid,
+				err,
+			)
+		}
+	}
+}
+
+// Ensures values which Foreman casts to the same value are considered equal
+func TestParameterValuesEqual(t *testing.T) {
+	testCases := []struct {
+		parameterType string
+		a             string
+		b             string
+		equal         bool
+	}{
+		{parameterType: api.ParameterTypeString, a: "1", b: "01", equal: false},
+		{parameterType: api.ParameterTypeBoolean, a: "1", b: "true", equal: true},
+		{parameterType: api.ParameterTypeInteger, a: "01", b: "1", equal: true},
+		{parameterType: api.ParameterTypeHash, a: `{"b":1,"a":2}`, b: "{\"a\": 2, \"b\": 1}", equal: true},
+		{parameterType: api.ParameterTypeArray, a: `[1,2]`, b: `[2,1]`, equal: false},
+	}
+
+	for _, testCase := range testCases {
+		if parameterValuesEqual(testCase.parameterType, testCase.a, testCase.b) != testCase.equal {
+			t.Fatalf(
+				"parameterValuesEqual did not compare the %s values [%s] and [%s]. "+
+					"Expected [%t].",
+				testCase.parameterType,
+				testCase.a,
+				testCase.b,
+				testCase.equal,
+			)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Taxonomies
+// -----------------------------------------------------------------------------
+
+// Ensures the taxonomies read from Foreman do not cause a diff - whether they
+// are configured or not - and taxonomies which are not configured do not
+// override the provider's defaults
+func TestForemanTaxonomies_ReadWithoutDiff(t *testing.T) {
+	obj := RandForemanMedia()
+	obj.OperatingSystemIds = nil
+
+	cred := api.ClientCredentials{}
+	conf := api.ClientConfig{}
+	mux, server, client := NewForemanAPIAndClient(cred, conf)
+	defer server.Close()
+
+	mux.HandleFunc(MediasURI+"/"+strconv.Itoa(obj.Id), func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"id": ` + strconv.Itoa(obj.Id) + `,
+			"name": "` + obj.Name + `",
+			"path": "` + obj.Path + `",
+			"os_family": "` + obj.OSFamily + `",
+			"locations": [{"id": 7}, {"id": 2}],
+			"organizations": [{"id": 1}]
+		}`))
+	})
+
+	r := resourceForemanMedia()
+	resourceData := MockForemanMediaResourceData(ForemanMediaToInstanceState(obj))
+	if diags := resourceForemanMediaRead(context.TODO(), resourceData, client); diags.HasError() {
+		t.Fatalf("resourceForemanMediaRead returned an error: [%+v]", diags)
+	}
+	s := resourceData.State()
+
+	configs := []map[string]interface{}{
+		{
+			"name":      obj.Name,
+			"path":      obj.Path,
+			"os_family": obj.OSFamily,
+		},
+		{
+			"name":             obj.Name,
+			"path":             obj.Path,
+			"os_family":        obj.OSFamily,
+			"location_ids":     []interface{}{2, 7},
+			"organization_ids": []interface{}{1},
+		},
+	}
+
+	for _, config := range configs {
+		diff, diffErr := r.Diff(context.TODO(), s, terraform.NewResourceConfigRaw(config), nil)
+		if diffErr != nil {
+			t.Fatalf("resourceForemanMedia could not diff the taxonomies: %s", diffErr)
+		}
+		if !diff.Empty() {
+			t.Fatalf(
+				"The taxonomies read by resourceForemanMediaRead caused a diff "+
+					"for the configuration [%v]: [%+v].",
+				config,
+				diff.Attributes,
+			)
+		}
+
+	}
+
+	// the configuration without taxonomies, as seen during apply
+	configType := r.CoreConfigSchema().ImpliedType()
+	configAttrs := map[string]cty.Value{}
+	for key, attrType := range configType.AttributeTypes() {
+		configAttrs[key] = cty.NullVal(attrType)
+	}
+	configAttrs["name"] = cty.StringVal(obj.Name)
+	configAttrs["path"] = cty.StringVal(obj.Path)
+	configAttrs["os_family"] = cty.StringVal(obj.OSFamily)
+	diff := &terraform.InstanceDiff{RawConfig: cty.ObjectVal(configAttrs)}
+	resourceData, _ = schema.InternalMap(r.Schema).Data(s, diff)
+
+	ft := buildForemanTaxonomies(resourceData)
+	if ft.LocationIds != nil || ft.OrganizationIds != nil {
+		t.Fatalf(
+			"buildForemanTaxonomies overrode the provider's defaults with the "+
+				"taxonomies read from Foreman: [%+v].",
+			ft,
+		)
+	}
+}
+
+// Ensures the data sources of taxable objects read the object found by their
+// search, as the search results do not include the taxonomies
+func TestDataSources_ReadTaxonomies(t *testing.T) {
+	testCases := []struct {
+		funcName   string
+		dataSource *schema.Resource
+		uri        string
+	}{
+		{"dataSourceForemanAuthSourceLDAPRead", dataSourceForemanAuthSourceLDAP(), AuthSourceLDAPsURI},
+		{"dataSourceForemanComputeResourceRead", dataSourceForemanComputeResource(), ComputeResourcesURI},
+		{"dataSourceForemanEnvironmentRead", dataSourceForemanEnvironment(), EnvironmentsURI},
+		{"dataSourceForemanHostgroupRead", dataSourceForemanHostgroup(), HostgroupsURI},
+		{"dataSourceForemanHTTPProxyRead", dataSourceForemanHTTPProxy(), HTTPProxiesURI},
+		{"dataSourceForemanMediaRead", dataSourceForemanMedia(), MediasURI},
+		{"dataSourceForemanPartitionTableRead", dataSourceForemanPartitionTable(), PartitionTablesURI},
+		{"dataSourceForemanProvisioningTemplateRead", dataSourceForemanProvisioningTemplate(), ProvisioningTemplatesURI},
+		{"dataSourceForemanRoleRead", dataSourceForemanRole(), RolesURI},
+		{"dataSourceForemanSmartProxyRead", dataSourceForemanSmartProxy(), SmartProxiesURI},
+	}
+
+	for _, testCase := range testCases {
+		mux, server, client := NewForemanAPIAndClient(api.ClientCredentials{}, api.ClientConfig{})
+
+		mux.HandleFunc(testCase.uri, func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{
+				"total": 1, "subtotal": 1, "page": 1, "per_page": 20,
+				"results": [{"id": 5, "name": "test"}]
+			}`))
+		})
+		mux.HandleFunc(testCase.uri+"/5", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{
+				"id": 5,
+				"name": "test",
+				"locations": [{"id": 7}, {"id": 2}],
+				"organizations": [{"id": 1}]
+			}`))
+		})
+
+		resourceData := testCase.dataSource.Data(&terraform.InstanceState{
+			Attributes: map[string]string{"name": "test"},
+		})
+		diags := testCase.dataSource.ReadContext(context.TODO(), resourceData, client)
+		server.Close()
+
+		locationIds := resourceData.Get("location_ids").(*schema.Set)
+		organizationIds := resourceData.Get("organization_ids").(*schema.Set)
+		if diags.HasError() || locationIds.Len() != 2 || !locationIds.Contains(7) ||
+			!locationIds.Contains(2) || organizationIds.Len() != 1 || !organizationIds.Contains(1) {
+			t.Errorf(
+				"%s read the locations %v and organizations %v with diagnostics "+
+					"[%+v]. Expected [2 7] and [1].",
+				testCase.funcName,
+				locationIds.List(),
+				organizationIds.List(),
+				diags,
+			)
+		}
+	}
+}